@@ -0,0 +1,89 @@
+package goterm
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AdaptiveColor is a pair of colors for terminals with light and dark
+// backgrounds
+// Resolve it when drawing with Screen.Resolve, so the same theme stays
+// legible on both white and black terminals.
+type AdaptiveColor struct {
+	Light Color // Used on light backgrounds
+	Dark  Color // Used on dark backgrounds
+}
+
+// Color returns the color for a dark or light background
+func (a AdaptiveColor) Color(dark bool) Color {
+	if dark {
+		return a.Dark
+	}
+	return a.Light
+}
+
+// Resolve returns the variant of the adaptive color that suits the screen's
+// background
+func (s *Screen) Resolve(a AdaptiveColor) Color {
+	return a.Color(s.DarkBackground())
+}
+
+// DarkBackground reports whether the terminal is assumed to have a dark
+// background
+// Init detects it with DetectDarkBackground; screens created with NewScreen
+// assume a dark background.
+func (s *Screen) DarkBackground() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dark
+}
+
+// SetDarkBackground overrides the detected terminal background, e.g. from a
+// user setting
+func (s *Screen) SetDarkBackground(dark bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dark = dark
+}
+
+// DetectDarkBackground guesses whether the terminal has a dark background
+// It reads the COLORFGBG variable set by rxvt, Konsole, iTerm2 and others,
+// whose last field is the ANSI index of the background color. Without it,
+// or if it cannot be parsed, a dark background is assumed as the most
+// common setup.
+func DetectDarkBackground() bool {
+	return darkFromColorFGBG(os.Getenv("COLORFGBG"))
+}
+
+// darkFromColorFGBG interprets a COLORFGBG value such as "15;0" or
+// "0;default;15"
+func darkFromColorFGBG(value string) bool {
+	fields := strings.Split(value, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return true
+	}
+	r, g, b := paletteRGB(uint8(bg)) // #nosec G115
+	return luminance(r, g, b) < 0.5
+}
+
+// luminance returns the approximate relative brightness of an RGB color,
+// from 0 for black to 1 for white
+func luminance(r, g, b uint8) float64 {
+	return (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 255
+}
+
+// backdrop returns the color translucent colors are blended with when
+// nothing is beneath them: the theme background, or black or white if that
+// is the terminal default
+// Must be called with the screen lock held.
+func (s *Screen) backdrop() Color {
+	if _, _, _, ok := s.theme.Background.resolveRGB(); ok {
+		return s.theme.Background
+	}
+	if s.dark {
+		return ColorRGB(0, 0, 0)
+	}
+	return ColorRGB(255, 255, 255)
+}
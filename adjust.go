@@ -0,0 +1,83 @@
+package goterm
+
+import "math"
+
+// HSL returns the hue in degrees, saturation and lightness of the color
+// Palette colors use their standard RGB values; the default color reports
+// all zeros.
+func (c Color) HSL() (h, s, l float64) {
+	r8, g8, b8, ok := c.resolveRGB()
+	if !ok {
+		return 0, 0, 0
+	}
+	r, g, b := float64(r8)/255, float64(g8)/255, float64(b8)/255
+	hi, lo := max(r, g, b), min(r, g, b)
+	l = (hi + lo) / 2
+	if hi == lo {
+		return 0, 0, l
+	}
+
+	d := hi - lo
+	s = d / (1 - math.Abs(2*l-1))
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+// HSV returns the hue in degrees, saturation and value of the color, the
+// inverse of ColorHSV
+// Palette colors use their standard RGB values; the default color reports
+// all zeros.
+func (c Color) HSV() (h, s, v float64) {
+	h, sl, l := c.HSL()
+	v = l + sl*min(l, 1-l)
+	if v > 0 {
+		s = 2 * (1 - l/v)
+	}
+	return h, s, v
+}
+
+// Lighten returns the color with its HSL lightness raised by amount, e.g.
+// 0.1 for ten percentage points, for hover and focus variants
+// The result is a true color; the default color is returned unchanged.
+func (c Color) Lighten(amount float64) Color {
+	return c.adjustHSL(0, amount)
+}
+
+// Darken returns the color with its HSL lightness lowered by amount
+// The result is a true color; the default color is returned unchanged.
+func (c Color) Darken(amount float64) Color {
+	return c.adjustHSL(0, -amount)
+}
+
+// Saturate returns the color with its HSL saturation raised by amount;
+// a negative amount desaturates, e.g. for disabled variants
+// The result is a true color; the default color is returned unchanged.
+func (c Color) Saturate(amount float64) Color {
+	return c.adjustHSL(amount, 0)
+}
+
+// Grayscale returns the gray with the same perceived brightness as the color
+// The default color is returned unchanged.
+func (c Color) Grayscale() Color {
+	if _, _, _, ok := c.resolveRGB(); !ok {
+		return c
+	}
+	gray := unitToByte(linearToSRGB(c.Luminance()))
+	return ColorRGB(gray, gray, gray)
+}
+
+// adjustHSL shifts the saturation and lightness of the color
+func (c Color) adjustHSL(ds, dl float64) Color {
+	if _, _, _, ok := c.resolveRGB(); !ok {
+		return c
+	}
+	h, s, l := c.HSL()
+	return ColorHSL(h, s+ds, l+dl)
+}
@@ -0,0 +1,114 @@
+package goterm
+
+// HAlign is the horizontal alignment of text within a rectangle
+type HAlign int
+
+// Horizontal alignment constants
+const (
+	AlignLeft HAlign = iota
+	AlignCenter
+	AlignRight
+)
+
+// VAlign is the vertical alignment of text within a rectangle
+type VAlign int
+
+// Vertical alignment constants
+const (
+	AlignTop VAlign = iota
+	AlignMiddle
+	AlignBottom
+)
+
+// DrawTextAligned draws text wrapped to the width of rect and aligned inside it
+// Each line is placed left, centered or right according to halign, and the
+// block of lines top, middle or bottom according to valign. Anything that
+// does not fit is clipped to rect.
+func (s *Screen) DrawTextAligned(rect Rect, text string, halign HAlign, valign VAlign, fg, bg Color, style Style) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawTextAligned(rect, text, halign, valign, fg, bg, style)
+}
+
+// DrawTextAligned draws text wrapped to the width of rect and aligned inside it
+// See Screen.DrawTextAligned.
+func (b *Buffer) DrawTextAligned(rect Rect, text string, halign HAlign, valign VAlign, fg, bg Color, style Style) {
+	lines := Wrap(text, rect.W)
+	if len(lines) == 0 || rect.Empty() {
+		return
+	}
+
+	y := rect.Y
+	switch valign {
+	case AlignMiddle:
+		y += (rect.H - len(lines)) / 2
+	case AlignBottom:
+		y += rect.H - len(lines)
+	}
+
+	b.withClip(rect, func() {
+		for i, line := range lines {
+			x := rect.X
+			switch halign {
+			case AlignCenter:
+				x += (rect.W - StringWidth(line)) / 2
+			case AlignRight:
+				x += rect.W - StringWidth(line)
+			}
+			b.DrawText(x, y+i, line, fg, bg, style)
+		}
+	})
+}
+
+// Anchor returns the rectangle of a w×h element placed in rect according to
+// halign and valign, kept margin cells away from the edges of rect
+// Recomputing the position from the current screen rectangle on every frame
+// keeps floating elements such as clocks, badges and frame counters in
+// their corner as the terminal is resized. The element is shrunk if it
+// does not fit within the margins.
+func Anchor(rect Rect, w, h int, halign HAlign, valign VAlign, margin int) Rect {
+	inner := rect.Inset(InsetsAll(max(0, margin)))
+	r := Rect{X: inner.X, Y: inner.Y, W: max(0, min(w, inner.W)), H: max(0, min(h, inner.H))}
+	switch halign {
+	case AlignCenter:
+		r.X += (inner.W - r.W) / 2
+	case AlignRight:
+		r.X += inner.W - r.W
+	}
+	switch valign {
+	case AlignMiddle:
+		r.Y += (inner.H - r.H) / 2
+	case AlignBottom:
+		r.Y += inner.H - r.H
+	}
+	return r
+}
+
+// AlignTopLeft returns a w×h rectangle in the top-left corner of rect,
+// margin cells from its edges
+func AlignTopLeft(rect Rect, w, h, margin int) Rect {
+	return Anchor(rect, w, h, AlignLeft, AlignTop, margin)
+}
+
+// AlignTopRight returns a w×h rectangle in the top-right corner of rect,
+// margin cells from its edges
+func AlignTopRight(rect Rect, w, h, margin int) Rect {
+	return Anchor(rect, w, h, AlignRight, AlignTop, margin)
+}
+
+// AlignBottomLeft returns a w×h rectangle in the bottom-left corner of
+// rect, margin cells from its edges
+func AlignBottomLeft(rect Rect, w, h, margin int) Rect {
+	return Anchor(rect, w, h, AlignLeft, AlignBottom, margin)
+}
+
+// AlignBottomRight returns a w×h rectangle in the bottom-right corner of
+// rect, margin cells from its edges
+func AlignBottomRight(rect Rect, w, h, margin int) Rect {
+	return Anchor(rect, w, h, AlignRight, AlignBottom, margin)
+}
+
+// Centered returns a w×h rectangle in the middle of rect
+func Centered(rect Rect, w, h int) Rect {
+	return Anchor(rect, w, h, AlignCenter, AlignMiddle, 0)
+}
@@ -0,0 +1,437 @@
+package goterm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Terminal modes an App turns on while it runs
+const (
+	mouseOn  = "\x1b[?1000h\x1b[?1002h\x1b[?1006h" // Clicks, drags and SGR reports
+	mouseOff = "\x1b[?1006l\x1b[?1002l\x1b[?1000l"
+	pasteOn  = "\x1b[?2004h"
+	pasteOff = "\x1b[?2004l"
+)
+
+// escapeDelay is how long a lone Escape waits for the rest of a sequence
+// before it is taken as the Escape key
+const escapeDelay = 50 * time.Millisecond
+
+// Msg is a message for the event loop of an App: an Event, or any value the
+// application posts to itself
+type Msg any
+
+// EventHandler is anything that handles events, such as a widget or a
+// Keymap
+type EventHandler interface {
+	// HandleEvent reports whether ev was used
+	HandleEvent(ev Event) bool
+}
+
+// Middleware wraps the handling of a message by an App
+// It calls next to pass msg on, possibly changed, or does not to drop it.
+// Middleware runs on the event loop.
+type Middleware func(msg Msg, next func(Msg))
+
+// FrameStats describes a frame drawn by an App
+type FrameStats struct {
+	Frame    uint64        // Number of the frame, from 1
+	Time     time.Time     // When the frame started
+	Interval time.Duration // Time since the previous frame, as passed to Update
+	Update   time.Duration // Time spent in Update
+	Draw     time.Duration // Time spent clearing the screen, in the layouts and in Draw
+	Show     time.Duration // Time spent writing the frame to the terminal
+	Changed  int           // Cells that Show found changed since the previous frame
+	Bytes    int64         // Bytes written to the terminal by Show
+	Queued   int           // Messages waiting in the queue when the frame started
+}
+
+// App runs the main loop of a terminal application: it reads and decodes
+// input, dispatches events, follows changes in the terminal size, and
+// updates and redraws the screen at a steady frame rate
+// Set the fields and call Run:
+//
+//	app := &goterm.App{
+//		Update: func(dt time.Duration) { game.Step(dt) },
+//		Draw:   func(s *goterm.Screen) { game.Render(s) },
+//	}
+//	if err := app.Run(context.Background()); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Each event is offered to OnEvent and then to Handlers in order until one
+// uses it; Ctrl+C that nothing uses quits. Resizes are applied to the screen
+// with HandleResize before they are dispatched. Every frame the screen's
+// layouts are redrawn with Relayout before Draw is called, so widgets placed
+// by layouts show the events they used. Messages other than events,
+// posted with Post, go to OnMessage. Events, messages, Update and Draw are
+// all handled on the goroutine running Run, so they need no locking between
+// them.
+type App struct {
+	Screen    *Screen                // Initialized with Init, and closed afterwards, if nil
+	FPS       int                    // Frames per second; defaults to 30
+	Mouse     bool                   // Report mouse clicks, drags and the wheel
+	Input     io.Reader              // Defaults to os.Stdin
+	OnEvent   func(ev Event) bool    // Offered every event first; reports whether it was used
+	Handlers  []EventHandler         // Offered the events OnEvent does not use, in order
+	OnMessage func(msg Msg)          // Called with messages posted that are not events
+	Update    func(dt time.Duration) // Called every frame with the time since the last one
+	Draw      func(s *Screen)        // Called every frame after the screen is cleared and its layouts redrawn
+
+	// Middleware wraps the handling of every message, events included, the
+	// first outermost
+	Middleware []Middleware
+	OnInput    func(raw []byte)       // Called with input as read, before it is decoded
+	OnFrame    func(stats FrameStats) // Called after every frame is shown
+
+	// Signals stop the application like Quit; nil means os.Interrupt,
+	// SIGTERM and SIGHUP, and an empty slice none
+	Signals []os.Signal
+
+	once   sync.Once
+	ctx    context.Context
+	cancel context.CancelFunc
+	events chan Msg
+	quit   chan struct{}
+	done   chan struct{}
+	stop   sync.Once
+
+	mu       sync.Mutex
+	teardown []func() // Registered with Defer
+
+	hudOn atomic.Bool
+	hud   hud // Only used by the loop
+}
+
+// init creates the channels, so Post and Quit work before Run
+func (a *App) init() {
+	a.once.Do(func() {
+		a.ctx, a.cancel = context.WithCancel(context.Background())
+		a.events = make(chan Msg, 64)
+		a.quit = make(chan struct{})
+		a.done = make(chan struct{})
+	})
+}
+
+// Run runs the application until Quit is called, a command returns Quit,
+// one of the Signals arrives or ctx is done
+// Before it returns, Run calls the functions registered with Defer and then
+// restores the terminal, which it also does when a callback panics, or a
+// command does, whose panic is raised again on the goroutine running Run.
+// It returns nil when the application stops, or the error that stopped it.
+// An App can only be run once. Reading from standard input cannot be
+// interrupted, so the goroutine reading it lives on until the next key
+// press after Run returns.
+func (a *App) Run(ctx context.Context) (err error) {
+	a.init()
+	defer close(a.done)
+	defer a.cancel()
+
+	screen := a.Screen
+	if screen == nil {
+		if screen, err = Init(); err != nil {
+			return err
+		}
+		a.Screen = screen
+		defer func() {
+			_ = screen.write(screen.imageReset() + "\x1b[0m\x1b[2J\x1b[H\x1b[?25h")
+			if cerr := screen.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("%w: %v", ErrTerminalRestoreFailed, cerr)
+			}
+		}()
+	}
+
+	modes, reset := pasteOn, pasteOff
+	if a.Mouse {
+		modes, reset = modes+mouseOn, mouseOff+reset
+	}
+	if err := screen.write(modes); err != nil {
+		return err
+	}
+	defer func() {
+		if rerr := screen.write(reset); rerr != nil && err == nil {
+			err = rerr
+		}
+	}()
+	defer a.runTeardown()
+
+	signals := make(chan os.Signal, 1)
+	if sigs := a.signals(); len(sigs) > 0 {
+		signal.Notify(signals, sigs...)
+		defer signal.Stop(signals)
+	}
+
+	input := a.Input
+	if input == nil {
+		input = os.Stdin
+	}
+	chunks := make(chan []byte)
+	go a.read(input, chunks)
+
+	fps := a.FPS
+	if fps <= 0 {
+		fps = 30
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(fps))
+	defer ticker.Stop()
+	escape := time.NewTimer(escapeDelay)
+	escape.Stop()
+
+	// Count what each frame writes to the terminal
+	counter := &countingWriter{}
+	screen.mu.Lock()
+	counter.w, screen.out = screen.out, counter
+	screen.mu.Unlock()
+	defer func() {
+		screen.mu.Lock()
+		screen.out = counter.w
+		screen.mu.Unlock()
+	}()
+
+	handle := a.deliver
+	for i := len(a.Middleware) - 1; i >= 0; i-- {
+		mw, next := a.Middleware[i], handle
+		handle = func(msg Msg) { mw(msg, next) }
+	}
+
+	var decoder InputDecoder
+	var frame uint64
+	width, height := screen.Size()
+	last := time.Now()
+	for {
+		// Stop as soon as Quit is called, even if more is waiting
+		select {
+		case <-a.quit:
+			return nil
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-a.quit:
+			return nil
+		case <-signals:
+			return nil
+		case chunk := <-chunks:
+			escape.Stop()
+			if a.OnInput != nil {
+				a.OnInput(chunk)
+			}
+			for _, ev := range decoder.Feed(chunk) {
+				handle(ev)
+			}
+			if decoder.Pending() {
+				escape.Reset(escapeDelay)
+			}
+		case <-escape.C:
+			for _, ev := range decoder.Flush() {
+				handle(ev)
+			}
+		case msg := <-a.events:
+			// Ticks of timers stopped while they were queued are dropped
+			if tick, ok := msg.(TickEvent); ok && !tick.Timer.deliver() {
+				continue
+			}
+			handle(msg)
+		case now := <-ticker.C:
+			frame++
+			stats := FrameStats{Frame: frame, Time: now, Interval: now.Sub(last), Queued: len(a.events)}
+			last = now
+			if w, h, ok := screen.terminalSize(); ok && (w != width || h != height) {
+				width, height = w, h
+				handle(ResizeEvent{Width: w, Height: h})
+			}
+
+			start := time.Now()
+			if a.Update != nil {
+				a.Update(stats.Interval)
+			}
+			stats.Update = time.Since(start)
+
+			start = time.Now()
+			if a.Draw != nil || len(screen.Layouts()) > 0 {
+				screen.Relayout()
+			}
+			if a.Draw != nil {
+				a.Draw(screen)
+			}
+			stats.Draw = time.Since(start)
+			a.drawHUD(screen)
+
+			start = time.Now()
+			written := counter.n.Load()
+			if err := screen.Show(); err != nil {
+				return err
+			}
+			stats.Show = time.Since(start)
+			stats.Bytes = counter.n.Load() - written
+			stats.Changed = screen.lastChanged()
+
+			if a.OnFrame != nil {
+				a.OnFrame(stats)
+			}
+			a.recordHUD(stats)
+		}
+	}
+}
+
+// Defer registers fn to be called when Run returns, before the terminal is
+// restored, to tear down what the application set up
+// The functions are called in the reverse order they were registered, like
+// deferred calls, even when Run returns because of a panic. Defer is safe to
+// call from any goroutine.
+func (a *App) Defer(fn func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.teardown = append(a.teardown, fn)
+}
+
+// runTeardown calls the functions registered with Defer, last first
+func (a *App) runTeardown() {
+	a.mu.Lock()
+	teardown := a.teardown
+	a.teardown = nil
+	a.mu.Unlock()
+	// Deferred, so the others still run if one panics
+	for _, fn := range teardown {
+		defer fn()
+	}
+}
+
+// signals returns the signals that stop the application
+func (a *App) signals() []os.Signal {
+	if a.Signals == nil {
+		return []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+	}
+	return a.Signals
+}
+
+// Quit stops Run at the end of the event or frame being processed
+// It is safe to call from any goroutine, and more than once.
+func (a *App) Quit() {
+	a.init()
+	a.stop.Do(func() { close(a.quit) })
+}
+
+// Post queues msg for the loop: an Event is dispatched like one read from
+// the terminal, and anything else is passed to OnMessage
+// It is safe to call from any goroutine, which makes it the way for work
+// done in the background to hand its results to the application. It waits
+// while the queue is full, and drops msg if the application has stopped.
+func (a *App) Post(msg Msg) {
+	a.init()
+	select {
+	case a.events <- msg:
+	case <-a.done:
+	}
+}
+
+// read sends what is read from r to chunks until reading fails or the
+// application stops
+func (a *App) read(r io.Reader, chunks chan<- []byte) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			select {
+			case chunks <- append([]byte(nil), buf[:n]...):
+			case <-a.done:
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// deliver handles msg at the end of the middleware chain
+func (a *App) deliver(msg Msg) {
+	switch msg := msg.(type) {
+	case quitMsg:
+		a.Quit()
+	case panicMsg:
+		panic(fmt.Sprintf("%v\n\ngoroutine running the command:\n%s", msg.value, msg.stack))
+	case batchMsg:
+		for _, cmd := range msg {
+			a.Go(cmd)
+		}
+	case Event:
+		a.dispatch(msg)
+	default:
+		if a.OnMessage != nil {
+			a.OnMessage(msg)
+		}
+	}
+}
+
+// dispatch offers ev to OnEvent and the handlers until one uses it
+func (a *App) dispatch(ev Event) {
+	switch ev := ev.(type) {
+	case ResizeEvent:
+		// Wipe what the terminal kept of the old layout before redrawing
+		_ = a.Screen.write("\x1b[2J")
+		a.Screen.HandleResize(ev)
+	case ThemeEvent:
+		if ev.Err == nil {
+			a.Screen.SetTheme(ev.Theme)
+			a.Screen.Relayout()
+		}
+	}
+	if a.OnEvent != nil && a.OnEvent(ev) {
+		return
+	}
+	for _, h := range a.Handlers {
+		if h.HandleEvent(ev) {
+			return
+		}
+	}
+	if k, ok := ev.(KeyEvent); ok && k.Key == KeyRune && k.Rune == 'c' && k.Modifiers == ModCtrl {
+		a.Quit()
+	}
+}
+
+// write writes an escape sequence to the terminal
+func (s *Screen) write(seq string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprint(s.out, seq); err != nil {
+		return fmt.Errorf("failed to write to terminal: %w", err)
+	}
+	return nil
+}
+
+// terminalSize returns the size of the terminal the screen was initialized
+// on, and false for a screen not attached to a terminal
+func (s *Screen) terminalSize() (width, height int, ok bool) {
+	if s.oldState == nil {
+		return 0, 0, false
+	}
+	width, height, err := term.GetSize(s.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// countingWriter is a writer that counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,68 @@
+package goterm_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+// runApp runs app on a screen writing nowhere until it quits, and fails if
+// it is still running after a few seconds
+func runApp(t *testing.T, app *goterm.App) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Run() did not quit")
+	}
+}
+
+func TestAppQuitsPastTextArea(t *testing.T) {
+	screen := goterm.NewScreen(20, 5)
+	goterm.SetOutput(screen, io.Discard)
+	area := &widgets.TextArea{}
+	area.SetText("hello")
+	area.SetFocused(true)
+
+	// Ctrl+C with nothing selected is left to the app, which quits
+	runApp(t, &goterm.App{
+		Screen:   screen,
+		FPS:      1000,
+		Input:    strings.NewReader("\x03"),
+		Handlers: []goterm.EventHandler{area},
+	})
+}
+
+func TestAppRedrawsLayouts(t *testing.T) {
+	screen := goterm.NewScreen(20, 5)
+	goterm.SetOutput(screen, io.Discard)
+	area := &widgets.TextArea{}
+	area.SetFocused(true)
+	screen.AddLayout(func(r goterm.Rect) []goterm.Placement {
+		return []goterm.Placement{{Rect: r, Widget: area}}
+	})
+
+	// Without Draw, the layouts are still redrawn every frame, so the key
+	// typed shows up
+	var app *goterm.App
+	app = &goterm.App{
+		Screen:   screen,
+		FPS:      1000,
+		Input:    strings.NewReader("x"),
+		Handlers: []goterm.EventHandler{area},
+		OnFrame: func(goterm.FrameStats) {
+			if screen.GetCell(0, 0).Ch == 'x' {
+				app.Quit()
+			}
+		},
+	}
+	runApp(t, app)
+}
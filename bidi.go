@@ -0,0 +1,244 @@
+package goterm
+
+import (
+	"strings"
+	"unicode"
+)
+
+// bidiClass is a simplified Unicode bidirectional character type
+type bidiClass uint8
+
+const (
+	bidiL  bidiClass = iota // Strong left-to-right
+	bidiR                   // Strong right-to-left (Hebrew and similar)
+	bidiAL                  // Arabic letter
+	bidiEN                  // European number
+	bidiAN                  // Arabic number
+	bidiWS                  // Whitespace
+	bidiON                  // Other neutral
+)
+
+// rtlScripts lists scripts whose letters are strong right-to-left
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko,
+	unicode.Samaritan, unicode.Mandaic,
+}
+
+// classify returns the bidi class of a rune
+func classify(r rune) bidiClass {
+	switch {
+	case r >= '0' && r <= '9':
+		return bidiEN
+	case (r >= 0x0660 && r <= 0x0669) || (r >= 0x06f0 && r <= 0x06f9):
+		return bidiAN
+	case unicode.Is(unicode.Arabic, r) && !unicode.IsMark(r):
+		return bidiAL
+	case unicode.In(r, rtlScripts...) && !unicode.IsMark(r):
+		return bidiR
+	case unicode.IsSpace(r):
+		return bidiWS
+	case unicode.IsLetter(r):
+		return bidiL
+	}
+	return bidiON
+}
+
+// bidiMirrors maps paired punctuation to its mirrored glyph for rule L4
+var bidiMirrors = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{',
+	'<': '>', '>': '<', '«': '»', '»': '«',
+}
+
+// isRTLClass reports whether a class counts as right-to-left when resolving
+// neutrals (numbers adopt the direction of the Arabic/Hebrew text around them)
+func isRTLClass(c bidiClass) bool {
+	return c == bidiR || c == bidiAL || c == bidiAN
+}
+
+// reorderBidi converts a single line of text from logical to visual order
+// following the implicit rules of the Unicode Bidirectional Algorithm
+// (UAX #9): paragraph level from the first strong character, weak type
+// resolution for numbers, neutral resolution, implicit levels, and reversal
+// of runs with bracket mirroring. Explicit embedding controls are not
+// interpreted. Text without right-to-left characters is returned unchanged.
+func reorderBidi(text string) string {
+	var clusters []string
+	var classes []bidiClass
+	hasRTL := false
+	for len(text) > 0 {
+		n := nextCluster(text)
+		cluster := text[:n]
+		text = text[n:]
+
+		c := classify([]rune(cluster)[0])
+		if c == bidiR || c == bidiAL || c == bidiAN {
+			hasRTL = true
+		}
+		clusters = append(clusters, cluster)
+		classes = append(classes, c)
+	}
+	if !hasRTL {
+		return strings.Join(clusters, "")
+	}
+
+	base := paragraphLevel(classes)
+	resolveWeak(classes, base)
+	resolveNeutral(classes, base)
+	levels := implicitLevels(classes, base)
+
+	// Rule L1: trailing whitespace takes the paragraph level
+	for i := len(classes) - 1; i >= 0 && classes[i] == bidiWS; i-- {
+		levels[i] = base
+	}
+
+	// Rule L4: mirror paired punctuation in right-to-left runs
+	for i, cluster := range clusters {
+		if levels[i]%2 == 1 {
+			if m, ok := bidiMirrors[[]rune(cluster)[0]]; ok && len(cluster) == len(string(m)) {
+				clusters[i] = string(m)
+			}
+		}
+	}
+
+	reverseLevels(clusters, levels)
+	return strings.Join(clusters, "")
+}
+
+// paragraphLevel applies rules P2/P3: the first strong character decides
+// whether the paragraph is left-to-right (0) or right-to-left (1)
+func paragraphLevel(classes []bidiClass) int {
+	for _, c := range classes {
+		switch c {
+		case bidiL:
+			return 0
+		case bidiR, bidiAL:
+			return 1
+		}
+	}
+	return 0
+}
+
+// resolveWeak applies simplified weak type rules W2, W3 and W7
+// European numbers following Arabic letters become Arabic numbers, Arabic
+// letters become R, and European numbers in a left-to-right context become L.
+func resolveWeak(classes []bidiClass, base int) {
+	lastStrong := bidiL
+	if base == 1 {
+		lastStrong = bidiR
+	}
+	for i, c := range classes {
+		switch c {
+		case bidiL, bidiR:
+			lastStrong = c
+		case bidiAL:
+			lastStrong = bidiAL
+			classes[i] = bidiR
+		case bidiEN:
+			if lastStrong == bidiAL {
+				classes[i] = bidiAN
+			} else if lastStrong == bidiL {
+				classes[i] = bidiL
+			}
+		}
+	}
+}
+
+// resolveNeutral applies rules N1/N2: a run of neutrals between characters of
+// the same direction takes that direction, otherwise the embedding direction
+func resolveNeutral(classes []bidiClass, base int) {
+	embedding := bidiL
+	if base == 1 {
+		embedding = bidiR
+	}
+
+	for i := 0; i < len(classes); {
+		if classes[i] != bidiWS && classes[i] != bidiON {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(classes) && (classes[i] == bidiWS || classes[i] == bidiON) {
+			i++
+		}
+
+		before, after := embedding, embedding
+		if start > 0 {
+			before = direction(classes[start-1])
+		}
+		if i < len(classes) {
+			after = direction(classes[i])
+		}
+
+		resolved := embedding
+		if before == after {
+			resolved = before
+		}
+		for j := start; j < i; j++ {
+			// Keep whitespace marked so rule L1 can still find it
+			if classes[j] == bidiON || i < len(classes) {
+				classes[j] = resolved
+			}
+		}
+	}
+}
+
+// direction collapses a resolved class to L or R for neutral resolution
+func direction(c bidiClass) bidiClass {
+	if isRTLClass(c) || c == bidiEN {
+		return bidiR
+	}
+	return bidiL
+}
+
+// implicitLevels applies rules I1/I2 to compute an embedding level per cluster
+func implicitLevels(classes []bidiClass, base int) []int {
+	levels := make([]int, len(classes))
+	for i, c := range classes {
+		level := base
+		switch {
+		case base%2 == 0 && c == bidiR:
+			level++
+		case base%2 == 0 && (c == bidiAN || c == bidiEN):
+			level += 2
+		case base%2 == 1 && (c == bidiL || c == bidiAN || c == bidiEN):
+			level++
+		}
+		levels[i] = level
+	}
+	return levels
+}
+
+// reverseLevels applies rule L2: from the highest level down to the lowest
+// odd level, reverse every contiguous run at that level or higher
+func reverseLevels(clusters []string, levels []int) {
+	highest, lowestOdd := 0, -1
+	for _, l := range levels {
+		if l > highest {
+			highest = l
+		}
+		if l%2 == 1 && (lowestOdd < 0 || l < lowestOdd) {
+			lowestOdd = l
+		}
+	}
+	if lowestOdd < 0 {
+		return
+	}
+
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(levels); {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			start := i
+			for i < len(levels) && levels[i] >= level {
+				i++
+			}
+			for a, b := start, i-1; a < b; a, b = a+1, b-1 {
+				clusters[a], clusters[b] = clusters[b], clusters[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+		}
+	}
+}
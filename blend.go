@@ -0,0 +1,82 @@
+package goterm
+
+import "math"
+
+// Blend returns the color t of the way from c to other, mixing each RGB
+// channel
+// t is clamped to [0, 1]: 0 gives c and 1 gives other. Palette colors are
+// mixed using their standard RGB values and the result is always a true
+// color. The terminal's default color has no known RGB value, so blending
+// with it switches from c to other at t = 0.5.
+func (c Color) Blend(other Color, t float64) Color {
+	return c.blend(other, t, false)
+}
+
+// BlendLinear is like Blend but mixes in linear light instead of sRGB
+// Fades between saturated colors stay brighter and avoid the muddy middle
+// of a plain channel mix, at slightly higher cost.
+func (c Color) BlendLinear(other Color, t float64) Color {
+	return c.blend(other, t, true)
+}
+
+// Lerp returns the color t of the way from a to b; it is a.Blend(b, t)
+func Lerp(a, b Color, t float64) Color {
+	return a.Blend(b, t)
+}
+
+// blend mixes c and other in sRGB or linear light
+func (c Color) blend(other Color, t float64, linear bool) Color {
+	t = clamp01(t)
+	r1, g1, b1, ok1 := c.resolveRGB()
+	r2, g2, b2, ok2 := other.resolveRGB()
+	if !ok1 || !ok2 {
+		if t < 0.5 {
+			return c
+		}
+		return other
+	}
+
+	mix := func(a, b uint8) uint8 {
+		if linear {
+			return unitToByte(linearToSRGB(lerp(srgbToLinear(a), srgbToLinear(b), t)))
+		}
+		return unitToByte(lerp(float64(a)/255, float64(b)/255, t))
+	}
+	return ColorRGB(mix(r1, r2), mix(g1, g2), mix(b1, b2))
+}
+
+// resolveRGB returns the RGB value of a true or palette color
+// ok is false for the default color, whose value is up to the terminal.
+func (c Color) resolveRGB() (r, g, b uint8, ok bool) {
+	switch c.mode {
+	case ColorModeTrueColor:
+		return c.r, c.g, c.b, true
+	case ColorMode16, ColorMode256:
+		r, g, b = paletteRGB(c.index)
+		return r, g, b, true
+	}
+	return 0, 0, 0, false
+}
+
+// lerp interpolates linearly between a and b
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// srgbToLinear converts an sRGB channel value to linear light in [0, 1]
+func srgbToLinear(v uint8) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts linear light in [0, 1] to an sRGB channel value in
+// [0, 1]
+func linearToSRGB(f float64) float64 {
+	if f <= 0.0031308 {
+		return f * 12.92
+	}
+	return 1.055*math.Pow(f, 1/2.4) - 0.055
+}
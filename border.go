@@ -0,0 +1,127 @@
+package goterm
+
+// BorderStyle selects the character set used to draw box borders
+type BorderStyle int
+
+// Border style constants
+const (
+	BorderSingle           BorderStyle = iota // ┌─┐ │ └─┘
+	BorderDouble                              // ╔═╗ ║ ╚═╝
+	BorderRounded                             // ╭─╮ │ ╰─╯
+	BorderThick                               // ┏━┓ ┃ ┗━┛
+	BorderASCII                               // +-+ | +-+
+	BorderDoubleHorizontal                    // ╒═╕ │ ╘═╛
+	BorderDoubleVertical                      // ╓─╖ ║ ╙─╜
+)
+
+// BorderChars holds the runes that make up a box border
+type BorderChars struct {
+	TopLeft, TopRight       rune
+	BottomLeft, BottomRight rune
+	Horizontal, Vertical    rune
+}
+
+// borderSets maps each border style to its characters
+var borderSets = map[BorderStyle]BorderChars{
+	BorderSingle:  {'┌', '┐', '└', '┘', '─', '│'},
+	BorderDouble:  {'╔', '╗', '╚', '╝', '═', '║'},
+	BorderRounded: {'╭', '╮', '╰', '╯', '─', '│'},
+	BorderThick:   {'┏', '┓', '┗', '┛', '━', '┃'},
+	BorderASCII:   {'+', '+', '+', '+', '-', '|'},
+
+	BorderDoubleHorizontal: {'╒', '╕', '╘', '╛', '═', '│'},
+	BorderDoubleVertical:   {'╓', '╖', '╙', '╜', '─', '║'},
+}
+
+// Chars returns the border characters for the style
+// Unknown styles fall back to BorderSingle.
+func (b BorderStyle) Chars() BorderChars {
+	if chars, ok := borderSets[b]; ok {
+		return chars
+	}
+	return borderSets[BorderSingle]
+}
+
+// TitlePosition selects where DrawTitledBox places the title on the border
+type TitlePosition int
+
+// Title position constants
+const (
+	TitleTopLeft TitlePosition = iota
+	TitleTopCenter
+	TitleTopRight
+	TitleBottomLeft
+	TitleBottomCenter
+	TitleBottomRight
+)
+
+// DrawBox draws the outline of a w×h box with its top-left corner at (x, y)
+// The interior is left untouched. Boxes smaller than 2×2 are not drawn.
+func (s *Screen) DrawBox(x, y, w, h int, border BorderStyle, fg, bg Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawBox(x, y, w, h, border, fg, bg)
+}
+
+// DrawTitledBox draws a box like DrawBox with a bold title set into the top
+// or bottom border
+// The title is padded with one space on each side and truncated with "…" so
+// it never overwrites the corners.
+func (s *Screen) DrawTitledBox(x, y, w, h int, border BorderStyle, title string, pos TitlePosition, fg, bg Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawTitledBox(x, y, w, h, border, title, pos, fg, bg)
+}
+
+// DrawBox draws the outline of a w×h box with its top-left corner at (x, y)
+// The interior is left untouched. Boxes smaller than 2×2 are not drawn.
+func (b *Buffer) DrawBox(x, y, w, h int, border BorderStyle, fg, bg Color) {
+	if w < 2 || h < 2 {
+		return
+	}
+
+	chars := border.Chars()
+	cell := func(ch rune) Cell { return NewCell(ch, fg, bg, StyleNone) }
+
+	// Edges
+	b.Fill(x+1, y, w-2, 1, cell(chars.Horizontal))
+	b.Fill(x+1, y+h-1, w-2, 1, cell(chars.Horizontal))
+	b.Fill(x, y+1, 1, h-2, cell(chars.Vertical))
+	b.Fill(x+w-1, y+1, 1, h-2, cell(chars.Vertical))
+
+	// Corners
+	b.SetCell(x, y, cell(chars.TopLeft))
+	b.SetCell(x+w-1, y, cell(chars.TopRight))
+	b.SetCell(x, y+h-1, cell(chars.BottomLeft))
+	b.SetCell(x+w-1, y+h-1, cell(chars.BottomRight))
+}
+
+// DrawTitledBox draws a box like DrawBox with a bold title set into the top
+// or bottom border
+// The title is padded with one space on each side and truncated with "…" so
+// it never overwrites the corners or the border cells next to them.
+func (b *Buffer) DrawTitledBox(x, y, w, h int, border BorderStyle, title string, pos TitlePosition, fg, bg Color) {
+	b.DrawBox(x, y, w, h, border, fg, bg)
+	if title == "" || w < 7 || h < 2 {
+		return
+	}
+
+	// Leave the corners and one border cell on each side visible
+	text := " " + Truncate(title, w-6, "…") + " "
+	tw := StringWidth(text)
+
+	tx := x + 2
+	switch pos {
+	case TitleTopCenter, TitleBottomCenter:
+		tx = x + (w-tw)/2
+	case TitleTopRight, TitleBottomRight:
+		tx = x + w - 2 - tw
+	}
+
+	ty := y
+	if pos >= TitleBottomLeft {
+		ty = y + h - 1
+	}
+
+	b.DrawText(tx, ty, text, fg, bg, StyleBold)
+}
@@ -0,0 +1,102 @@
+package goterm
+
+// Insets are the widths in cells of the four sides of a margin, border or
+// padding
+type Insets struct {
+	Top, Right, Bottom, Left int
+}
+
+// InsetsAll returns insets of n cells on every side
+func InsetsAll(n int) Insets {
+	return Insets{n, n, n, n}
+}
+
+// InsetsXY returns insets of x cells on the left and right and y cells on
+// the top and bottom
+// Since cells are about twice as tall as they are wide, InsetsXY(2, 1)
+// looks even on most terminals.
+func InsetsXY(x, y int) Insets {
+	return Insets{y, x, y, x}
+}
+
+// Width returns the total width of the left and right sides
+func (in Insets) Width() int {
+	return in.Left + in.Right
+}
+
+// Height returns the total height of the top and bottom sides
+func (in Insets) Height() int {
+	return in.Top + in.Bottom
+}
+
+// Add returns the insets of in and other together
+func (in Insets) Add(other Insets) Insets {
+	return Insets{in.Top + other.Top, in.Right + other.Right, in.Bottom + other.Bottom, in.Left + other.Left}
+}
+
+// Inset returns the part of r inside in
+// If the sides take up the whole rectangle, the result is empty, with its
+// corner where the top and left sides end, but never beyond r.
+func (r Rect) Inset(in Insets) Rect {
+	w := max(0, r.W)
+	h := max(0, r.H)
+	x := min(in.Left, w)
+	y := min(in.Top, h)
+	return Rect{
+		X: r.X + x,
+		Y: r.Y + y,
+		W: max(0, w-x-in.Right),
+		H: max(0, h-y-in.Bottom),
+	}
+}
+
+// Outset returns r grown by in on every side
+func (r Rect) Outset(in Insets) Rect {
+	return Rect{
+		X: r.X - in.Left,
+		Y: r.Y - in.Top,
+		W: r.W + in.Width(),
+		H: r.H + in.Height(),
+	}
+}
+
+// Box is the space around a widget's content: a margin, an optional border
+// one cell wide, and padding inside the border, from the outside in
+// It does the arithmetic between the rectangle given to a widget, where its
+// border is drawn and where its content goes:
+//
+//	box := goterm.Box{Border: true, Padding: goterm.InsetsXY(1, 0)}
+//	b := box.BorderRect(rect)
+//	screen.DrawBox(b.X, b.Y, b.W, b.H, goterm.BorderRounded, fg, bg)
+//	content := box.ContentRect(rect)
+type Box struct {
+	Margin  Insets // Space outside the border
+	Border  bool   // Whether there is a border between margin and padding
+	Padding Insets // Space between the border and the content
+}
+
+// Insets returns the total width of each side of the box
+func (b Box) Insets() Insets {
+	in := b.Margin.Add(b.Padding)
+	if b.Border {
+		in = in.Add(InsetsAll(1))
+	}
+	return in
+}
+
+// BorderRect returns the rectangle whose outline is the border of a box
+// occupying r, which is r inside the margin
+func (b Box) BorderRect(r Rect) Rect {
+	return r.Inset(b.Margin)
+}
+
+// ContentRect returns the rectangle left for content in a box occupying r
+func (b Box) ContentRect(r Rect) Rect {
+	return r.Inset(b.Insets())
+}
+
+// OuterRect returns the rectangle a box occupies around content, the
+// inverse of ContentRect
+func (b Box) OuterRect(content Rect) Rect {
+	return content.Outset(b.Insets())
+}
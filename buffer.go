@@ -0,0 +1,471 @@
+package goterm
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Buffer is a grid of cells without any terminal state
+// Buffers are used to pre-render panels, sprites and cached widgets that are
+// later stamped onto a Screen with Blit. Unlike Screen, a Buffer is not safe
+// for concurrent use.
+type Buffer struct {
+	width  int
+	height int
+	cells  []Cell
+
+	// Drawing outside clip is discarded; it covers the whole buffer unless
+	// narrowed by PushClip or a View while drawing
+	clip  Rect
+	clips []Rect // Clip regions saved by PushClip
+
+	// Cell used by Clear and Resize for empty cells
+	blank Cell
+
+	// Text layout options used by DrawText
+	text textOptions
+}
+
+// textOptions carries the settings used while laying out text
+type textOptions struct {
+	bidi        bool
+	tabWidth    int
+	unprintable UnprintablePolicy
+	fallback    rune
+}
+
+// NewBuffer creates a buffer of the given dimensions filled with blank cells
+// Panics if width or height are <= 0
+func NewBuffer(width, height int) *Buffer {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Sprintf("invalid buffer dimensions: width=%d, height=%d", width, height))
+	}
+
+	b := &Buffer{
+		width:  width,
+		height: height,
+		cells:  make([]Cell, width*height),
+		text:   textOptions{tabWidth: 8},
+		blank:  NewCell(' ', ColorDefault(), ColorDefault(), StyleNone),
+	}
+	b.clip = b.Bounds()
+	b.Clear()
+
+	return b
+}
+
+// Size returns the buffer dimensions
+func (b *Buffer) Size() (width, height int) {
+	return b.width, b.height
+}
+
+// Bounds returns the rectangle covered by the buffer
+func (b *Buffer) Bounds() Rect {
+	return Rect{W: b.width, H: b.height}
+}
+
+// SetCell sets the cell at the specified position
+// Does nothing if x, y are out of bounds
+func (b *Buffer) SetCell(x, y int, cell Cell) {
+	if !b.clip.Contains(x, y) {
+		return
+	}
+	b.cells[y*b.width+x] = cell
+}
+
+// SetContent sets the cell at the specified position to primary followed by
+// any combining runes
+// A wide character also claims the next column, which is filled with a blank
+// cell; if it does not fit, a blank is set instead. Does nothing if x, y are
+// out of bounds.
+func (b *Buffer) SetContent(x, y int, primary rune, combining []rune, fg, bg Color, style Style) {
+	cell := NewCell(primary, fg, bg, style)
+	cell.Comb = string(combining)
+	if cell.width() == 2 {
+		if !b.clip.Contains(x+1, y) {
+			cell = NewCell(' ', fg, bg, style)
+		} else {
+			b.SetCell(x+1, y, NewCell(' ', fg, bg, style))
+		}
+	}
+	b.SetCell(x, y, cell)
+}
+
+// GetContent returns the runes, colors, style and display width of the cell
+// at the specified position
+func (b *Buffer) GetContent(x, y int) (primary rune, combining []rune, fg, bg Color, style Style, width int) {
+	cell := b.GetCell(x, y)
+	if cell.Comb != "" {
+		combining = []rune(cell.Comb)
+	}
+	return cell.Ch, combining, cell.Fg, cell.Bg, cell.Style, cell.width()
+}
+
+// GetCell returns the cell at the specified position
+// Returns a default empty cell if x, y are out of bounds
+func (b *Buffer) GetCell(x, y int) Cell {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return NewCell(' ', ColorDefault(), ColorDefault(), StyleNone)
+	}
+	return b.cells[y*b.width+x]
+}
+
+// GetRow returns a copy of row y
+// Returns nil if y is out of bounds
+func (b *Buffer) GetRow(y int) []Cell {
+	if y < 0 || y >= b.height {
+		return nil
+	}
+	row := make([]Cell, b.width)
+	copy(row, b.cells[y*b.width:(y+1)*b.width])
+	return row
+}
+
+// CopyInto copies the cells of rect into dst row by row, so the cell at
+// (rect.X+x, rect.Y+y) lands at dst[y*rect.W+x], and returns the number of
+// cells written
+// Positions outside the buffer are written as default empty cells. Copying
+// stops when dst is full.
+func (b *Buffer) CopyInto(dst []Cell, rect Rect) int {
+	if rect.Empty() {
+		return 0
+	}
+	n := min(len(dst), rect.W*rect.H)
+	for i := 0; i < n; i++ {
+		dst[i] = b.GetCell(rect.X+i%rect.W, rect.Y+i/rect.W)
+	}
+	return n
+}
+
+// Clear resets all cells to their default state
+func (b *Buffer) Clear() {
+	for i := range b.cells {
+		b.cells[i] = b.blank
+	}
+}
+
+// Resize changes the buffer dimensions
+// Content is preserved where it fits in the new dimensions
+func (b *Buffer) Resize(width, height int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Create new cells
+	newCells := make([]Cell, width*height)
+	for i := range newCells {
+		newCells[i] = b.blank
+	}
+
+	// Copy existing content that fits
+	minWidth := min(width, b.width)
+	minHeight := min(height, b.height)
+	for y := 0; y < minHeight; y++ {
+		copy(newCells[y*width:y*width+minWidth], b.cells[y*b.width:y*b.width+minWidth])
+	}
+
+	b.width = width
+	b.height = height
+	b.cells = newCells
+	b.clip = b.Bounds()
+	b.clips = nil
+}
+
+// Fill sets every cell of the w×h region at (x, y) to cell
+// The region is clipped to the buffer bounds.
+func (b *Buffer) Fill(x, y, w, h int, cell Cell) {
+	r := Rect{X: x, Y: y, W: w, H: h}.Intersect(b.clip)
+	for row := r.Y; row < r.Y+r.H; row++ {
+		for col := r.X; col < r.X+r.W; col++ {
+			b.cells[row*b.width+col] = cell
+		}
+	}
+}
+
+// FillStyle changes the colors and style of every cell in rect while keeping
+// the characters, e.g. to highlight a panel or selection
+// The region is clipped to the buffer bounds.
+func (b *Buffer) FillStyle(rect Rect, fg, bg Color, style Style) {
+	r := rect.Intersect(b.clip)
+	for row := r.Y; row < r.Y+r.H; row++ {
+		for col := r.X; col < r.X+r.W; col++ {
+			c := &b.cells[row*b.width+col]
+			c.Fg = fg
+			c.Bg = bg
+			c.Style = style
+		}
+	}
+}
+
+// ForEach calls fn for every cell in rect and replaces the cell with the
+// value fn returns
+// The region is clipped to the buffer bounds.
+func (b *Buffer) ForEach(rect Rect, fn func(x, y int, c Cell) Cell) {
+	r := rect.Intersect(b.clip)
+	for y := r.Y; y < r.Y+r.H; y++ {
+		for x := r.X; x < r.X+r.W; x++ {
+			i := y*b.width + x
+			b.cells[i] = fn(x, y, b.cells[i])
+		}
+	}
+}
+
+// Scroll shifts the whole buffer content vertically by dy rows and fills the
+// vacated rows with fill
+// Positive dy moves content up (new rows appear at the bottom, as in a log
+// pane); negative dy moves it down.
+func (b *Buffer) Scroll(dy int, fill Cell) {
+	b.ScrollRegion(b.Bounds(), dy, fill)
+}
+
+// ScrollRegion shifts the content inside rect vertically by dy rows, leaving
+// cells outside rect untouched, and fills the vacated rows with fill
+// The direction of dy is the same as for Scroll.
+func (b *Buffer) ScrollRegion(rect Rect, dy int, fill Cell) {
+	r := rect.Intersect(b.clip)
+	if r.Empty() || dy == 0 {
+		return
+	}
+
+	for i := 0; i < r.H; i++ {
+		// Walk rows in the direction that never reads an already moved row
+		row := r.Y + i
+		if dy < 0 {
+			row = r.Y + r.H - 1 - i
+		}
+
+		dst := b.cells[row*b.width+r.X : row*b.width+r.X+r.W]
+		src := row + dy
+		if src < r.Y || src >= r.Y+r.H {
+			for j := range dst {
+				dst[j] = fill
+			}
+			continue
+		}
+		copy(dst, b.cells[src*b.width+r.X:src*b.width+r.X+r.W])
+	}
+}
+
+// InsertLines inserts n rows filled with fill at row y, shifting the rows
+// below down; rows pushed past the bottom are discarded
+func (b *Buffer) InsertLines(y, n int, fill Cell) {
+	if n > 0 {
+		b.ScrollRegion(Rect{Y: y, W: b.width, H: b.height - y}, -n, fill)
+	}
+}
+
+// DeleteLines removes n rows starting at row y, shifting the rows below up
+// and filling the rows vacated at the bottom with fill
+func (b *Buffer) DeleteLines(y, n int, fill Cell) {
+	if n > 0 {
+		b.ScrollRegion(Rect{Y: y, W: b.width, H: b.height - y}, n, fill)
+	}
+}
+
+// Blit copies the srcRect region of src onto the buffer with its top-left
+// corner at (dstX, dstY)
+// The region is clipped to both buffers and transparent source cells are
+// skipped. src may be the buffer itself.
+func (b *Buffer) Blit(dstX, dstY int, src *Buffer, srcRect Rect) {
+	r := srcRect.Intersect(src.Bounds())
+	dstX += r.X - srcRect.X
+	dstY += r.Y - srcRect.Y
+
+	// Clip against the destination, shifting the source region to match
+	dst := Rect{X: dstX, Y: dstY, W: r.W, H: r.H}.Intersect(b.clip)
+	if dst.Empty() {
+		return
+	}
+	r.X += dst.X - dstX
+	r.Y += dst.Y - dstY
+
+	rows := make([]Cell, dst.W)
+	for i := 0; i < dst.H; i++ {
+		// Copy through a scratch row so overlapping self-blits stay correct
+		row := i
+		if src == b && dst.Y > r.Y {
+			row = dst.H - 1 - i
+		}
+		srcStart := (r.Y+row)*src.width + r.X
+		copy(rows, src.cells[srcStart:srcStart+dst.W])
+		b.overlay(dst.X, dst.Y+row, rows)
+	}
+}
+
+// overlay copies the non-transparent cells of src onto row y from column x
+// A wide character whose second half gets covered keeps only its first half,
+// which is blanked. Translucent colors are blended with the cells beneath.
+func (b *Buffer) overlay(x, y int, src []Cell) {
+	start := y*b.width + x
+	for i, cell := range src {
+		if cell.Transparent() {
+			continue
+		}
+		under := &b.cells[start+i]
+		if cell.Bg.transparency != 0 && cell.Ch == ' ' && cell.Comb == "" {
+			// A translucent blank tints the content beneath it
+			if _, _, _, ok := under.Fg.resolveRGB(); ok {
+				under.Fg = cell.Bg.over(under.Fg)
+			}
+			under.Bg = cell.Bg.over(under.Bg)
+			if cell.Tag != 0 {
+				under.Tag = cell.Tag
+			}
+			if cell.Link != "" {
+				under.Link = cell.Link
+			}
+			continue
+		}
+		cell.Bg = cell.Bg.over(under.Bg)
+		cell.Fg = cell.Fg.over(cell.Bg)
+		if (i == 0 || src[i-1].Transparent()) && b.clip.Contains(x+i-1, y) {
+			if left := &b.cells[start+i-1]; left.width() == 2 {
+				left.Ch, left.Comb = ' ', ""
+			}
+		}
+		*under = cell
+	}
+}
+
+// PushClip restricts all subsequent drawing to rect, within any clip region
+// already active, until the matching PopClip
+// SetCell, DrawText and the other drawing methods silently discard cells
+// outside the clip region. Resize drops all clip regions.
+func (b *Buffer) PushClip(rect Rect) {
+	b.clips = append(b.clips, b.clip)
+	b.clip = rect.Intersect(b.clip)
+}
+
+// PopClip restores the clip region active before the last PushClip
+func (b *Buffer) PopClip() {
+	if len(b.clips) == 0 {
+		return
+	}
+	b.clip = b.clips[len(b.clips)-1]
+	b.clips = b.clips[:len(b.clips)-1]
+}
+
+// withClip runs fn with drawing further restricted to r
+func (b *Buffer) withClip(r Rect, fn func()) {
+	saved := b.clip
+	b.clip = r.Intersect(saved)
+	defer func() { b.clip = saved }()
+	fn()
+}
+
+// DrawText draws text at the specified position with the given colors and style
+// Text is split into grapheme clusters so combining marks and emoji sequences
+// (ZWJ, variation selectors, skin tones, flags) share one cell with their base
+// character; zero-width runes at the start of text attach to the cell left of
+// x. Wide characters occupy two columns; the second column is filled with a
+// blank cell. Text that extends beyond the buffer width is clipped, and a wide
+// character that does not fit in the last column is replaced by a blank.
+//
+// Tabs advance to the next tab stop (counted from x), '\n' (or "\r\n")
+// continues on the next row at column x, and other C0 control characters are
+// drawn as their visible Control Pictures symbol (␀-␟, ␡).
+func (b *Buffer) DrawText(x, y int, text string, fg, bg Color, style Style) {
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if b.text.bidi {
+			line = reorderBidi(line)
+		}
+		b.drawLine(x, y+i, line, fg, bg, style)
+	}
+}
+
+// DrawTextf formats according to a format specifier and draws the result at
+// the specified position like DrawText
+func (b *Buffer) DrawTextf(x, y int, fg, bg Color, style Style, format string, args ...any) {
+	b.DrawText(x, y, fmt.Sprintf(format, args...), fg, bg, style)
+}
+
+// DrawTextWrapped draws text wrapped to the given width starting at (x, y)
+// using Wrap, and returns the number of lines drawn
+func (b *Buffer) DrawTextWrapped(x, y, width int, text string, fg, bg Color, style Style) int {
+	lines := Wrap(text, width)
+	for i, line := range lines {
+		b.DrawText(x, y+i, line, fg, bg, style)
+	}
+	return len(lines)
+}
+
+// drawLine draws a single line of text without newlines for DrawText
+func (b *Buffer) drawLine(x, y int, text string, fg, bg Color, style Style) {
+	b.drawRun(x, x, y, text, fg, bg, style)
+}
+
+// drawRun draws text without newlines starting at column col and returns the
+// column after it
+// Tab stops are counted from column x, the start of the line.
+func (b *Buffer) drawRun(x, col, y int, text string, fg, bg Color, style Style) int {
+	for len(text) > 0 {
+		n := nextCluster(text)
+		cluster := text[:n]
+		text = text[n:]
+
+		if cluster == "\t" {
+			next := x + ((col-x)/b.text.tabWidth+1)*b.text.tabWidth
+			for ; col < next; col++ {
+				b.SetCell(col, y, NewCell(' ', fg, bg, style))
+			}
+			continue
+		}
+		cluster = controlPicture(cluster)
+		if isUnprintable(cluster) {
+			// Substitutes may span several cells, so draw them as text
+			col += b.drawSubstitute(col, y, replaceUnprintable(cluster, b.text.unprintable, b.text.fallback), fg, bg, style)
+			continue
+		}
+
+		w := clusterWidth(cluster)
+		if w == 0 {
+			b.attachToPrevious(col, y, cluster)
+			continue
+		}
+		if w == 2 && col+1 >= b.clip.X+b.clip.W {
+			b.SetCell(col, y, NewCell(' ', fg, bg, style))
+			col += w
+			continue
+		}
+
+		ch, size := utf8.DecodeRuneInString(cluster)
+		cell := NewCell(ch, fg, bg, style)
+		cell.Comb = cluster[size:]
+		b.SetCell(col, y, cell)
+		for i := 1; i < w; i++ {
+			b.SetCell(col+i, y, NewCell(' ', fg, bg, style))
+		}
+		col += w
+	}
+	return col
+}
+
+// drawSubstitute draws the replacement for an unprintable rune at (col, y)
+// and returns the number of columns used
+func (b *Buffer) drawSubstitute(col, y int, text string, fg, bg Color, style Style) int {
+	w := 0
+	for _, r := range text {
+		b.SetCell(col+w, y, NewCell(r, fg, bg, style))
+		if RuneWidth(r) == 2 {
+			w++
+			b.SetCell(col+w, y, NewCell(' ', fg, bg, style))
+		}
+		w++
+	}
+	return w
+}
+
+// attachToPrevious appends zero-width runes to the cell before column col,
+// stepping over the blank second half of a wide character
+func (b *Buffer) attachToPrevious(col, y int, comb string) {
+	prev := col - 1
+	if !b.clip.Contains(prev, y) {
+		return
+	}
+	if prev > b.clip.X && b.cells[y*b.width+prev-1].width() == 2 {
+		prev--
+	}
+	b.cells[y*b.width+prev].Comb += comb
+}
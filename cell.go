@@ -2,10 +2,13 @@ package goterm
 
 // Cell represents a single character cell in the terminal screen buffer
 type Cell struct {
-	Ch    rune  // Character to display
-	Fg    Color // Foreground color
-	Bg    Color // Background color
-	Style Style // Text styling flags
+	Ch    rune   // Character to display
+	Comb  string // Runes rendered together with Ch (emoji sequences, combining marks)
+	Fg    Color  // Foreground color
+	Bg    Color  // Background color
+	Style Style  // Text styling flags
+	Tag   int    // Application-defined ID for hit-testing; 0 if untagged
+	Link  string // URL opened by clicking the cell in terminals with OSC 8 support; "" for none
 }
 
 // NewCell creates a new cell with the specified attributes
@@ -18,18 +21,64 @@ func NewCell(ch rune, fg, bg Color, style Style) Cell {
 	}
 }
 
+// CellTransparent returns the transparent cell
+// Transparent cells are skipped by Blit and layer compositing so the content
+// underneath shows through, which allows non-rectangular popups, shadows and
+// sprites. The transparent cell is the zero Cell (rune 0); Show renders it as
+// a blank.
+func CellTransparent() Cell {
+	return Cell{}
+}
+
+// Transparent reports whether the cell lets the content below show through
+func (c Cell) Transparent() bool {
+	return c.Ch == 0
+}
+
 // Clear resets the cell to default (space character, default colors, no style)
 func (c *Cell) Clear() {
 	c.Ch = ' '
+	c.Comb = ""
 	c.Fg = ColorDefault()
 	c.Bg = ColorDefault()
 	c.Style = StyleNone
+	c.Tag = 0
+	c.Link = ""
 }
 
 // Equal checks if two cells are identical
 func (c Cell) Equal(other Cell) bool {
 	return c.Ch == other.Ch &&
+		c.Comb == other.Comb &&
 		c.Fg == other.Fg &&
 		c.Bg == other.Bg &&
-		c.Style == other.Style
+		c.Style == other.Style &&
+		c.Tag == other.Tag &&
+		c.Link == other.Link
+}
+
+// resolveReverse replaces StyleReverse with swapped colors when both colors
+// are explicit
+// Terminals disagree on how reverse video treats the default colors, and some
+// render it unreadably; explicit colors display the same everywhere. Cells
+// using a default color keep StyleReverse, since only the terminal knows it.
+func (c Cell) resolveReverse() Cell {
+	if c.Style.Has(StyleReverse) && c.Fg.Mode() != ColorModeDefault && c.Bg.Mode() != ColorModeDefault {
+		c.Fg, c.Bg = c.Bg, c.Fg
+		c.Style = c.Style.Clear(StyleReverse)
+	}
+	return c
+}
+
+// text returns the full grapheme cluster displayed by the cell
+func (c Cell) text() string {
+	return string(c.Ch) + c.Comb
+}
+
+// width returns the number of columns the cell's content occupies
+func (c Cell) width() int {
+	if c.Comb == "" {
+		return RuneWidth(c.Ch)
+	}
+	return clusterWidth(c.text())
 }
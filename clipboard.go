@@ -0,0 +1,20 @@
+package goterm
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// SetClipboard asks the terminal to place text on the system clipboard
+// It uses the OSC 52 escape sequence, which most modern terminals support,
+// some only once enabled in their settings; others ignore it. This works over
+// SSH, where the application has no access to the local clipboard.
+func (s *Screen) SetClipboard(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if _, err := fmt.Fprint(s.out, seq); err != nil {
+		return fmt.Errorf("failed to set clipboard: %w", err)
+	}
+	return nil
+}
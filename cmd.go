@@ -0,0 +1,87 @@
+package goterm
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// Cmd is work to do off the event loop, such as a request over the network
+// or reading a file, that produces a message for the loop
+// Commands run with App.Go on goroutines of their own, so they must not
+// touch application state; they return what they found instead, and the
+// message they return, if not nil, is handled on the event loop like any
+// other. Define a type for each kind of result and handle it with a type
+// switch in App.OnMessage or Model.Update:
+//
+//	type pageLoaded struct {
+//		body []byte
+//		err  error
+//	}
+//
+//	app.Go(func() goterm.Msg {
+//		body, err := fetch(app.Context(), url)
+//		return pageLoaded{body, err}
+//	})
+type Cmd func() Msg
+
+// quitMsg is the message of the Quit command
+type quitMsg struct{}
+
+// batchMsg is the message of a Batch command
+type batchMsg []Cmd
+
+// panicMsg carries a panic in a command to the event loop
+type panicMsg struct {
+	value any
+	stack []byte
+}
+
+// Quit is a Cmd that stops the App running it
+func Quit() Msg {
+	return quitMsg{}
+}
+
+// Batch returns a Cmd that runs cmds concurrently, each delivering its own
+// message
+// Nil commands are ignored.
+func Batch(cmds ...Cmd) Cmd {
+	return func() Msg {
+		return batchMsg(cmds)
+	}
+}
+
+// Go runs cmd on a new goroutine and posts the message it returns to the
+// event loop
+// It is safe to call from any goroutine. A nil cmd does nothing, and the
+// message of a command that finishes after the application has stopped is
+// dropped. If cmd panics, the panic is raised again on the event loop, so
+// Run restores the terminal before the program dies.
+func (a *App) Go(cmd Cmd) {
+	if cmd == nil {
+		return
+	}
+	a.init()
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				// Panic on the loop, which restores the terminal as it
+				// unwinds, or here if it has stopped already
+				select {
+				case a.events <- panicMsg{value: v, stack: debug.Stack()}:
+				case <-a.done:
+					panic(v)
+				}
+			}
+		}()
+		if msg := cmd(); msg != nil {
+			a.Post(msg)
+		}
+	}()
+}
+
+// Context returns a context that is canceled when Run returns, for commands
+// to give up work whose result is no longer wanted
+func (a *App) Context() context.Context {
+	a.init()
+	return a.ctx
+}
@@ -1,6 +1,11 @@
 package goterm
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
 
 // ColorMode represents the color capability mode
 type ColorMode int
@@ -21,6 +26,8 @@ type Color struct {
 	mode    ColorMode
 	r, g, b uint8 // RGB values for truecolor
 	index   uint8 // Palette index for 16/256-color modes
+
+	transparency uint8 // 255 minus the alpha of ColorRGBA, 0 for opaque colors
 }
 
 // ColorDefault returns the terminal's default color
@@ -39,6 +46,21 @@ func ColorRGB(r, g, b uint8) Color {
 	}
 }
 
+// ColorRGBA creates a translucent true color with alpha a, from 0 (fully
+// transparent) to 255 (opaque)
+// A translucent color is blended with the content beneath it when a layer,
+// Blit or DrawSprite puts it over other cells; a blank cell with a
+// translucent background tints the cells below instead of covering them,
+// which gives scrims behind modals and soft shadows. Translucent colors
+// that are still unresolved when the screen is shown are blended with the
+// theme's Background, or with black or white depending on the terminal
+// background if that is the default color.
+func ColorRGBA(r, g, b, a uint8) Color {
+	c := ColorRGB(r, g, b)
+	c.transparency = 255 - a
+	return c
+}
+
 // ColorIndex creates an indexed color (0-255)
 // Ranges:
 //   - 0-7: Basic ANSI colors
@@ -56,6 +78,127 @@ func ColorIndex(index uint8) Color {
 	}
 }
 
+// ColorHex creates a true color from a hex string in "#RRGGBB" or the
+// short "#RGB" form
+// The leading '#' is optional and digits are case-insensitive. Returns an
+// error wrapping ErrInvalidColor if s is not a valid hex color.
+func ColorHex(s string) (Color, error) {
+	digits := strings.TrimPrefix(s, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) != 6 {
+		return Color{}, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+	return ColorRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil // #nosec G115
+}
+
+// ParseColor parses a color specification as found in configuration files
+// Accepted forms are "default" (or an empty string), a hex color such as
+// "#ff8000" or "#f80", a palette index from "0" to "255", and a CSS/X11
+// color name such as "orchid". Returns an error wrapping ErrInvalidColor for
+// anything else.
+func ParseColor(s string) (Color, error) {
+	spec := strings.TrimSpace(s)
+	switch {
+	case spec == "" || strings.EqualFold(spec, "default"):
+		return ColorDefault(), nil
+	case strings.HasPrefix(spec, "#"):
+		return ColorHex(spec)
+	}
+	if strings.Trim(spec, "0123456789") == "" {
+		n, err := strconv.ParseUint(spec, 10, 8)
+		if err != nil {
+			return Color{}, fmt.Errorf("%w: palette index %q out of range", ErrInvalidColor, s)
+		}
+		return ColorIndex(uint8(n)), nil
+	}
+	if c, err := ColorByName(spec); err == nil {
+		return c, nil
+	}
+	if c, err := ColorHex(spec); err == nil {
+		return c, nil
+	}
+	return Color{}, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+}
+
+// MustColorHex is like ColorHex but panics if s is not a valid hex color
+// Intended for color literals in themes and package-level variables.
+func MustColorHex(s string) Color {
+	c, err := ColorHex(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ColorHSV creates a true color from hue, saturation and value
+// h is the hue in degrees (0 red, 120 green, 240 blue) and wraps around, so
+// any value is accepted; s and v are clamped to [0, 1]. Stepping the hue is
+// the easiest way to produce rainbows and evenly spaced palettes.
+func ColorHSV(h, s, v float64) Color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = clamp01(s)
+	v = clamp01(v)
+
+	sector := h / 60
+	i := int(sector)
+	f := sector - float64(i)
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+
+	var r, g, b float64
+	switch i % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	case 5:
+		r, g, b = v, p, q
+	}
+	return ColorRGB(unitToByte(r), unitToByte(g), unitToByte(b))
+}
+
+// ColorHSL creates a true color from hue, saturation and lightness
+// h is the hue in degrees as for ColorHSV; s and l are clamped to [0, 1].
+// Lightness 0.5 gives the pure hue, 0 black and 1 white.
+func ColorHSL(h, s, l float64) Color {
+	s = clamp01(s)
+	l = clamp01(l)
+
+	v := l + s*min(l, 1-l)
+	sv := 0.0
+	if v > 0 {
+		sv = 2 * (1 - l/v)
+	}
+	return ColorHSV(h, sv, v)
+}
+
+// clamp01 limits f to the range [0, 1]
+func clamp01(f float64) float64 {
+	return max(0, min(1, f))
+}
+
+// unitToByte converts a channel value in [0, 1] to 0-255
+func unitToByte(f float64) uint8 {
+	return uint8(math.Round(clamp01(f) * 255))
+}
+
 // Named color constants (ANSI colors)
 var (
 	ColorBlack   = ColorIndex(0)
@@ -78,60 +221,145 @@ func (c Color) RGB() (r, g, b uint8) {
 	return c.r, c.g, c.b
 }
 
+// Alpha returns the opacity of the color, 255 for all but translucent
+// ColorRGBA colors
+func (c Color) Alpha() uint8 {
+	return 255 - c.transparency
+}
+
+// over returns the color composited over below
+// Opaque colors are returned as they are, and so are translucent colors
+// over a color without a known RGB value, to be resolved later.
+func (c Color) over(below Color) Color {
+	if c.transparency == 0 {
+		return c
+	}
+	if _, _, _, ok := below.resolveRGB(); !ok {
+		return c
+	}
+	return below.Blend(ColorRGB(c.r, c.g, c.b), float64(c.Alpha())/255)
+}
+
 // Index returns the palette index (only valid for 16/256-color modes)
 func (c Color) Index() uint8 {
 	return c.index
 }
 
+// String returns a readable description of the color: "default",
+// "ansi(1)" for the 16 ANSI colors, "ansi256(208)" for the 256-color palette
+// or "rgb(255,128,0)" for true colors and "rgba(255,128,0,64)" for
+// translucent ones
+func (c Color) String() string {
+	switch c.mode {
+	case ColorMode16:
+		return fmt.Sprintf("ansi(%d)", c.index)
+	case ColorMode256:
+		return fmt.Sprintf("ansi256(%d)", c.index)
+	case ColorModeTrueColor:
+		if c.transparency != 0 {
+			return fmt.Sprintf("rgba(%d,%d,%d,%d)", c.r, c.g, c.b, c.Alpha())
+		}
+		return fmt.Sprintf("rgb(%d,%d,%d)", c.r, c.g, c.b)
+	}
+	return "default"
+}
+
 // To256 converts RGB color to nearest 256-color palette index
+// Both the nearest entry of the 6x6x6 color cube and of the 24-step
+// grayscale ramp are considered and the closer one wins, so near-gray colors
+// keep their brightness instead of snapping to the coarse cube grays.
 func (c Color) To256() Color {
 	if c.mode != ColorModeTrueColor {
 		return c
 	}
 
-	// Convert RGB to 216-color cube (6x6x6)
-	// Formula: 16 + 36*r + 6*g + b where r,g,b are in range 0-5
-	// r, g, b are in range 0-5, so maximum value is:
-	// 16 + 36*5 + 6*5 + 5 = 16 + 180 + 30 + 5 = 231, which fits in uint8
-	r := (int(c.r) * 6) / 256
-	g := (int(c.g) * 6) / 256
-	b := (int(c.b) * 6) / 256
+	// Cube index: 16 + 36*r + 6*g + b with r, g, b in 0-5, at most 231
+	cube := uint8(16 + 36*cubeLevel(c.r) + 6*cubeLevel(c.g) + cubeLevel(c.b)) // #nosec G115
+
+	// Grayscale ramp: 232-255 with values 8, 18, ..., 238
+	avg := (int(c.r) + int(c.g) + int(c.b)) / 3
+	gray := uint8(232 + max(0, min(23, (avg-3)/10))) // #nosec G115
+
+	target := rgbToLab(c.r, c.g, c.b)
+	if deltaE2000(target, paletteLab[gray]) < deltaE2000(target, paletteLab[cube]) {
+		return ColorIndex(gray)
+	}
+	return ColorIndex(cube)
+}
 
-	// Safe conversion: result is always in range [16, 231]
-	index := uint8(16 + 36*r + 6*g + b) // #nosec G115
-	return ColorIndex(index)
+// cubeLevel returns the index (0-5) of the color cube level nearest to v
+func cubeLevel(v uint8) int {
+	switch {
+	case v < 48:
+		return 0
+	case v < 115:
+		return 1
+	}
+	return (int(v) - 35) / 40
 }
 
-// To16 converts color to nearest ANSI 16-color
+// To16 converts color to the nearest ANSI 16-color, including the bright
+// variants
+// The match is by perceptual distance to the standard xterm palette, so e.g.
+// orange becomes yellow rather than an unrelated color. The default color is
+// returned unchanged.
 func (c Color) To16() Color {
-	if c.mode == ColorMode16 {
+	switch c.mode {
+	case ColorMode16, ColorModeDefault:
 		return c
+	case ColorMode256:
+		r, g, b := paletteRGB(c.index)
+		return ColorIndex(nearestIndex(r, g, b, 0, 16))
 	}
+	return ColorIndex(nearestIndex(c.r, c.g, c.b, 0, 16))
+}
 
-	// Convert to 256-color first if needed
-	col := c
-	if c.mode == ColorModeTrueColor {
-		col = c.To256()
+// ToRGB converts a palette color to the true color of its standard xterm
+// RGB value
+// True colors and the default color are returned unchanged.
+func (c Color) ToRGB() Color {
+	if c.mode != ColorMode16 && c.mode != ColorMode256 {
+		return c
 	}
+	return ColorRGB(paletteRGB(c.index))
+}
+
+// ansi16RGB holds the xterm default RGB values of the 16 ANSI colors
+var ansi16RGB = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube (indices 16-231)
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
 
-	// Map 256-color to nearest 16-color
-	idx := col.index
-	if idx < 16 {
-		return col
+// paletteRGB returns the RGB value of a 256-color palette index
+func paletteRGB(index uint8) (r, g, b uint8) {
+	switch {
+	case index < 16:
+		rgb := ansi16RGB[index]
+		return rgb[0], rgb[1], rgb[2]
+	case index < 232:
+		i := index - 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
 	}
+	gray := 8 + 10*(index-232)
+	return gray, gray, gray
+}
 
-	// Simplified mapping: map to basic 8 colors
-	// This is a basic implementation - a more sophisticated one would
-	// calculate actual color distance
-	if idx >= 232 { // Grayscale
-		if idx < 244 {
-			return ColorBlack
+// nearestIndex returns the palette index in [from, to) closest to the given
+// RGB value by CIEDE2000 distance
+func nearestIndex(r, g, b uint8, from, to int) uint8 {
+	target := rgbToLab(r, g, b)
+	best, bestDist := from, math.Inf(1)
+	for i := from; i < to; i++ {
+		if d := deltaE2000(target, paletteLab[i]); d < bestDist {
+			best, bestDist = i, d
 		}
-		return ColorWhite
 	}
-
-	// For color cube, use simple modulo mapping
-	return ColorIndex(idx % 8)
+	return uint8(best) // #nosec G115
 }
 
 // ansiCode returns the ANSI escape sequence for this color
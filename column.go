@@ -0,0 +1,92 @@
+package goterm
+
+import "unicode/utf8"
+
+// NextCell returns the byte offset of the grapheme cluster that follows the
+// one starting at byte offset i, together with the display width of the
+// cluster at i
+// Moving the cursor right over "é" or a wide character therefore skips
+// the whole cell. At or beyond the end of s, it returns (len(s), 0).
+func NextCell(s string, i int) (next, width int) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(s) {
+		return len(s), 0
+	}
+	n := nextCluster(s[i:])
+	return i + n, clusterWidth(s[i : i+n])
+}
+
+// PrevCell returns the byte offset of the grapheme cluster that ends at byte
+// offset i, together with its display width
+// At or before the start of s, it returns (0, 0).
+func PrevCell(s string, i int) (prev, width int) {
+	if i > len(s) {
+		i = len(s)
+	}
+	start := 0
+	for start < i {
+		n := nextCluster(s[start:])
+		if start+n >= i {
+			return start, clusterWidth(s[start : start+n])
+		}
+		start += n
+	}
+	return 0, 0
+}
+
+// ByteToColumn returns the display column at which the cluster containing
+// byte offset i starts
+func ByteToColumn(s string, i int) int {
+	col := 0
+	for start := 0; start < len(s); {
+		n := nextCluster(s[start:])
+		if start+n > i {
+			break
+		}
+		col += clusterWidth(s[start : start+n])
+		start += n
+	}
+	return col
+}
+
+// ColumnToByte returns the byte offset of the cluster displayed at column col
+// If col falls on the second half of a wide character, the offset of that
+// character is returned. Columns past the end of s map to len(s).
+func ColumnToByte(s string, col int) int {
+	width := 0
+	for start := 0; start < len(s); {
+		n := nextCluster(s[start:])
+		w := clusterWidth(s[start : start+n])
+		if width+w > col {
+			return start
+		}
+		width += w
+		start += n
+	}
+	return len(s)
+}
+
+// RuneToByte returns the byte offset of the rune with index n in s
+// Indexes past the end of s map to len(s).
+func RuneToByte(s string, n int) int {
+	for i := range s {
+		if n <= 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
+
+// ByteToRune returns the index of the rune containing byte offset i
+// Offsets before the start of s map to 0 and offsets past the end to the
+// number of runes in s.
+func ByteToRune(s string, i int) int {
+	i = max(0, min(i, len(s)))
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return utf8.RuneCountInString(s[:i])
+}
@@ -0,0 +1,39 @@
+package goterm
+
+import "math"
+
+// Luminance returns the relative luminance of the color as defined by WCAG,
+// from 0 for black to 1 for white
+// Palette colors use their standard RGB values; the default color, whose
+// value is up to the terminal, reports 0.
+func (c Color) Luminance() float64 {
+	r, g, b, ok := c.resolveRGB()
+	if !ok {
+		return 0
+	}
+	return 0.2126*srgbToLinear(r) + 0.7152*srgbToLinear(g) + 0.0722*srgbToLinear(b)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1
+// for identical luminance to 21 for black on white
+// WCAG recommends at least 4.5 for body text and 3 for large text.
+func ContrastRatio(a, b Color) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	return (math.Max(la, lb) + 0.05) / (math.Min(la, lb) + 0.05)
+}
+
+// BestTextColor returns black or white, whichever has the higher contrast
+// on the background bg, for text on arbitrary colors such as badges, bars
+// and heatmap cells
+// On the default background it returns the default color, which the
+// terminal already pairs legibly with its background.
+func BestTextColor(bg Color) Color {
+	if bg.mode == ColorModeDefault {
+		return ColorDefault()
+	}
+	black, white := ColorRGB(0, 0, 0), ColorRGB(255, 255, 255)
+	if ContrastRatio(bg, white) > ContrastRatio(bg, black) {
+		return white
+	}
+	return black
+}
@@ -12,4 +12,16 @@ var (
 
 	// ErrTerminalRestoreFailed indicates that terminal restoration failed
 	ErrTerminalRestoreFailed = errors.New("terminal restore failed")
+
+	// ErrInvalidColor indicates that a color specification could not be parsed
+	ErrInvalidColor = errors.New("invalid color")
+
+	// ErrInvalidSGR indicates that an SGR escape sequence could not be parsed
+	ErrInvalidSGR = errors.New("invalid SGR sequence")
+
+	// ErrInvalidStyle indicates that a style specification could not be parsed
+	ErrInvalidStyle = errors.New("invalid style")
+
+	// ErrInvalidKey indicates that a key name could not be parsed
+	ErrInvalidKey = errors.New("invalid key")
 )
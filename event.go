@@ -1,5 +1,7 @@
 package goterm
 
+import "time"
+
 // Event is the interface for all terminal events
 type Event interface {
 	isEvent()
@@ -39,6 +41,60 @@ const (
 	ModCtrl  Modifier = 1 << 2 // Control key held
 )
 
+// Key identifies a key on the keyboard
+type Key int
+
+// Key constants
+const (
+	KeyRune      Key = iota // A character key; see KeyEvent.Rune
+	KeyEnter                // Enter/Return
+	KeyTab                  // Tab; Shift-Tab has ModShift set
+	KeyBackspace            // Backspace
+	KeyEscape               // Escape
+	KeyUp                   // Up arrow
+	KeyDown                 // Down arrow
+	KeyLeft                 // Left arrow
+	KeyRight                // Right arrow
+	KeyHome                 // Home
+	KeyEnd                  // End
+	KeyPageUp               // Page Up
+	KeyPageDown             // Page Down
+	KeyInsert               // Insert
+	KeyDelete               // Delete
+	KeyF1                   // Function keys F1-F12
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+// KeyEvent represents a key press
+// Control characters are reported as the letter with ModCtrl, e.g. Ctrl-C
+// is KeyRune 'c' with ModCtrl.
+type KeyEvent struct {
+	Key       Key      // Which key
+	Rune      rune     // Character typed, for KeyRune
+	Modifiers Modifier // Keyboard modifiers held
+}
+
+func (KeyEvent) isEvent() {}
+
+// PasteEvent represents text pasted into the terminal
+// Terminals with bracketed paste deliver a paste as one event instead of a
+// series of key presses.
+type PasteEvent struct {
+	Text string // Pasted text
+}
+
+func (PasteEvent) isEvent() {}
+
 // MouseEvent represents a mouse interaction
 type MouseEvent struct {
 	X, Y      int         // Cell coordinates
@@ -56,3 +112,11 @@ type ResizeEvent struct {
 }
 
 func (ResizeEvent) isEvent() {}
+
+// TickEvent is delivered by the timers an App starts with After and Every
+type TickEvent struct {
+	Timer *Timer    // Timer that fired
+	Time  time.Time // When it fired
+}
+
+func (TickEvent) isEvent() {}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
 )
 
 func main() {
@@ -67,7 +68,7 @@ func main() {
 	screen.Clear()
 	w, h := screen.Size()
 	msg := "Thanks for watching the goterm demo!"
-	screen.DrawText((w-len(msg))/2, h/2, msg, goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleBold)
+	screen.DrawTextAligned(goterm.Rect{W: w, H: h}, msg, goterm.AlignCenter, goterm.AlignMiddle, goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleBold)
 	if err := screen.Show(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to show screen: %v\n", err)
 	}
@@ -78,29 +79,22 @@ func drawHeader(screen *goterm.Screen, title string) {
 	w, _ := screen.Size()
 
 	// Top border
-	border := "═"
-	for i := 0; i < w; i++ {
-		screen.SetCell(i, 0, goterm.NewCell(rune(border[0]), goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold))
-	}
+	border := goterm.NewCell('═', goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold)
+	screen.Fill(0, 0, w, 1, border)
 
 	// Title (centered)
 	titleText := fmt.Sprintf(" %s ", title)
-	startX := (w - len(titleText)) / 2
-	screen.DrawText(startX, 1, titleText, goterm.ColorWhite, goterm.ColorBlue, goterm.StyleBold)
+	screen.DrawTextAligned(goterm.Rect{Y: 1, W: w, H: 1}, titleText, goterm.AlignCenter, goterm.AlignTop, goterm.ColorWhite, goterm.ColorBlue, goterm.StyleBold)
 
 	// Bottom border of header
-	for i := 0; i < w; i++ {
-		screen.SetCell(i, 2, goterm.NewCell(rune(border[0]), goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold))
-	}
+	screen.Fill(0, 2, w, 1, border)
 }
 
 func drawFooter(screen *goterm.Screen, message string) {
 	w, h := screen.Size()
 
 	// Bottom border
-	for i := 0; i < w; i++ {
-		screen.SetCell(i, h-2, goterm.NewCell('─', goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone))
-	}
+	screen.Fill(0, h-2, w, 1, goterm.NewCell('─', goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone))
 
 	// Footer message
 	screen.DrawText(2, h-1, message, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleItalic)
@@ -124,7 +118,6 @@ func demoWelcome(screen *goterm.Screen) {
 
 	startY := (h - len(lines)) / 2
 	for i, line := range lines {
-		startX := (w - len(line)) / 2
 		color := goterm.ColorGreen
 		style := goterm.StyleNone
 
@@ -135,7 +128,7 @@ func demoWelcome(screen *goterm.Screen) {
 			color = goterm.ColorYellow
 		}
 
-		screen.DrawText(startX, startY+i, line, color, goterm.ColorDefault(), style)
+		screen.DrawTextAligned(goterm.Rect{Y: startY + i, W: w, H: 1}, line, goterm.AlignCenter, goterm.AlignTop, color, goterm.ColorDefault(), style)
 	}
 }
 
@@ -161,12 +154,12 @@ func demo16Colors(screen *goterm.Screen) {
 	y := 6
 	for i, c := range colors {
 		// Standard color
-		screen.DrawText(4, y+i, fmt.Sprintf("%-10s", c.name), c.color, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawTextf(4, y+i, c.color, goterm.ColorDefault(), goterm.StyleNone, "%-10s", c.name)
 		screen.DrawText(16, y+i, "█████", c.color, goterm.ColorDefault(), goterm.StyleBold)
 
 		// Bright variant (index + 8)
 		brightColor := goterm.ColorIndex(uint8(i + 8))
-		screen.DrawText(w/2, y+i, fmt.Sprintf("Bright %-10s", c.name), brightColor, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawTextf(w/2, y+i, brightColor, goterm.ColorDefault(), goterm.StyleNone, "Bright %-10s", c.name)
 		screen.DrawText(w/2+16, y+i, "█████", brightColor, goterm.ColorDefault(), goterm.StyleBold)
 	}
 
@@ -174,7 +167,7 @@ func demo16Colors(screen *goterm.Screen) {
 	screen.DrawText(4, y+9, "Background Colors:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 	y += 11
 	for i, c := range colors {
-		screen.DrawText(4, y+i, fmt.Sprintf("  %-8s  ", c.name), goterm.ColorWhite, c.color, goterm.StyleBold)
+		screen.DrawTextf(4, y+i, goterm.ColorWhite, c.color, goterm.StyleBold, "  %-8s  ", c.name)
 	}
 }
 
@@ -289,12 +282,12 @@ func demoColorConversions(screen *goterm.Screen) {
 	y++
 	screen.DrawText(4, y, "Converted to 256-color:", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
 	screen.DrawText(34, y, "████████", c256, goterm.ColorDefault(), goterm.StyleNone)
-	screen.DrawText(44, y, fmt.Sprintf("(index: %d)", c256.Index()), goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(44, y, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone, "(index: %d)", c256.Index())
 
 	y++
 	screen.DrawText(4, y, "Converted to 16-color:", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
 	screen.DrawText(34, y, "████████", c16, goterm.ColorDefault(), goterm.StyleNone)
-	screen.DrawText(44, y, fmt.Sprintf("(index: %d)", c16.Index()), goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(44, y, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone, "(index: %d)", c16.Index())
 
 	// More examples
 	y += 3
@@ -348,7 +341,7 @@ func demoStyles(screen *goterm.Screen) {
 	y := 6
 	for i, s := range styles {
 		// Style name
-		screen.DrawText(4, y+i, fmt.Sprintf("%-25s", s.name), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawTextf(4, y+i, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "%-25s", s.name)
 
 		// Example text with style
 		screen.DrawText(30, y+i, "The quick brown fox jumps over the lazy dog", goterm.ColorWhite, goterm.ColorDefault(), s.style)
@@ -377,7 +370,7 @@ func demoStyleCombinations(screen *goterm.Screen) {
 
 	y := 6
 	for i, c := range combinations {
-		screen.DrawText(4, y+i, fmt.Sprintf("%-30s", c.name), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawTextf(4, y+i, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "%-30s", c.name)
 		screen.DrawText(36, y+i, "The quick brown fox", goterm.ColorWhite, goterm.ColorDefault(), c.style)
 	}
 
@@ -403,6 +396,10 @@ func demoStyleCombinations(screen *goterm.Screen) {
 }
 
 func demoUnicode(screen *goterm.Screen) {
+	// Display the Arabic and Hebrew samples in visual order
+	screen.SetBidi(true)
+	defer screen.SetBidi(false)
+
 	screen.DrawText(4, 4, "Unicode Character Support:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 
 	examples := []struct {
@@ -428,7 +425,7 @@ func demoUnicode(screen *goterm.Screen) {
 
 	y := 6
 	for i, ex := range examples {
-		screen.DrawText(4, y+i, fmt.Sprintf("%-18s", ex.label+":"), goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
+		screen.DrawTextf(4, y+i, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold, "%-18s", ex.label+":")
 		screen.DrawText(24, y+i, ex.text, ex.color, goterm.ColorDefault(), goterm.StyleNone)
 	}
 }
@@ -442,22 +439,22 @@ func demoBoxDrawing(screen *goterm.Screen) {
 	y := 6
 	screen.DrawText(4, y, "Single Line:", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
 	y++
-	drawBox(screen, 4, y, 30, 5, goterm.ColorCyan, true)
+	screen.DrawBox(4, y, 30, 5, goterm.BorderSingle, goterm.ColorCyan, goterm.ColorDefault())
 	screen.DrawText(6, y+2, "Single-line box", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
 
 	// Double-line box
 	screen.DrawText(40, 6, "Double Line:", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
-	drawBox(screen, 40, 7, 30, 5, goterm.ColorMagenta, false)
+	screen.DrawBox(40, 7, 30, 5, goterm.BorderDouble, goterm.ColorMagenta, goterm.ColorDefault())
 	screen.DrawText(42, 9, "Double-line box", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
 
 	// Nested boxes
 	y = 14
 	screen.DrawText(4, y, "Nested Boxes:", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
 	y++
-	drawBox(screen, 4, y, 60, 8, goterm.ColorGreen, true)
-	drawBox(screen, 8, y+2, 20, 4, goterm.ColorYellow, true)
+	screen.DrawBox(4, y, 60, 8, goterm.BorderSingle, goterm.ColorGreen, goterm.ColorDefault())
+	screen.DrawBox(8, y+2, 20, 4, goterm.BorderSingle, goterm.ColorYellow, goterm.ColorDefault())
 	screen.DrawText(10, y+3, "Inner box", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	drawBox(screen, 32, y+2, 20, 4, goterm.ColorCyan, false)
+	screen.DrawBox(32, y+2, 20, 4, goterm.BorderDouble, goterm.ColorCyan, goterm.ColorDefault())
 	screen.DrawText(34, y+3, "Another inner", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
 
 	// Complex layout
@@ -467,7 +464,7 @@ func demoBoxDrawing(screen *goterm.Screen) {
 		y++
 
 		// Main container
-		drawBox(screen, 4, y, 70, 10, goterm.ColorWhite, true)
+		screen.DrawBox(4, y, 70, 10, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
 
 		// Title bar
 		screen.DrawText(6, y+1, "┤ Application Title ├", goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold)
@@ -494,45 +491,13 @@ func demoBoxDrawing(screen *goterm.Screen) {
 	}
 }
 
-func drawBox(screen *goterm.Screen, x, y, width, height int, color goterm.Color, singleLine bool) {
-	var topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical rune
-
-	if singleLine {
-		topLeft, topRight = '┌', '┐'
-		bottomLeft, bottomRight = '└', '┘'
-		horizontal, vertical = '─', '│'
-	} else {
-		topLeft, topRight = '╔', '╗'
-		bottomLeft, bottomRight = '╚', '╝'
-		horizontal, vertical = '═', '║'
-	}
-
-	// Corners
-	screen.SetCell(x, y, goterm.NewCell(topLeft, color, goterm.ColorDefault(), goterm.StyleNone))
-	screen.SetCell(x+width-1, y, goterm.NewCell(topRight, color, goterm.ColorDefault(), goterm.StyleNone))
-	screen.SetCell(x, y+height-1, goterm.NewCell(bottomLeft, color, goterm.ColorDefault(), goterm.StyleNone))
-	screen.SetCell(x+width-1, y+height-1, goterm.NewCell(bottomRight, color, goterm.ColorDefault(), goterm.StyleNone))
-
-	// Horizontal lines
-	for i := 1; i < width-1; i++ {
-		screen.SetCell(x+i, y, goterm.NewCell(horizontal, color, goterm.ColorDefault(), goterm.StyleNone))
-		screen.SetCell(x+i, y+height-1, goterm.NewCell(horizontal, color, goterm.ColorDefault(), goterm.StyleNone))
-	}
-
-	// Vertical lines
-	for i := 1; i < height-1; i++ {
-		screen.SetCell(x, y+i, goterm.NewCell(vertical, color, goterm.ColorDefault(), goterm.StyleNone))
-		screen.SetCell(x+width-1, y+i, goterm.NewCell(vertical, color, goterm.ColorDefault(), goterm.StyleNone))
-	}
-}
-
 func demoScreenBuffer(screen *goterm.Screen) {
 	screen.DrawText(4, 4, "Screen Buffer Management:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 
 	w, h := screen.Size()
 
 	y := 6
-	screen.DrawText(4, y, fmt.Sprintf("Current Screen Size: %dx%d", w, h), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(4, y, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "Current Screen Size: %dx%d", w, h)
 
 	y += 2
 	screen.DrawText(4, y, "Screen buffer stores cells in memory before rendering", goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone)
@@ -565,7 +530,7 @@ func demoScreenBuffer(screen *goterm.Screen) {
 	// Create a pattern in a box
 	boxX, boxY := 6, y
 	boxW, boxH := 40, 8
-	drawBox(screen, boxX, boxY, boxW, boxH, goterm.ColorYellow, true)
+	screen.DrawBox(boxX, boxY, boxW, boxH, goterm.BorderSingle, goterm.ColorYellow, goterm.ColorDefault())
 
 	// Fill with pattern
 	for dy := 1; dy < boxH-1; dy++ {
@@ -596,7 +561,7 @@ func demoCellManipulation(screen *goterm.Screen) {
 	y++
 	cell1 := goterm.NewCell('A', goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold)
 	cell2 := goterm.NewCell('A', goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold)
-	screen.DrawText(6, y, fmt.Sprintf("cell1.Equal(cell2) = %v", cell1.Equal(cell2)), goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(6, y, goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleNone, "cell1.Equal(cell2) = %v", cell1.Equal(cell2))
 
 	y += 2
 	screen.DrawText(4, y, "Example 3: Grid pattern with cells", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleBold)
@@ -631,7 +596,7 @@ func demoResize(screen *goterm.Screen) {
 	screen.DrawText(4, 4, "Dynamic Resize Support:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 
 	y := 6
-	screen.DrawText(4, y, fmt.Sprintf("Current terminal size: %dx%d", w, h), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(4, y, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "Current terminal size: %dx%d", w, h)
 
 	y += 2
 	screen.DrawText(4, y, "The Screen.Resize() method allows changing buffer dimensions", goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone)
@@ -658,7 +623,7 @@ func demoResize(screen *goterm.Screen) {
 	// Draw a reference grid to show resize
 	y += 2
 	for i := 0; i < w && i < 80; i += 10 {
-		screen.DrawText(i, y, fmt.Sprintf("%d", i), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawTextf(i, y, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "%d", i)
 		for j := 0; j < h-y-3 && j < 20; j++ {
 			screen.SetCell(i, y+1+j, goterm.NewCell('│', goterm.ColorBlue, goterm.ColorDefault(), goterm.StyleDim))
 		}
@@ -666,8 +631,6 @@ func demoResize(screen *goterm.Screen) {
 }
 
 func demoGradients(screen *goterm.Screen) {
-	w, _ := screen.Size()
-
 	screen.DrawText(4, 4, "Color Gradients:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 
 	y := 6
@@ -676,51 +639,34 @@ func demoGradients(screen *goterm.Screen) {
 	// Red to Green gradient
 	y += 2
 	screen.DrawText(4, y, "Red → Green:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	for i := 0; i < 64 && (18+i) < w; i++ {
-		r := uint8(255 - (i * 255 / 63))
-		g := uint8(i * 255 / 63)
-		color := goterm.ColorRGB(r, g, 0)
-		screen.SetCell(18+i, y, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
+	screen.FillGradient(goterm.Rect{X: 18, Y: y, W: 64, H: 1},
+		goterm.NewGradient(goterm.ColorRGB(255, 0, 0), goterm.ColorRGB(0, 255, 0)), goterm.Horizontal)
 
 	// Green to Blue gradient
 	y++
 	screen.DrawText(4, y, "Green → Blue:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	for i := 0; i < 64 && (18+i) < w; i++ {
-		g := uint8(255 - (i * 255 / 63))
-		b := uint8(i * 255 / 63)
-		color := goterm.ColorRGB(0, g, b)
-		screen.SetCell(18+i, y, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
+	screen.FillGradient(goterm.Rect{X: 18, Y: y, W: 64, H: 1},
+		goterm.NewGradient(goterm.ColorRGB(0, 255, 0), goterm.ColorRGB(0, 0, 255)), goterm.Horizontal)
 
 	// Blue to Red gradient
 	y++
 	screen.DrawText(4, y, "Blue → Red:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	for i := 0; i < 64 && (18+i) < w; i++ {
-		b := uint8(255 - (i * 255 / 63))
-		r := uint8(i * 255 / 63)
-		color := goterm.ColorRGB(r, 0, b)
-		screen.SetCell(18+i, y, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
+	screen.FillGradient(goterm.Rect{X: 18, Y: y, W: 64, H: 1},
+		goterm.NewGradient(goterm.ColorRGB(0, 0, 255), goterm.ColorRGB(255, 0, 0)), goterm.Horizontal)
 
 	// Rainbow gradient
 	y += 2
 	screen.DrawText(4, y, "Rainbow:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	for i := 0; i < 64 && (18+i) < w; i++ {
-		hue := float64(i) / 64.0
-		r, g, b := hsvToRGB(hue, 1.0, 1.0)
-		color := goterm.ColorRGB(r, g, b)
-		screen.SetCell(18+i, y, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
+	rainbow := goterm.NewGradient(
+		goterm.ColorHSV(0, 1, 1), goterm.ColorHSV(60, 1, 1), goterm.ColorHSV(120, 1, 1),
+		goterm.ColorHSV(180, 1, 1), goterm.ColorHSV(240, 1, 1), goterm.ColorHSV(300, 1, 1))
+	screen.FillGradient(goterm.Rect{X: 18, Y: y, W: 64, H: 1}, rainbow, goterm.Horizontal)
 
 	// Grayscale gradient
 	y++
 	screen.DrawText(4, y, "Grayscale:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	for i := 0; i < 64 && (18+i) < w; i++ {
-		gray := uint8(i * 255 / 63)
-		color := goterm.ColorRGB(gray, gray, gray)
-		screen.SetCell(18+i, y, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
+	screen.FillGradient(goterm.Rect{X: 18, Y: y, W: 64, H: 1},
+		goterm.NewGradient(goterm.ColorRGB(0, 0, 0), goterm.ColorRGB(255, 255, 255)), goterm.Horizontal)
 
 	// 2D gradient
 	y += 3
@@ -729,49 +675,12 @@ func demoGradients(screen *goterm.Screen) {
 
 	gridW, gridH := 48, 12
 	for dy := 0; dy < gridH; dy++ {
-		for dx := 0; dx < gridW; dx++ {
-			r := uint8((dx * 255) / (gridW - 1))
-			b := uint8((dy * 255) / (gridH - 1))
-			color := goterm.ColorRGB(r, 0, b)
-			if (6+dx) < w {
-				screen.SetCell(6+dx, y+dy, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
-			}
-		}
+		b := uint8((dy * 255) / (gridH - 1))
+		screen.FillGradient(goterm.Rect{X: 6, Y: y + dy, W: gridW, H: 1},
+			goterm.NewGradient(goterm.ColorRGB(0, 0, b), goterm.ColorRGB(255, 0, b)), goterm.Horizontal)
 	}
 }
 
-func hsvToRGB(h, s, v float64) (uint8, uint8, uint8) {
-	if s == 0 {
-		gray := uint8(v * 255)
-		return gray, gray, gray
-	}
-
-	h = h * 6.0
-	i := int(h)
-	f := h - float64(i)
-	p := v * (1.0 - s)
-	q := v * (1.0 - s*f)
-	t := v * (1.0 - s*(1.0-f))
-
-	var r, g, b float64
-	switch i % 6 {
-	case 0:
-		r, g, b = v, t, p
-	case 1:
-		r, g, b = q, v, p
-	case 2:
-		r, g, b = p, v, t
-	case 3:
-		r, g, b = p, q, v
-	case 4:
-		r, g, b = t, p, v
-	case 5:
-		r, g, b = v, p, q
-	}
-
-	return uint8(r * 255), uint8(g * 255), uint8(b * 255)
-}
-
 func demoAnimation(screen *goterm.Screen) {
 	screen.DrawText(4, 4, "Animation Demo:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 
@@ -794,24 +703,24 @@ func demoAnimation(screen *goterm.Screen) {
 		// Draw shadow
 		screen.SetCell(x, y+20, goterm.NewCell('○', goterm.ColorBlack, goterm.ColorDefault(), goterm.StyleDim))
 		// Draw frame number
-		screen.DrawText(x, y+21, fmt.Sprintf("%d", i+1), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawTextf(x, y+21, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "%d", i+1)
 	}
 
 	// Spinner animation
-	spinners := []rune{'|', '/', '─', '\\', '|', '/', '─', '\\'}
+	spinner := &widgets.Spinner{Frames: widgets.SpinnerLine, Fg: goterm.ColorGreen, Style: goterm.StyleBold}
 	screen.DrawText(40, y, "Spinner:", goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold)
-	for i, spin := range spinners {
+	for i := 0; i < 8; i++ {
 		x := 40 + 10 + i*3
-		screen.SetCell(x, y+1, goterm.NewCell(spin, goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleBold))
-		screen.DrawText(x-1, y+2, fmt.Sprintf("%d", i+1), goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+		spinner.Draw(screen.SubView(x, y+1, 1, 1))
+		spinner.Tick()
+		screen.DrawTextf(x-1, y+2, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone, "%d", i+1)
 	}
 
 	// Color wave
 	screen.DrawText(4, y+24, "Color Wave:", goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold)
 	for i := 0; i < 60; i++ {
 		hue := float64(i) / 60.0
-		r, g, b := hsvToRGB(hue, 1.0, 1.0)
-		color := goterm.ColorRGB(r, g, b)
+		color := goterm.ColorHSV(hue*360, 1.0, 1.0)
 		screen.SetCell(4+i, y+25, goterm.NewCell('█', color, goterm.ColorDefault(), goterm.StyleNone))
 	}
 
@@ -825,7 +734,7 @@ func demoFinalShowcase(screen *goterm.Screen) {
 
 	// Title
 	title := "goterm - Terminal Graphics Library"
-	screen.DrawText((w-len(title))/2, 4, title, goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold)
+	screen.DrawTextAligned(goterm.Rect{Y: 4, W: w, H: 1}, title, goterm.AlignCenter, goterm.AlignTop, goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleBold)
 
 	// Feature highlights in boxes
 	features := []struct {
@@ -875,11 +784,10 @@ func demoFinalShowcase(screen *goterm.Screen) {
 		x := startX + i*25
 
 		// Draw box
-		drawBox(screen, x, y, 22, 8, feat.color, true)
+		screen.DrawBox(x, y, 22, 8, goterm.BorderSingle, feat.color, goterm.ColorDefault())
 
 		// Title
-		titleX := x + (22-len(feat.title))/2
-		screen.DrawText(titleX, y+1, feat.title, feat.color, goterm.ColorDefault(), goterm.StyleBold)
+		screen.DrawTextAligned(goterm.Rect{X: x, Y: y + 1, W: 22, H: 1}, feat.title, goterm.AlignCenter, goterm.AlignTop, feat.color, goterm.ColorDefault(), goterm.StyleBold)
 
 		// Items
 		for j, item := range feat.items {
@@ -891,8 +799,7 @@ func demoFinalShowcase(screen *goterm.Screen) {
 	y = 17
 	for i := 0; i < w-8; i++ {
 		hue := float64(i) / float64(w-8)
-		r, g, b := hsvToRGB(hue, 1.0, 1.0)
-		color := goterm.ColorRGB(r, g, b)
+		color := goterm.ColorHSV(hue*360, 1.0, 1.0)
 		screen.SetCell(4+i, y, goterm.NewCell('═', color, goterm.ColorDefault(), goterm.StyleBold))
 	}
 
@@ -922,11 +829,9 @@ func demoFinalShowcase(screen *goterm.Screen) {
 	finalY := h - 6
 	if finalY > y+len(keyFeatures)+2 {
 		msg := "Ready to build amazing terminal UIs!"
-		msgX := (w - len(msg)) / 2
-		screen.DrawText(msgX, finalY, msg, goterm.ColorMagenta, goterm.ColorDefault(), goterm.StyleBold|goterm.StyleItalic)
+		screen.DrawTextAligned(goterm.Rect{Y: finalY, W: w, H: 1}, msg, goterm.AlignCenter, goterm.AlignTop, goterm.ColorMagenta, goterm.ColorDefault(), goterm.StyleBold|goterm.StyleItalic)
 
 		repo := "github.com/dshills/goterm"
-		repoX := (w - len(repo)) / 2
-		screen.DrawText(repoX, finalY+2, repo, goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleUnderline)
+		screen.DrawTextAligned(goterm.Rect{Y: finalY + 2, W: w, H: 1}, repo, goterm.AlignCenter, goterm.AlignTop, goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleUnderline)
 	}
 }
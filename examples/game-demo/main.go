@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
 )
 
 // Game states
@@ -70,7 +73,6 @@ type Game struct {
 	Score       int
 	Time        float64
 	DeltaTime   float64
-	LastUpdate  time.Time
 	GameAreaX   int
 	GameAreaY   int
 	GameAreaW   int
@@ -80,66 +82,40 @@ type Game struct {
 }
 
 func main() {
-	// Initialize the terminal
-	screen, err := goterm.Init()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to initialize terminal: %v\n", err)
-		os.Exit(1)
-	}
-	defer func() {
-		if err := screen.Close(); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to close screen: %v\n", err)
-		}
-	}()
-
-	// Initialize random seed
-	rand.Seed(time.Now().UnixNano())
-
 	// Create game
 	game := NewGame()
 
-	// Game loop
-	ticker := time.NewTicker(33 * time.Millisecond) // ~30 FPS
-	defer ticker.Stop()
-
-	gameDuration := 45 * time.Second
-	startTime := time.Now()
-
-	for {
-		select {
-		case <-ticker.C:
-			// Check if demo should end
-			if time.Since(startTime) > gameDuration {
-				return
-			}
-
-			// Update delta time
-			now := time.Now()
-			game.DeltaTime = now.Sub(game.LastUpdate).Seconds()
-			game.LastUpdate = now
-
-			// Clear screen
-			screen.Clear()
+	// The demo ends by itself after a while, or on Ctrl+C
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
 
-			// Update and render based on state
+	app := &goterm.App{
+		FPS: 30,
+		Update: func(dt time.Duration) {
+			game.DeltaTime = dt.Seconds()
 			switch game.State {
 			case StateMenu:
 				game.UpdateMenu()
-				game.RenderMenu(screen)
 			case StatePlaying:
 				game.Update()
+			}
+		},
+		Draw: func(screen *goterm.Screen) {
+			switch game.State {
+			case StateMenu:
+				game.RenderMenu(screen)
+			case StatePlaying:
 				game.Render(screen)
 			case StateGameOver:
 				game.RenderGameOver(screen)
 			case StateVictory:
 				game.RenderVictory(screen)
 			}
-
-			// Show the frame
-			if err := screen.Show(); err != nil {
-				return
-			}
-		}
+		},
+	}
+	if err := app.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Game demo failed: %v\n", err)
+		os.Exit(1)
 	}
 }
 
@@ -150,7 +126,6 @@ func NewGame() *Game {
 		MapHeight:   20,
 		MaxMessages: 5,
 		Messages:    make([]string, 0),
-		LastUpdate:  time.Now(),
 		GameAreaX:   2,
 		GameAreaY:   2,
 		GameAreaW:   44,
@@ -622,16 +597,14 @@ func (g *Game) RenderMenu(screen *goterm.Screen) {
 
 	// Title
 	title := "DUNGEON CRAWLER"
-	titleX := (w - len(title)) / 2
-	screen.DrawText(titleX, h/2-5, title,
+	screen.DrawTextAligned(goterm.Rect{Y: h/2 - 5, W: w, H: 1}, title, goterm.AlignCenter, goterm.AlignTop,
 		goterm.ColorRGB(255, 100, 50),
 		goterm.ColorDefault(),
 		goterm.StyleBold)
 
 	// Subtitle
 	subtitle := "Terminal Game Demo"
-	subtitleX := (w - len(subtitle)) / 2
-	screen.DrawText(subtitleX, h/2-3, subtitle,
+	screen.DrawTextAligned(goterm.Rect{Y: h/2 - 3, W: w, H: 1}, subtitle, goterm.AlignCenter, goterm.AlignTop,
 		goterm.ColorCyan,
 		goterm.ColorDefault(),
 		goterm.StyleItalic)
@@ -645,13 +618,11 @@ func (g *Game) RenderMenu(screen *goterm.Screen) {
 		"Auto-playing demo...",
 	}
 
-	for i, line := range instructions {
-		x := (w - len(line)) / 2
-		screen.DrawText(x, h/2+i, line,
-			goterm.ColorWhite,
-			goterm.ColorDefault(),
-			goterm.StyleNone)
-	}
+	screen.DrawTextAligned(goterm.Rect{Y: h / 2, W: w, H: len(instructions)}, strings.Join(instructions, "\n"),
+		goterm.AlignCenter, goterm.AlignTop,
+		goterm.ColorWhite,
+		goterm.ColorDefault(),
+		goterm.StyleNone)
 
 	// Legend
 	legendY := h/2 + 7
@@ -673,16 +644,17 @@ func (g *Game) RenderMenu(screen *goterm.Screen) {
 	}
 
 	for i, leg := range legends {
-		x := (w - 30) / 2
-		screen.DrawText(x, legendY+i, leg.ch, leg.color, goterm.ColorDefault(), goterm.StyleBold)
-		screen.DrawText(x+3, legendY+i, leg.desc, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+		line := goterm.StyledText{}.
+			Add(leg.ch, leg.color, goterm.ColorDefault(), goterm.StyleBold).
+			Add("  "+leg.desc, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+		screen.DrawSpans((w-30)/2, legendY+i, line)
 	}
 }
 
 func (g *Game) Render(screen *goterm.Screen) {
 	// Draw game border
-	g.DrawBorder(screen, g.GameAreaX-1, g.GameAreaY-1, g.GameAreaW+2, g.GameAreaH+2,
-		goterm.ColorCyan, "DUNGEON LEVEL 1")
+	screen.DrawTitledBox(g.GameAreaX-1, g.GameAreaY-1, g.GameAreaW+2, g.GameAreaH+2,
+		goterm.BorderSingle, "DUNGEON LEVEL 1", goterm.TitleTopCenter, goterm.ColorCyan, goterm.ColorDefault())
 
 	// Draw map
 	for y := 0; y < g.MapHeight; y++ {
@@ -755,7 +727,7 @@ func (g *Game) DrawUI(screen *goterm.Screen) {
 	statsX := g.GameAreaX + g.GameAreaW + 3
 	statsY := g.GameAreaY
 
-	g.DrawBorder(screen, statsX-1, statsY-1, 32, 12, goterm.ColorYellow, "STATS")
+	screen.DrawTitledBox(statsX-1, statsY-1, 32, 12, goterm.BorderSingle, "STATS", goterm.TitleTopCenter, goterm.ColorYellow, goterm.ColorDefault())
 
 	// Player health bar
 	screen.DrawText(statsX, statsY, "Health:", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
@@ -763,12 +735,14 @@ func (g *Game) DrawUI(screen *goterm.Screen) {
 		goterm.ColorGreen, goterm.ColorRed)
 
 	// Score
-	screen.DrawText(statsX, statsY+3, fmt.Sprintf("Score: %d", g.Score),
-		goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(statsX, statsY+3,
+		goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone,
+		"Score: %d", g.Score)
 
 	// Time
-	screen.DrawText(statsX, statsY+4, fmt.Sprintf("Time: %.1fs", g.Time),
-		goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(statsX, statsY+4,
+		goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone,
+		"Time: %.1fs", g.Time)
 
 	// Enemy count
 	activeEnemies := 0
@@ -777,8 +751,9 @@ func (g *Game) DrawUI(screen *goterm.Screen) {
 			activeEnemies++
 		}
 	}
-	screen.DrawText(statsX, statsY+6, fmt.Sprintf("Enemies: %d", activeEnemies),
-		goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(statsX, statsY+6,
+		goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone,
+		"Enemies: %d", activeEnemies)
 
 	// Item count
 	activeItems := 0
@@ -787,20 +762,22 @@ func (g *Game) DrawUI(screen *goterm.Screen) {
 			activeItems++
 		}
 	}
-	screen.DrawText(statsX, statsY+7, fmt.Sprintf("Items: %d", activeItems),
-		goterm.ColorMagenta, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawTextf(statsX, statsY+7,
+		goterm.ColorMagenta, goterm.ColorDefault(), goterm.StyleNone,
+		"Items: %d", activeItems)
 
 	// FPS
 	fps := 1.0 / g.DeltaTime
 	if g.DeltaTime == 0 {
 		fps = 0
 	}
-	screen.DrawText(statsX, statsY+9, fmt.Sprintf("FPS: %.0f", fps),
-		goterm.ColorRGB(150, 150, 150), goterm.ColorDefault(), goterm.StyleDim)
+	screen.DrawTextf(statsX, statsY+9,
+		goterm.ColorRGB(150, 150, 150), goterm.ColorDefault(), goterm.StyleDim,
+		"FPS: %.0f", fps)
 
 	// Message log (bottom)
 	logY := h - 8
-	g.DrawBorder(screen, 1, logY-1, w-2, 7, goterm.ColorGreen, "MESSAGE LOG")
+	screen.DrawTitledBox(1, logY-1, w-2, 7, goterm.BorderSingle, "MESSAGE LOG", goterm.TitleTopCenter, goterm.ColorGreen, goterm.ColorDefault())
 
 	for i, msg := range g.Messages {
 		y := logY + i
@@ -817,75 +794,36 @@ func (g *Game) DrawBar(screen *goterm.Screen, x, y, width, value, maxValue int,
 		maxValue = 1
 	}
 
-	filled := (value * width) / maxValue
-	if filled > width {
-		filled = width
-	}
-	if filled < 0 {
-		filled = 0
-	}
-
-	// Draw filled portion
-	for i := 0; i < filled; i++ {
-		screen.SetCell(x+i, y, goterm.NewCell('█', fullColor, goterm.ColorDefault(), goterm.StyleNone))
-	}
-
-	// Draw empty portion
-	for i := filled; i < width; i++ {
-		screen.SetCell(x+i, y, goterm.NewCell('░', emptyColor, goterm.ColorDefault(), goterm.StyleDim))
+	bar := &widgets.ProgressBar{
+		Value: float64(value) / float64(maxValue),
+		Fill:  fullColor,
+		Empty: emptyColor,
 	}
+	bar.Draw(screen.SubView(x, y, width, 1))
 
 	// Draw value text
 	text := fmt.Sprintf("%d/%d", value, maxValue)
-	textX := x + (width-len(text))/2
-	screen.DrawText(textX, y, text, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
-}
-
-func (g *Game) DrawBorder(screen *goterm.Screen, x, y, width, height int, color goterm.Color, title string) {
-	// Corners
-	screen.SetCell(x, y, goterm.NewCell('┌', color, goterm.ColorDefault(), goterm.StyleNone))
-	screen.SetCell(x+width-1, y, goterm.NewCell('┐', color, goterm.ColorDefault(), goterm.StyleNone))
-	screen.SetCell(x, y+height-1, goterm.NewCell('└', color, goterm.ColorDefault(), goterm.StyleNone))
-	screen.SetCell(x+width-1, y+height-1, goterm.NewCell('┘', color, goterm.ColorDefault(), goterm.StyleNone))
-
-	// Horizontal lines
-	for i := 1; i < width-1; i++ {
-		screen.SetCell(x+i, y, goterm.NewCell('─', color, goterm.ColorDefault(), goterm.StyleNone))
-		screen.SetCell(x+i, y+height-1, goterm.NewCell('─', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
-
-	// Vertical lines
-	for i := 1; i < height-1; i++ {
-		screen.SetCell(x, y+i, goterm.NewCell('│', color, goterm.ColorDefault(), goterm.StyleNone))
-		screen.SetCell(x+width-1, y+i, goterm.NewCell('│', color, goterm.ColorDefault(), goterm.StyleNone))
-	}
-
-	// Title
-	if title != "" {
-		titleText := fmt.Sprintf(" %s ", title)
-		titleX := x + (width-len(titleText))/2
-		screen.DrawText(titleX, y, titleText, color, goterm.ColorDefault(), goterm.StyleBold)
-	}
+	screen.DrawTextAligned(goterm.Rect{X: x, Y: y, W: width, H: 1}, text, goterm.AlignCenter, goterm.AlignTop, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleBold)
 }
 
 func (g *Game) RenderGameOver(screen *goterm.Screen) {
 	w, h := screen.Size()
 
 	// Draw red border
-	g.DrawBorder(screen, 2, 2, w-4, h-4, goterm.ColorRed, "GAME OVER")
+	screen.DrawTitledBox(2, 2, w-4, h-4, goterm.BorderSingle, "GAME OVER", goterm.TitleTopCenter, goterm.ColorRed, goterm.ColorDefault())
 
 	// Title
 	title := "GAME OVER"
-	screen.DrawText((w-len(title))/2, h/2-3, title,
+	screen.DrawTextAligned(goterm.Rect{Y: h/2 - 3, W: w, H: 1}, title, goterm.AlignCenter, goterm.AlignTop,
 		goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold|goterm.StyleReverse)
 
 	// Final score
 	scoreText := fmt.Sprintf("Final Score: %d", g.Score)
-	screen.DrawText((w-len(scoreText))/2, h/2, scoreText,
+	screen.DrawTextAligned(goterm.Rect{Y: h / 2, W: w, H: 1}, scoreText, goterm.AlignCenter, goterm.AlignTop,
 		goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleBold)
 
 	timeText := fmt.Sprintf("Survived: %.1f seconds", g.Time)
-	screen.DrawText((w-len(timeText))/2, h/2+1, timeText,
+	screen.DrawTextAligned(goterm.Rect{Y: h/2 + 1, W: w, H: 1}, timeText, goterm.AlignCenter, goterm.AlignTop,
 		goterm.ColorCyan, goterm.ColorDefault(), goterm.StyleNone)
 }
 
@@ -893,7 +831,7 @@ func (g *Game) RenderVictory(screen *goterm.Screen) {
 	w, h := screen.Size()
 
 	// Draw gold border
-	g.DrawBorder(screen, 2, 2, w-4, h-4, goterm.ColorYellow, "VICTORY")
+	screen.DrawTitledBox(2, 2, w-4, h-4, goterm.BorderSingle, "VICTORY", goterm.TitleTopCenter, goterm.ColorYellow, goterm.ColorDefault())
 
 	// Title with animation
 	title := "★ VICTORY! ★"
@@ -901,7 +839,7 @@ func (g *Game) RenderVictory(screen *goterm.Screen) {
 	if int(g.Time*2)%2 == 0 {
 		titleColor = goterm.ColorRGB(255, 215, 0)
 	}
-	screen.DrawText((w-len(title))/2, h/2-3, title,
+	screen.DrawTextAligned(goterm.Rect{Y: h/2 - 3, W: w, H: 1}, title, goterm.AlignCenter, goterm.AlignTop,
 		titleColor, goterm.ColorDefault(), goterm.StyleBold)
 
 	// Messages
@@ -912,10 +850,8 @@ func (g *Game) RenderVictory(screen *goterm.Screen) {
 		"You cleared the dungeon!",
 	}
 
-	for i, msg := range messages {
-		screen.DrawText((w-len(msg))/2, h/2+i, msg,
-			goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
-	}
+	screen.DrawTextAligned(goterm.Rect{Y: h / 2, W: w, H: len(messages)}, strings.Join(messages, "\n"),
+		goterm.AlignCenter, goterm.AlignTop, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
 
 	// Fireworks effect (simple)
 	for i := 0; i < 5; i++ {
@@ -926,6 +862,11 @@ func (g *Game) RenderVictory(screen *goterm.Screen) {
 			goterm.ColorCyan, goterm.ColorMagenta,
 		}
 		color := colors[rand.Intn(len(colors))]
-		screen.SetCell(x, y, goterm.NewCell('*', color, goterm.ColorDefault(), goterm.StyleBold))
+		screen.DrawSprite(x-2, y-1, fireworkSprite, goterm.SpriteOptions{Recolor: true, Fg: color})
 	}
 }
+
+// fireworkSprite is the burst drawn by the victory screen
+var fireworkSprite = goterm.SpriteFromText(`\ | /
+- * -
+/ | \`, goterm.ColorWhite, goterm.ColorDefault())
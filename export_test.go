@@ -0,0 +1,11 @@
+package goterm
+
+import "io"
+
+// SetOutput sends what s writes to the terminal to w, for the tests in
+// package goterm_test that run an App with widgets
+func SetOutput(s *Screen, w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out = w
+}
@@ -0,0 +1,53 @@
+package goterm
+
+// FloodFill replaces the connected region of cells identical to the cell at
+// (x, y) with cell, as the bucket tool of a paint program does
+// Cells are connected through their four edges. Does nothing if x, y are out
+// of bounds.
+func (s *Screen) FloodFill(x, y int, cell Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FloodFill(x, y, cell)
+}
+
+// FloodFill replaces the connected region of cells identical to the cell at
+// (x, y) with cell
+// See Screen.FloodFill.
+func (b *Buffer) FloodFill(x, y int, cell Cell) {
+	if !b.clip.Contains(x, y) {
+		return
+	}
+	target := b.cells[y*b.width+x]
+	if target == cell {
+		return
+	}
+
+	// Scanline fill: fill a whole run of the row, then queue the rows above
+	// and below it
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		px, py := p[0], p[1]
+		if b.cells[py*b.width+px] != target {
+			continue
+		}
+
+		left, right := px, px
+		for left > b.clip.X && b.cells[py*b.width+left-1] == target {
+			left--
+		}
+		for right < b.clip.X+b.clip.W-1 && b.cells[py*b.width+right+1] == target {
+			right++
+		}
+
+		for cx := left; cx <= right; cx++ {
+			b.cells[py*b.width+cx] = cell
+			for _, ny := range [2]int{py - 1, py + 1} {
+				if ny >= b.clip.Y && ny < b.clip.Y+b.clip.H && b.cells[ny*b.width+cx] == target {
+					stack = append(stack, [2]int{cx, ny})
+				}
+			}
+		}
+	}
+}
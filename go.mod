@@ -2,7 +2,6 @@ module github.com/dshills/goterm
 
 go 1.25.3
 
-require (
-	golang.org/x/sys v0.37.0 // indirect
-	golang.org/x/term v0.36.0 // indirect
-)
+require golang.org/x/term v0.36.0
+
+require golang.org/x/sys v0.37.0 // indirect
@@ -1,6 +1,19 @@
 package goterm
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
 
 // Basic sanity tests to ensure coverage reporting works
 // More comprehensive tests are in tests/unit/ directory
@@ -26,3 +39,809 @@ func TestNewScreenBasic(t *testing.T) {
 		t.Errorf("NewScreen(80, 24).Size() = (%d, %d), want (80, 24)", w, h)
 	}
 }
+
+func TestShowColorProfile(t *testing.T) {
+	tests := []struct {
+		profile ColorMode
+		want    string
+		reject  string
+	}{
+		{ColorModeTrueColor, "\x1b[38;2;255;128;0m", ""},
+		{ColorMode256, "\x1b[38;5;208m", "38;2"},
+		{ColorMode16, "\x1b[91m", "38;"},
+		{ColorModeDefault, "X", "\x1b[3"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		screen := NewScreen(1, 1)
+		screen.out = &out
+		screen.SetColorProfile(tt.profile)
+		screen.SetCell(0, 0, NewCell('X', ColorRGB(255, 128, 0), ColorDefault(), StyleNone))
+
+		if err := screen.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		if got := out.String(); !strings.Contains(got, tt.want) || (tt.reject != "" && strings.Contains(got, tt.reject)) {
+			t.Errorf("profile %v: Show() wrote %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+}
+
+func TestShowTranslucentBackdrop(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(s *Screen)
+		want  string
+	}{
+		{"dark", func(s *Screen) {}, "\x1b[48;2;100;0;0m"},
+		{"light", func(s *Screen) { s.SetDarkBackground(false) }, "\x1b[48;2;227;127;127m"},
+		{"theme", func(s *Screen) {
+			theme := DefaultTheme()
+			theme.Background = ColorRGB(0, 0, 200)
+			s.SetTheme(theme)
+		}, "\x1b[48;2;100;0;100m"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		screen := NewScreen(1, 1)
+		screen.out = &out
+		tt.setup(screen)
+		screen.SetCell(0, 0, NewCell('X', ColorDefault(), ColorRGBA(200, 0, 0, 128), StyleNone))
+
+		if err := screen.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		if got := out.String(); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: Show() wrote %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShowBoldAsBright(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		cell    Cell
+		want    string
+	}{
+		{"disabled", false, NewCell('X', ColorRed, ColorDefault(), StyleBold), "\x1b[31m"},
+		{"bold", true, NewCell('X', ColorRed, ColorDefault(), StyleBold), "\x1b[91m"},
+		{"not_bold", true, NewCell('X', ColorRed, ColorDefault(), StyleNone), "\x1b[31m"},
+		{"already_bright", true, NewCell('X', ColorIndex(12), ColorDefault(), StyleBold), "\x1b[94m"},
+		{"palette", true, NewCell('X', ColorIndex(100), ColorDefault(), StyleBold), "\x1b[38;5;100m"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		screen := NewScreen(1, 1)
+		screen.out = &out
+		screen.SetBoldAsBright(tt.enabled)
+		screen.SetCell(0, 0, tt.cell)
+
+		if err := screen.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		if got := out.String(); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: Show() wrote %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShowResolvesReverse(t *testing.T) {
+	tests := []struct {
+		name string
+		cell Cell
+		want string
+	}{
+		{"explicit", NewCell('X', ColorRed, ColorBlue, StyleReverse|StyleBold), "\x1b[0m\x1b[34m\x1b[41m\x1b[1mX"},
+		{"default_fg", NewCell('X', ColorDefault(), ColorBlue, StyleReverse), "\x1b[0m\x1b[44m\x1b[7mX"},
+		{"default_bg", NewCell('X', ColorRed, ColorDefault(), StyleReverse), "\x1b[0m\x1b[31m\x1b[7mX"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		screen := NewScreen(1, 1)
+		screen.out = &out
+		screen.SetCell(0, 0, tt.cell)
+
+		if err := screen.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		if got := out.String(); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: Show() wrote %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShowWritesLinks(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(4, 1)
+	screen.out = &out
+	screen.DrawText(0, 0, "abcd", ColorDefault(), ColorDefault(), StyleNone)
+	screen.SetLink(Rect{X: 1, Y: 0, W: 2, H: 1}, "http://x/\x1b]evil")
+
+	if err := screen.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	want := "a\x1b]8;;http://x/]evil\x1b\\bc\x1b]8;;\x1b\\d"
+	if got := out.String(); !strings.Contains(got, want) {
+		t.Errorf("Show() wrote %q, want %q", got, want)
+	}
+	if got := out.String(); strings.Count(got, "\x1b]8;;") != 2 {
+		t.Errorf("Show() wrote %d link sequences, want 2", strings.Count(got, "\x1b]8;;"))
+	}
+}
+
+func TestStyleOverlineCode(t *testing.T) {
+	if got := StyleOverline.ansiCode(); got != "\x1b[53m" {
+		t.Errorf("StyleOverline.ansiCode() = %q, want %q", got, "\x1b[53m")
+	}
+	if got := (StyleBold | StyleOverline).ansiCode(); got != "\x1b[1m\x1b[53m" {
+		t.Errorf("(StyleBold|StyleOverline).ansiCode() = %q", got)
+	}
+}
+
+func TestStyleFilter(t *testing.T) {
+	basic := StyleBold | StyleUnderline | StyleReverse
+
+	tests := []struct {
+		name      string
+		style     Style
+		supported Style
+		want      Style
+	}{
+		{"all_supported", StyleItalic | StyleBold, StyleAll, StyleItalic | StyleBold},
+		{"italic_to_underline", StyleItalic, basic, StyleUnderline},
+		{"blink_to_bold", StyleSlowBlink | StyleRapidBlink, basic, StyleBold},
+		{"overline_to_underline", StyleOverline | StyleReverse, basic, StyleUnderline | StyleReverse},
+		{"dropped", StyleStrikethrough | StyleBold, basic, StyleBold},
+		{"fallback_unsupported", StyleItalic, StyleBold, StyleNone},
+		{"none_supported", StyleBold | StyleItalic, StyleNone, StyleNone},
+	}
+
+	for _, tt := range tests {
+		if got := tt.style.filter(tt.supported); got != tt.want {
+			t.Errorf("%s: filter() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShowSupportedStyles(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(1, 1)
+	screen.out = &out
+	screen.SetSupportedStyles(StyleBold | StyleUnderline)
+	screen.SetCell(0, 0, NewCell('X', ColorDefault(), ColorDefault(), StyleItalic))
+
+	if err := screen.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "\x1b[4m") || strings.Contains(got, "\x1b[3m") {
+		t.Errorf("Show() wrote %q, want italic replaced by underline", got)
+	}
+}
+
+func TestSGRCache(t *testing.T) {
+	cache := newSGRCache(2)
+	red := cache.get(ColorRed, ColorDefault(), StyleBold)
+	if want := "\x1b[0m\x1b[31m\x1b[1m"; string(red) != want {
+		t.Errorf("get() = %q, want %q", red, want)
+	}
+	if again := cache.get(ColorRed, ColorDefault(), StyleBold); &again[0] != &red[0] {
+		t.Error("get() composed a cached sequence again")
+	}
+
+	cache.get(ColorBlue, ColorDefault(), StyleNone)
+	cache.get(ColorRed, ColorDefault(), StyleBold)
+	cache.get(ColorGreen, ColorDefault(), StyleNone)
+	if n := cache.len(); n != 2 {
+		t.Fatalf("len() = %d, want 2", n)
+	}
+	if _, ok := cache.entries[sgrKey{fg: ColorBlue, bg: ColorDefault(), style: StyleNone}]; ok {
+		t.Error("least recently used sequence was not evicted")
+	}
+	if _, ok := cache.entries[sgrKey{fg: ColorRed, bg: ColorDefault(), style: StyleBold}]; !ok {
+		t.Error("recently used sequence was evicted")
+	}
+}
+
+func TestSetClipboard(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(1, 1)
+	screen.out = &out
+
+	if err := screen.SetClipboard("hi"); err != nil {
+		t.Fatalf("SetClipboard() error = %v", err)
+	}
+	if got, want := out.String(), "\x1b]52;c;aGk=\x07"; got != want {
+		t.Errorf("SetClipboard() wrote %q, want %q", got, want)
+	}
+}
+
+func TestShowKittyImages(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(10, 5)
+	screen.out = &out
+	screen.SetGraphicsProtocol(GraphicsKitty)
+	show := func() string {
+		t.Helper()
+		out.Reset()
+		if err := screen.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		return out.String()
+	}
+
+	im := screen.AddImage(image.NewRGBA(image.Rect(0, 0, 8, 8)), Rect{X: 2, Y: 1, W: 4, H: 2})
+	steps := []struct {
+		name   string
+		change func()
+		want   []string
+		absent []string
+	}{
+		{"added", func() {}, []string{"\x1b_Ga=t,f=100,t=d,i=1,q=2,m=0;", "\x1b[2;3H\x1b_Ga=p,i=1,p=1,c=4,r=2,C=1,"}, nil},
+		{"unchanged", func() {}, nil, []string{"\x1b_G"}},
+		{"moved", func() { im.SetRect(Rect{X: 0, Y: 0, W: 4, H: 2}) }, []string{"\x1b[1;1H\x1b_Ga=p,i=1,p=1,"}, []string{"a=t"}},
+		{"hidden", func() { im.SetVisible(false) }, []string{"\x1b_Ga=d,d=i,i=1,q=2"}, []string{"a=p"}},
+		{"shown", func() { im.SetVisible(true) }, []string{"a=p,i=1"}, []string{"a=t"}},
+		{"resized", func() { screen.Resize(12, 5) }, []string{"a=p,i=1"}, []string{"a=t"}},
+		{"replaced", func() { im.SetImage(image.NewRGBA(image.Rect(0, 0, 2, 2))) }, []string{"a=t,f=100,t=d,i=1", "a=p,i=1"}, nil},
+		{"removed", func() { screen.RemoveImage(im) }, []string{"\x1b_Ga=d,d=I,i=1,q=2"}, []string{"a=p"}},
+	}
+	for _, step := range steps {
+		step.change()
+		got := show()
+		for _, want := range step.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: output lacks %q", step.name, want)
+			}
+		}
+		for _, absent := range step.absent {
+			if strings.Contains(got, absent) {
+				t.Errorf("%s: output has %q", step.name, absent)
+			}
+		}
+	}
+	if got := screen.GetCell(2, 1).Ch; got != ' ' {
+		t.Errorf("kitty image drawn into the cells: %q", got)
+	}
+}
+
+func TestShowITerm2Images(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(6, 2)
+	screen.out = &out
+	screen.SetGraphicsProtocol(GraphicsITerm2)
+	show := func() string {
+		t.Helper()
+		out.Reset()
+		if err := screen.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		return out.String()
+	}
+
+	// The PNG of a 2×2 image is not a multiple of 3 bytes long, so its
+	// base64 form is padded
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatal(err)
+	}
+	im := screen.AddImage(img, Rect{X: 1, Y: 0, W: 2, H: 1})
+	var layer *Layer
+	steps := []struct {
+		name   string
+		change func()
+		want   []string
+		absent []string
+	}{
+		{"added", func() {}, []string{fmt.Sprintf("\x1b[1;2H\x1b]1337;File=inline=1;size=%d;width=2;height=1;preserveAspectRatio=0:", encoded.Len()), "\x1b[2C"}, nil},
+		{"unchanged", func() {}, []string{"\x1b[2C"}, []string{"1337"}},
+		{"covered", func() {
+			layer = screen.AddLayer(1)
+			layer.DrawText(1, 0, "X", ColorDefault(), ColorDefault(), StyleNone)
+		}, []string{"X", "\x1b[1C"}, []string{"1337", "\x1b[2C"}},
+		{"uncovered", func() { screen.RemoveLayer(layer) }, []string{"1337", "\x1b[2C"}, nil},
+		{"hidden", func() { im.SetVisible(false) }, nil, []string{"1337", "C"}},
+		{"shown", func() { im.SetVisible(true) }, []string{"1337", "\x1b[2C"}, nil},
+		{"moved", func() { im.SetRect(Rect{X: 4, Y: 1, W: 2, H: 1}) }, []string{"\x1b[2;5H\x1b]1337;"}, []string{"\x1b[2C"}},
+	}
+	for _, step := range steps {
+		step.change()
+		got := show()
+		for _, want := range step.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: output lacks %q", step.name, want)
+			}
+		}
+		for _, absent := range step.absent {
+			if strings.Contains(got, absent) {
+				t.Errorf("%s: output has %q", step.name, absent)
+			}
+		}
+	}
+}
+
+func TestAppRun(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(4, 2)
+	screen.out = &out
+
+	var keys []rune
+	frames := 0
+	var app *App
+	app = &App{
+		Screen: screen,
+		FPS:    1000,
+		Input:  strings.NewReader("ab"),
+		OnEvent: func(ev Event) bool {
+			if k, ok := ev.(KeyEvent); ok {
+				keys = append(keys, k.Rune)
+			}
+			return false
+		},
+		Update: func(time.Duration) {
+			// Quit once the input has been read and a few frames drawn
+			if frames++; frames >= 3 && len(keys) == 2 {
+				app.Post(KeyEvent{Key: KeyRune, Rune: 'c', Modifiers: ModCtrl})
+			}
+		},
+		Draw: func(s *Screen) { s.DrawText(0, 0, "hi", ColorDefault(), ColorDefault(), StyleNone) },
+	}
+	app.Post(ResizeEvent{Width: 3, Height: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Run() did not quit on Ctrl+C")
+	}
+	if got := string(keys); got != "abc" {
+		t.Errorf("keys dispatched = %q, want %q", got, "abc")
+	}
+	if w, h := screen.Size(); w != 3 || h != 1 {
+		t.Errorf("Size() after resize = %dx%d, want 3x1", w, h)
+	}
+	if got := screen.GetCell(1, 0).Ch; got != 'i' {
+		t.Errorf("cell (1, 0) = %q, want 'i' drawn by Draw", got)
+	}
+	written := out.String()
+	if !strings.HasPrefix(written, pasteOn) || !strings.HasSuffix(written, pasteOff) {
+		t.Errorf("Run() did not turn bracketed paste on and off: %q", written)
+	}
+}
+
+// loadedMsg is the message of the commands in TestAppGo
+type loadedMsg struct {
+	n int
+}
+
+func TestAppGo(t *testing.T) {
+	screen := NewScreen(1, 1)
+	screen.out = io.Discard
+	release := make(chan struct{})
+	sum := 0
+	var app *App
+	app = &App{
+		Screen: screen,
+		Input:  strings.NewReader(""),
+		OnMessage: func(msg Msg) {
+			if m, ok := msg.(loadedMsg); ok {
+				sum += m.n
+				if sum == 3 {
+					app.Go(Quit)
+				}
+			}
+		},
+	}
+	app.Go(Batch(
+		func() Msg { return loadedMsg{1} },
+		func() Msg { <-release; return loadedMsg{2} },
+		nil,
+	))
+	app.Go(func() Msg { return nil })
+	close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Run() did not stop on the Quit command")
+	}
+	if sum != 3 {
+		t.Errorf("messages added up to %d, want 3", sum)
+	}
+	select {
+	case <-app.Context().Done():
+	default:
+		t.Error("Context() not canceled after Run returned")
+	}
+}
+
+func TestAppTimers(t *testing.T) {
+	screen := NewScreen(1, 1)
+	screen.out = io.Discard
+	var app *App
+	app = &App{Screen: screen, Input: strings.NewReader("")}
+	every := app.Every(time.Millisecond)
+	after := app.After(20 * time.Millisecond)
+	stopped := app.After(time.Millisecond)
+	stopped.Stop()
+
+	ticks, late := 0, 0
+	app.OnEvent = func(ev Event) bool {
+		tick, ok := ev.(TickEvent)
+		if !ok {
+			return false
+		}
+		switch tick.Timer {
+		case every:
+			if every.Stopped() {
+				late++
+			}
+			ticks++
+		case after:
+			every.Stop()
+			app.After(10 * time.Millisecond)
+		case stopped:
+			t.Error("tick from a stopped timer delivered")
+		default:
+			app.Quit()
+		}
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Run() did not stop after the last timer")
+	}
+	if ticks < 2 || late > 0 {
+		t.Errorf("Every delivered %d ticks, %d after Stop; want several, none late", ticks, late)
+	}
+}
+
+func TestAppMiddlewareAndLogger(t *testing.T) {
+	screen := NewScreen(2, 1)
+	screen.out = io.Discard
+	var keys []string
+	frames := 0
+	var app *App
+	app = &App{
+		Screen: screen,
+		FPS:    1000,
+		Input:  strings.NewReader("\x1b[Ax"),
+		OnEvent: func(ev Event) bool {
+			keys = append(keys, ev.(KeyEvent).String())
+			return false
+		},
+		Middleware: []Middleware{func(msg Msg, next func(Msg)) {
+			// Drop x
+			if k, ok := msg.(KeyEvent); !ok || k.Rune != 'x' {
+				next(msg)
+			}
+		}},
+		OnFrame: func(stats FrameStats) {
+			if frames++; frames == 2 {
+				app.Go(Quit)
+			}
+		},
+	}
+	var log bytes.Buffer
+	logger := NewLogger(&log)
+	logger.Attach(app)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.Join(keys, " "); got != "Up" {
+		t.Errorf("keys after middleware = %q, want %q", got, "Up")
+	}
+	for _, want := range []string{
+		`input "\x1b[Ax"`,
+		"event goterm.KeyEvent Up (",
+		"event goterm.KeyEvent x (",
+		"message goterm.quitMsg {} (",
+		"frame 1 interval=",
+		"frame 2 interval=",
+	} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("log does not contain %q:\n%s", want, log.String())
+		}
+	}
+	if strings.Contains(log.String(), "bytes=0 ") {
+		t.Errorf("frame logged without bytes written:\n%s", log.String())
+	}
+	if logger.Err() != nil {
+		t.Errorf("Err() = %v", logger.Err())
+	}
+}
+
+func TestAppSignalShutdown(t *testing.T) {
+	screen := NewScreen(1, 1)
+	screen.out = io.Discard
+	var order []int
+	app := &App{Screen: screen, Input: strings.NewReader(""), Signals: []os.Signal{syscall.SIGHUP}}
+	app.Defer(func() { order = append(order, 1) })
+	app.Defer(func() { order = append(order, 2) })
+	app.OnFrame = func(FrameStats) {
+		self, err := os.FindProcess(os.Getpid())
+		if err == nil {
+			err = self.Signal(syscall.SIGHUP)
+		}
+		if err != nil {
+			t.Skipf("cannot signal the test process: %v", err)
+		}
+		app.OnFrame = nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Run() did not stop on SIGHUP")
+	}
+	if len(order) != 2 || order[0] != 2 || order[1] != 1 {
+		t.Errorf("deferred functions ran in order %v, want [2 1]", order)
+	}
+}
+
+func TestAppCommandPanic(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(1, 1)
+	screen.out = &out
+	tornDown := false
+	app := &App{Screen: screen, Input: strings.NewReader("")}
+	app.Defer(func() { tornDown = true })
+	app.Go(func() Msg { panic("boom") })
+
+	defer func() {
+		v := recover()
+		if v == nil || !strings.Contains(fmt.Sprint(v), "boom") {
+			t.Errorf("Run() panicked with %v, want the command's panic", v)
+		}
+		if !tornDown {
+			t.Error("deferred function not run on panic")
+		}
+		if !strings.HasSuffix(out.String(), pasteOff) {
+			t.Errorf("terminal modes not reset on panic: %q", out.String())
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = app.Run(ctx)
+	t.Error("Run() returned instead of panicking")
+}
+
+func TestAppHUD(t *testing.T) {
+	screen := NewScreen(30, 8)
+	screen.out = io.Discard
+	row := func(y int) string {
+		b := screen.Composite()
+		var sb strings.Builder
+		for x := 0; x < 30; x++ {
+			sb.WriteRune(b.GetCell(x, y).Ch)
+		}
+		return sb.String()
+	}
+
+	var changed []int
+	var first, shown string
+	var app *App
+	app = &App{Screen: screen, FPS: 1000, Input: strings.NewReader("")}
+	app.ToggleHUD()
+	app.OnFrame = func(stats FrameStats) {
+		changed = append(changed, stats.Changed)
+		switch stats.Frame {
+		case 1:
+			first = row(1)
+		case 3:
+			shown = row(1)
+			app.SetHUD(false)
+		case 5:
+			app.Quit()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(first, "│FPS ") {
+		t.Errorf("HUD row of the first frame = %q, want the HUD shown at once", first)
+	}
+	if !strings.Contains(shown, "│FPS ") {
+		t.Errorf("HUD row = %q, want the frame rate", shown)
+	}
+	if app.HUD() || strings.Contains(row(1), "FPS") {
+		t.Errorf("HUD still shown after SetHUD(false): %q", row(1))
+	}
+	if len(changed) < 3 || changed[0] != 30*8 || changed[1] == 0 {
+		t.Errorf("changed cells per frame = %v, want all at first, then the HUD", changed)
+	}
+}
+
+func TestAppWatchTheme(t *testing.T) {
+	screen := NewScreen(10, 2)
+	screen.out = io.Discard
+	path := t.TempDir() + "/theme.json"
+	write := func(data string, age time.Duration) {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		// Make every write visible even on file systems with coarse times
+		mtime := time.Now().Add(-age)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	red, _ := ParseColor("red")
+	blue, _ := ParseColor("blue")
+	write(`{"primary": "red"}`, time.Hour)
+
+	var events []ThemeEvent
+	var primaries []Color
+	var app *App
+	app = &App{Screen: screen, FPS: 1000, Input: strings.NewReader("")}
+	app.OnEvent = func(ev Event) bool {
+		te, ok := ev.(ThemeEvent)
+		if !ok {
+			return false
+		}
+		events = append(events, te)
+		primaries = append(primaries, screen.Theme().Primary)
+		switch len(events) {
+		case 1:
+			write(`{"primary": "blue"}`, time.Minute)
+		case 2:
+			write(`{"primary": `, 0)
+		case 3:
+			app.Quit()
+		}
+		return true
+	}
+	if err := app.WatchTheme(t.TempDir() + "/missing.json"); err == nil {
+		t.Error("WatchTheme() of a missing file succeeded")
+	}
+	if err := app.WatchTheme(path); err != nil {
+		t.Fatalf("WatchTheme() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d theme events, want 3", len(events))
+	}
+	if events[0].Err != nil || events[1].Err != nil || events[2].Err == nil {
+		t.Errorf("theme event errors = %v, %v, %v; want only the last", events[0].Err, events[1].Err, events[2].Err)
+	}
+	if primaries[0] != red || primaries[1] != blue || primaries[2] != blue {
+		t.Errorf("primary colors = %v, want red, blue, then unchanged", primaries)
+	}
+}
+
+// blankWidget is a Drawable that draws nothing
+type blankWidget struct{}
+
+func (blankWidget) Draw(Surface) {}
+
+func TestHUDBelowLayoutDebug(t *testing.T) {
+	screen := NewScreen(30, 8)
+	screen.out = io.Discard
+	screen.AddLayout(func(area Rect) []Placement {
+		return []Placement{{Rect: area, Widget: blankWidget{}}}
+	})
+	screen.SetLayoutDebug(true)
+
+	var app *App
+	app = &App{Screen: screen, FPS: 1000, Input: strings.NewReader(""), Draw: func(*Screen) {}}
+	app.SetHUD(true)
+	app.OnFrame = func(stats FrameStats) {
+		if stats.Frame == 2 {
+			app.Quit()
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// The outline of the layout and the HUD box share the top-right corner
+	b := screen.Composite()
+	if c := b.GetCell(29, 0); c.Ch != '┐' || c.Fg != ColorRed {
+		t.Errorf("overlapping cell = %q in %v, want the layout outline over the HUD", c.Ch, c.Fg)
+	}
+	if c := b.GetCell(15, 1); c.Fg != ColorWhite || c.Bg != ColorBlack {
+		t.Errorf("HUD cell = %+v, want white on black", c)
+	}
+}
+
+// counterModel counts '+' key presses, doubling the count when told by a
+// command, and quits at 6
+type counterModel struct {
+	count int
+}
+
+// doubleMsg is the message of the command counterModel runs
+type doubleMsg struct{}
+
+func (m counterModel) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case KeyEvent:
+		if msg.Rune == '+' {
+			m.count++
+			if m.count == 3 {
+				return m, func() Msg { return doubleMsg{} }
+			}
+		}
+	case doubleMsg:
+		m.count *= 2
+	}
+	if m.count == 6 {
+		return m, Quit
+	}
+	return m, nil
+}
+
+func (m counterModel) View(s *Screen) {
+	s.DrawText(0, 0, strconv.Itoa(m.count), ColorDefault(), ColorDefault(), StyleNone)
+}
+
+func TestProgram(t *testing.T) {
+	screen := NewScreen(4, 1)
+	screen.out = io.Discard
+	p := NewProgram(counterModel{})
+	p.App.Screen = screen
+	p.App.FPS = 1000
+	p.App.Input = strings.NewReader("+++")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	final, err := p.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Run() did not stop on Quit")
+	}
+	if got := final.(counterModel).count; got != 6 {
+		t.Errorf("final count = %d, want 6", got)
+	}
+}
+
+func BenchmarkShow(b *testing.B) {
+	screen := NewScreen(200, 60)
+	screen.out = io.Discard
+	colors := []Color{ColorRed, ColorRGB(255, 128, 0), ColorIndex(208), ColorDefault()}
+	for y := 0; y < 60; y++ {
+		for x := 0; x < 200; x++ {
+			screen.SetCell(x, y, NewCell('A', colors[(x/3)%len(colors)], colors[y%len(colors)], Style(x%3)))
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := screen.Show(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package goterm
+
+import "slices"
+
+// ColorStop is a color at a position between 0 and 1 along a gradient
+type ColorStop struct {
+	Pos   float64
+	Color Color
+}
+
+// Gradient is a smooth transition through a series of colors
+// Between two stops colors are mixed as by Color.Blend; before the first
+// and after the last stop the gradient keeps their colors.
+type Gradient struct {
+	stops []ColorStop
+}
+
+// NewGradient creates a gradient through the given colors, evenly spaced
+// from 0 to 1
+func NewGradient(colors ...Color) Gradient {
+	stops := make([]ColorStop, len(colors))
+	for i, c := range colors {
+		stops[i] = ColorStop{Color: c}
+		if len(colors) > 1 {
+			stops[i].Pos = float64(i) / float64(len(colors)-1)
+		}
+	}
+	return Gradient{stops: stops}
+}
+
+// NewGradientStops creates a gradient from stops at explicit positions
+// The stops may be given in any order.
+func NewGradientStops(stops ...ColorStop) Gradient {
+	stops = slices.Clone(stops)
+	slices.SortStableFunc(stops, func(a, b ColorStop) int {
+		switch {
+		case a.Pos < b.Pos:
+			return -1
+		case a.Pos > b.Pos:
+			return 1
+		}
+		return 0
+	})
+	return Gradient{stops: stops}
+}
+
+// At returns the color at position t, where 0 is the start and 1 the end of
+// the gradient
+// A gradient without stops is the default color.
+func (g Gradient) At(t float64) Color {
+	if len(g.stops) == 0 {
+		return ColorDefault()
+	}
+	if t <= g.stops[0].Pos {
+		return g.stops[0].Color
+	}
+	for i := 1; i < len(g.stops); i++ {
+		prev, next := g.stops[i-1], g.stops[i]
+		if t <= next.Pos {
+			return prev.Color.Blend(next.Color, (t-prev.Pos)/(next.Pos-prev.Pos))
+		}
+	}
+	return g.stops[len(g.stops)-1].Color
+}
+
+// Colors returns n colors sampled evenly from the start to the end of the
+// gradient, e.g. to color the characters of a title
+func (g Gradient) Colors(n int) []Color {
+	colors := make([]Color, max(n, 0))
+	for i := range colors {
+		colors[i] = g.At(gradientPos(i, n))
+	}
+	return colors
+}
+
+// gradientPos returns the position of step i out of n, from 0 to 1
+func gradientPos(i, n int) float64 {
+	if n <= 1 {
+		return 0
+	}
+	return float64(i) / float64(n-1)
+}
+
+// FillGradient colors the background of rect with the gradient running
+// along the given orientation
+// Characters, foreground colors and styles are kept, so text can be drawn
+// before or after; on a cleared region the result is a solid color band.
+func (s *Screen) FillGradient(rect Rect, g Gradient, orientation Orientation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FillGradient(rect, g, orientation)
+}
+
+// FillGradient colors the background of rect with the gradient running
+// along the given orientation
+// See Screen.FillGradient.
+func (b *Buffer) FillGradient(rect Rect, g Gradient, orientation Orientation) {
+	steps := rect.W
+	if orientation == Vertical {
+		steps = rect.H
+	}
+	colors := g.Colors(steps)
+
+	b.ForEach(rect, func(x, y int, c Cell) Cell {
+		i := x - rect.X
+		if orientation == Vertical {
+			i = y - rect.Y
+		}
+		c.Bg = colors[i]
+		return c
+	})
+}
@@ -0,0 +1,161 @@
+package goterm
+
+import (
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	textPresentation  = '\ufe0e'
+	emojiPresentation = '\ufe0f'
+)
+
+// widthOverrides holds per-cluster display widths that take precedence over
+// the Unicode-derived widths
+var widthOverrides = struct {
+	sync.RWMutex
+	m map[string]int
+}{m: make(map[string]int)}
+
+// terminalWidthOverrides lists known width disagreements of specific terminal
+// emulators, keyed by the TERM_PROGRAM or TERM value that identifies them
+var terminalWidthOverrides = map[string]map[string]int{
+	// The Linux console sizes emoji presentation sequences by their base character
+	"linux": {
+		"\u2764\ufe0f": 1, // ❤️
+		"\u263a\ufe0f": 1, // ☺️
+		"\u2600\ufe0f": 1, // ☀️
+		"\u2714\ufe0f": 1, // ✔️
+		"\u270c\ufe0f": 1, // ✌️
+		"\u26a0\ufe0f": 1, // ⚠️
+		"\u2708\ufe0f": 1, // ✈️
+		"\u260e\ufe0f": 1, // ☎️
+	},
+}
+
+// SetWidthOverride forces the display width of a grapheme cluster, for
+// terminals that render a particular emoji or sequence differently from the
+// Unicode rules. A negative width removes the override.
+func SetWidthOverride(cluster string, width int) {
+	widthOverrides.Lock()
+	defer widthOverrides.Unlock()
+
+	if width < 0 {
+		delete(widthOverrides.m, cluster)
+		return
+	}
+	widthOverrides.m[cluster] = width
+}
+
+// ClearWidthOverrides removes all cluster width overrides
+func ClearWidthOverrides() {
+	widthOverrides.Lock()
+	defer widthOverrides.Unlock()
+	widthOverrides.m = make(map[string]int)
+}
+
+// ApplyTerminalWidthOverrides installs the built-in override table for the
+// named terminal (a TERM_PROGRAM or TERM value)
+// Returns false if no table is known for that terminal.
+func ApplyTerminalWidthOverrides(name string) bool {
+	table, ok := terminalWidthOverrides[name]
+	if !ok {
+		return false
+	}
+	for cluster, width := range table {
+		SetWidthOverride(cluster, width)
+	}
+	return true
+}
+
+// lookupWidthOverride returns the overridden width for a cluster, if any
+func lookupWidthOverride(cluster string) (int, bool) {
+	widthOverrides.RLock()
+	defer widthOverrides.RUnlock()
+
+	if len(widthOverrides.m) == 0 {
+		return 0, false
+	}
+	w, ok := widthOverrides.m[cluster]
+	return w, ok
+}
+
+// isRegionalIndicator reports whether r is one of the flag letter symbols
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isEmojiExtender reports whether r extends the preceding emoji
+// (variation selectors, skin tone modifiers and tag characters)
+func isEmojiExtender(r rune) bool {
+	return (r >= 0xfe00 && r <= 0xfe0f) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) ||
+		(r >= 0xe0020 && r <= 0xe007f) ||
+		r == 0x20e3 // combining enclosing keycap
+}
+
+// isCombining reports whether r is a combining mark that attaches to the
+// preceding base character (diacritics, Arabic harakat, Indic vowel signs)
+func isCombining(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+// nextCluster returns the length in bytes of the grapheme cluster at the
+// start of s
+func nextCluster(s string) int {
+	first, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return 0
+	}
+
+	pairedFlag := false
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case isCombining(r), isEmojiExtender(r):
+			n += size
+		case r == zeroWidthJoiner:
+			n += size
+			// The joiner glues the following character into the cluster
+			if n < len(s) {
+				_, next := utf8.DecodeRuneInString(s[n:])
+				n += next
+			}
+		case isRegionalIndicator(first) && isRegionalIndicator(r) && !pairedFlag:
+			n += size
+			pairedFlag = true
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// clusterWidth returns the number of columns a grapheme cluster occupies
+func clusterWidth(cluster string) int {
+	if w, ok := lookupWidthOverride(cluster); ok {
+		return w
+	}
+
+	first, n := utf8.DecodeRuneInString(cluster)
+	if n == len(cluster) {
+		return RuneWidth(first)
+	}
+
+	w := RuneWidth(first)
+	if isRegionalIndicator(first) {
+		// A pair of regional indicators renders as a single flag
+		return 2
+	}
+	for _, r := range cluster[n:] {
+		switch r {
+		case emojiPresentation, zeroWidthJoiner:
+			w = 2
+		case textPresentation:
+			w = 1
+		}
+	}
+	return w
+}
@@ -0,0 +1,92 @@
+package goterm
+
+import (
+	"fmt"
+	"time"
+)
+
+// DebugHUDZ is the z-index of the debug HUD, above every other layer except
+// the layout debugging overlay, which is drawn over the HUD where they meet
+const DebugHUDZ = LayoutDebugZ - 1
+
+// hudWidth is the width of the debug HUD panel
+const hudWidth = 20
+
+// hud is the state of an App's debug HUD
+type hud struct {
+	layer *Layer
+	fps   float64    // Smoothed frames per second
+	last  FrameStats // Last frame shown
+}
+
+// SetHUD shows or hides the debug HUD
+// The HUD is a small panel in the top right corner of the screen, drawn on a
+// layer above everything else, showing the frame rate, the time the last
+// frame took to update, draw and show, how many cells it changed, the bytes
+// it wrote to the terminal, and how many messages were waiting in the
+// queue. It is drawn just before each frame is shown, with the statistics
+// of the frame before, so it appears or disappears with the next frame. It
+// is safe to call from any goroutine, e.g. from a key binding.
+func (a *App) SetHUD(enabled bool) {
+	a.hudOn.Store(enabled)
+}
+
+// HUD reports whether the debug HUD is shown
+func (a *App) HUD() bool {
+	return a.hudOn.Load()
+}
+
+// ToggleHUD shows the debug HUD if it is hidden and hides it otherwise
+func (a *App) ToggleHUD() {
+	a.SetHUD(!a.HUD())
+}
+
+// recordHUD records the stats of a frame shown, for the HUD of the next
+func (a *App) recordHUD(stats FrameStats) {
+	h := &a.hud
+	if stats.Interval > 0 {
+		fps := float64(time.Second) / float64(stats.Interval)
+		if h.fps == 0 {
+			h.fps = fps
+		}
+		// Smooth over about a second at 30 frames per second
+		h.fps += (fps - h.fps) / 30
+	}
+	h.last = stats
+}
+
+// drawHUD updates the HUD with the stats of the last frame shown, adding or
+// removing its layer as it was turned on or off
+func (a *App) drawHUD(screen *Screen) {
+	h := &a.hud
+	if !a.HUD() {
+		if h.layer != nil {
+			screen.RemoveLayer(h.layer)
+			h.layer = nil
+		}
+		return
+	}
+	if h.layer == nil {
+		h.layer = screen.AddLayer(DebugHUDZ)
+	}
+
+	h.layer.Clear()
+	w, _ := screen.Size()
+	stats := h.last
+	frame := stats.Update + stats.Draw + stats.Show
+	rows := []string{
+		fmt.Sprintf("FPS     %10.1f", h.fps),
+		fmt.Sprintf("Frame   %10s", frame.Round(time.Microsecond)),
+		fmt.Sprintf("Changed %10d", stats.Changed),
+		fmt.Sprintf("Bytes   %10d", stats.Bytes),
+		fmt.Sprintf("Queue   %10d", stats.Queued),
+	}
+	x := max(0, w-hudWidth)
+	fg, bg := ColorWhite, ColorBlack
+	h.layer.Fill(x, 0, hudWidth, len(rows)+2, NewCell(' ', fg, bg, StyleNone))
+	h.layer.DrawBox(x, 0, hudWidth, len(rows)+2, BorderSingle, ColorYellow, bg)
+	h.layer.DrawText(x+2, 0, "HUD", ColorYellow, bg, StyleBold)
+	for i, row := range rows {
+		h.layer.DrawText(x+1, i+1, row, fg, bg, StyleNone)
+	}
+}
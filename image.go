@@ -0,0 +1,393 @@
+package goterm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// GraphicsProtocol is the way a screen shows images
+type GraphicsProtocol int
+
+const (
+	// GraphicsCells draws images with half-block characters, two pixels to a
+	// cell, which works on any terminal with colors
+	GraphicsCells GraphicsProtocol = iota
+	// GraphicsKitty sends images at full resolution with the kitty graphics
+	// protocol, supported by kitty, WezTerm and Ghostty
+	GraphicsKitty
+	// GraphicsITerm2 sends images at full resolution as iTerm2 inline images
+	GraphicsITerm2
+)
+
+// kittyChunkSize is the most base64 image data sent in one escape sequence
+const kittyChunkSize = 4096
+
+// kittyZ is the z-index of kitty image placements: below text and below
+// cells with a background color, so that layers drawn over an image, such
+// as a dialog, cover it as they would cover other content
+const kittyZ = -1<<30 - 1
+
+// Image is a picture shown in a rectangle of screen cells
+// It sits above the screen's own content and below every layer, and is
+// scaled to fill its rectangle. How it is shown depends on the screen's
+// GraphicsProtocol: with GraphicsCells it is drawn with half-block
+// characters when the screen is composited, while with GraphicsKitty its
+// pixels are sent to the terminal once and only placed again when it moves,
+// so it stays on screen across frames without being sent again. The cells
+// under a kitty image should be left blank with the default background,
+// since text and background colors are drawn over it. With GraphicsITerm2
+// the image replaces the cells it covers, which Show leaves alone until the
+// image moves, is hidden or a layer is drawn over it.
+type Image struct {
+	screen  *Screen
+	id      uint32
+	img     image.Image
+	rect    Rect
+	visible bool
+	cells   []Cell // Half-block rendering of img in rect
+
+	// Terminal state, guarded by the screen's frameMu
+	sent    bool // Pixels transmitted with the kitty protocol
+	placed  Rect // Where the terminal shows the image, empty if nowhere
+	covered bool // Partly covered by a layer when last shown with iTerm2
+}
+
+// AddImage shows img in rect, in cells, until the image is removed
+func (s *Screen) AddImage(img image.Image, rect Rect) *Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextImageID++
+	im := &Image{screen: s, id: s.nextImageID, img: img, rect: rect, visible: true}
+	im.render()
+	s.images = append(s.images, im)
+	return im
+}
+
+// RemoveImage takes an image off the screen and frees its pixels in the
+// terminal
+func (s *Screen) RemoveImage(im *Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.images = slices.DeleteFunc(s.images, func(other *Image) bool { return other == im })
+
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+	if im.sent {
+		s.deletedImages = append(s.deletedImages, im.id)
+		im.sent, im.placed = false, Rect{}
+	}
+}
+
+// ID returns the number identifying the image to the terminal
+func (im *Image) ID() uint32 {
+	return im.id
+}
+
+// Rect returns the cells the image covers
+func (im *Image) Rect() Rect {
+	im.screen.mu.RLock()
+	defer im.screen.mu.RUnlock()
+	return im.rect
+}
+
+// SetRect moves or resizes the image to cover rect
+func (im *Image) SetRect(rect Rect) {
+	im.screen.mu.Lock()
+	defer im.screen.mu.Unlock()
+	im.rect = rect
+	im.render()
+}
+
+// SetImage replaces the picture shown, e.g. for the next frame of an
+// animation, which sends it to the terminal again
+func (im *Image) SetImage(img image.Image) {
+	im.screen.mu.Lock()
+	defer im.screen.mu.Unlock()
+	im.img = img
+	im.render()
+
+	im.screen.frameMu.Lock()
+	defer im.screen.frameMu.Unlock()
+	im.sent, im.placed = false, Rect{}
+}
+
+// Visible reports whether the image is shown
+func (im *Image) Visible() bool {
+	im.screen.mu.RLock()
+	defer im.screen.mu.RUnlock()
+	return im.visible
+}
+
+// SetVisible shows or hides the image, keeping its pixels in the terminal
+func (im *Image) SetVisible(visible bool) {
+	im.screen.mu.Lock()
+	defer im.screen.mu.Unlock()
+	im.visible = visible
+}
+
+// render draws the image into cells with half blocks, the upper half in the
+// foreground color and the lower half in the background color
+// Must be called with the screen lock held.
+func (im *Image) render() {
+	w, h := max(0, im.rect.W), max(0, im.rect.H)
+	im.cells = make([]Cell, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			top := samplePixel(im.img, x, 2*y, w, 2*h)
+			bottom := samplePixel(im.img, x, 2*y+1, w, 2*h)
+			cell := NewCell('▀', top, bottom, StyleNone)
+			if top.Alpha() == 0 && bottom.Alpha() == 0 {
+				cell = CellTransparent()
+			}
+			im.cells[y*w+x] = cell
+		}
+	}
+}
+
+// samplePixel returns the average color of the area of img that pixel
+// (x, y) covers when img is scaled to w×h pixels
+func samplePixel(img image.Image, x, y, w, h int) Color {
+	bounds := img.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	if sw == 0 || sh == 0 {
+		return ColorRGBA(0, 0, 0, 0)
+	}
+	x0, y0 := x*sw/w, y*sh/h
+	x1, y1 := max(x0+1, (x+1)*sw/w), max(y0+1, (y+1)*sh/h)
+
+	var r, g, b, a, n uint64
+	for sy := y0; sy < y1; sy++ {
+		for sx := x0; sx < x1; sx++ {
+			pr, pg, pb, pa := img.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+			r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+		}
+	}
+	if a == 0 {
+		return ColorRGBA(0, 0, 0, 0)
+	}
+	// Colors are premultiplied by alpha, so divide by the total alpha
+	return ColorRGBA(uint8(r*255/a), uint8(g*255/a), uint8(b*255/a), uint8(a/n>>8)) // #nosec G115
+}
+
+// drawImages draws the visible images onto out with half blocks
+// Must be called with the screen lock held.
+func (s *Screen) drawImages(out *Buffer) {
+	for _, im := range s.images {
+		if !im.visible {
+			continue
+		}
+		area := im.rect.Intersect(out.Bounds())
+		for y := area.Y; y < area.Y+area.H; y++ {
+			row := (y-im.rect.Y)*im.rect.W + area.X - im.rect.X
+			out.overlay(area.X, y, im.cells[row:row+area.W])
+		}
+	}
+}
+
+// showImages writes the commands that bring the terminal's images up to
+// date, and returns which of cells, the composited screen about to be
+// written, must be skipped to keep the images on it
+// With the kitty protocol, removed images are freed, new ones are sent, and
+// images that are new or have moved are placed. iTerm2 images are part of
+// the cells they cover, so they are sent again whenever they are new, have
+// moved or changed, or were partly covered by a layer that has gone, and
+// the cells of the image that no layer covers are skipped.
+// Must be called with the screen lock held.
+func (s *Screen) showImages(cells []Cell) (skip []bool, err error) {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+
+	var b strings.Builder
+	for _, id := range s.deletedImages {
+		fmt.Fprintf(&b, "\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", id)
+	}
+	s.deletedImages = nil
+
+	switch s.graphics {
+	case GraphicsKitty:
+		for _, im := range s.images {
+			if !im.visible || im.rect.Empty() {
+				if !im.placed.Empty() {
+					fmt.Fprintf(&b, "\x1b_Ga=d,d=i,i=%d,q=2\x1b\\", im.id)
+					im.placed = Rect{}
+				}
+				continue
+			}
+			if !im.sent {
+				if err := kittyTransmit(&b, im.id, im.img); err != nil {
+					return nil, err
+				}
+				im.sent = true
+			}
+			if im.placed != im.rect {
+				// Placing again with the same placement id moves the image
+				fmt.Fprintf(&b, "\x1b[%d;%dH\x1b_Ga=p,i=%d,p=1,c=%d,r=%d,C=1,z=%d,q=2\x1b\\",
+					im.rect.Y+1, im.rect.X+1, im.id, im.rect.W, im.rect.H, kittyZ)
+				im.placed = im.rect
+			}
+		}
+	case GraphicsITerm2:
+		width := s.buf.width
+		for _, im := range s.images {
+			area := im.rect.Intersect(s.buf.Bounds())
+			if !im.visible || area.Empty() {
+				// The cells written over it by this Show erase the image
+				im.placed, im.covered = Rect{}, false
+				continue
+			}
+			covered := false
+			for y := area.Y; y < area.Y+area.H; y++ {
+				for x := area.X; x < area.X+area.W; x++ {
+					i := y*width + x
+					if cells[i] != s.buf.cells[i] {
+						covered = true
+						continue
+					}
+					if skip == nil {
+						skip = make([]bool, len(cells))
+					}
+					skip[i] = true
+				}
+			}
+			if im.placed != im.rect || im.covered && !covered {
+				if err := iterm2Image(&b, im.rect, im.img); err != nil {
+					return nil, err
+				}
+				im.placed = im.rect
+			}
+			im.covered = covered
+		}
+	}
+	if b.Len() == 0 {
+		return skip, nil
+	}
+	if _, err := io.WriteString(s.out, b.String()); err != nil {
+		return nil, fmt.Errorf("failed to show images: %w", err)
+	}
+	return skip, nil
+}
+
+// encodeImage returns img as a base64-encoded PNG, and the size of the PNG
+// in bytes
+func encodeImage(img image.Image) (payload string, size int, err error) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		return "", 0, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data.Bytes()), data.Len(), nil
+}
+
+// kittyTransmit writes the commands sending img to the terminal as a PNG,
+// split into chunks, without showing it
+func kittyTransmit(b *strings.Builder, id uint32, img image.Image) error {
+	payload, _, err := encodeImage(img)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(payload); i += kittyChunkSize {
+		chunk := payload[i:min(i+kittyChunkSize, len(payload))]
+		more := 0
+		if i+kittyChunkSize < len(payload) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(b, "\x1b_Ga=t,f=100,t=d,i=%d,q=2,m=%d;%s\x1b\\", id, more, chunk)
+		} else {
+			fmt.Fprintf(b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return nil
+}
+
+// iterm2Image writes the iTerm2 inline image sequence drawing img as a PNG
+// stretched over rect
+func iterm2Image(b *strings.Builder, rect Rect, img image.Image) error {
+	payload, size, err := encodeImage(img)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(b, "\x1b[%d;%dH\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a",
+		rect.Y+1, rect.X+1, size, rect.W, rect.H, payload)
+	return nil
+}
+
+// invalidateImages makes the next Show place every image again, after the
+// terminal has been cleared or resized
+func (s *Screen) invalidateImages() {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+	for _, im := range s.images {
+		im.placed = Rect{}
+	}
+}
+
+// imageReset returns the sequence that frees every image in the terminal,
+// for when the application exits
+func (s *Screen) imageReset() string {
+	if s.GraphicsProtocol() != GraphicsKitty {
+		return ""
+	}
+	return "\x1b_Ga=d,d=A,q=2\x1b\\"
+}
+
+// SetGraphicsProtocol sets how images are shown
+// Images already sent to the terminal with the kitty protocol are freed by
+// the next Show when switching to another protocol.
+func (s *Screen) SetGraphicsProtocol(p GraphicsProtocol) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.graphics == p {
+		return
+	}
+	s.graphics = p
+
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+	for _, im := range s.images {
+		if im.sent && p != GraphicsKitty {
+			s.deletedImages = append(s.deletedImages, im.id)
+		}
+		im.sent, im.placed = false, Rect{}
+	}
+}
+
+// GraphicsProtocol returns how images are shown
+func (s *Screen) GraphicsProtocol() GraphicsProtocol {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.graphics
+}
+
+// DetectGraphicsProtocol guesses the best way to show images from the
+// environment
+// kitty, WezTerm and Ghostty support the kitty graphics protocol and iTerm2
+// its own inline images; other terminals, and terminal multiplexers, which
+// do not pass images through, get GraphicsCells.
+func DetectGraphicsProtocol() GraphicsProtocol {
+	termName := os.Getenv("TERM")
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(termName, "screen") {
+		return GraphicsCells
+	}
+	switch {
+	case termName == "xterm-kitty", termName == "xterm-ghostty", os.Getenv("KITTY_WINDOW_ID") != "":
+		return GraphicsKitty
+	}
+	switch strings.ToLower(os.Getenv("TERM_PROGRAM")) {
+	case "wezterm", "ghostty":
+		return GraphicsKitty
+	case "iterm.app":
+		return GraphicsITerm2
+	}
+	// Set by iTerm2 and passed on by ssh
+	if os.Getenv("LC_TERMINAL") == "iTerm2" {
+		return GraphicsITerm2
+	}
+	return GraphicsCells
+}
@@ -0,0 +1,295 @@
+package goterm
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Escape sequences that bracket pasted text
+const (
+	pasteStart = "\x1b[200~"
+	pasteEnd   = "\x1b[201~"
+)
+
+// csiTildeKeys are the keys sent as CSI n ~, by n
+var csiTildeKeys = map[int]Key{
+	1: KeyHome, 2: KeyInsert, 3: KeyDelete, 4: KeyEnd, 5: KeyPageUp, 6: KeyPageDown,
+	7: KeyHome, 8: KeyEnd,
+	11: KeyF1, 12: KeyF2, 13: KeyF3, 14: KeyF4, 15: KeyF5,
+	17: KeyF6, 18: KeyF7, 19: KeyF8, 20: KeyF9, 21: KeyF10, 23: KeyF11, 24: KeyF12,
+}
+
+// finalKeys are the keys sent as CSI or SS3 sequences ending in a letter,
+// by that letter
+var finalKeys = map[byte]Key{
+	'A': KeyUp, 'B': KeyDown, 'C': KeyRight, 'D': KeyLeft, 'H': KeyHome, 'F': KeyEnd,
+	'P': KeyF1, 'Q': KeyF2, 'R': KeyF3, 'S': KeyF4,
+}
+
+// InputDecoder turns the bytes a terminal sends in raw mode into events
+// It understands UTF-8 text, control characters, the CSI and SS3 sequences
+// of the cursor, editing and function keys with xterm modifiers, SGR and
+// X10 mouse reports, and bracketed paste. Alt is recognized as an Escape
+// prefix. Unknown sequences are dropped.
+//
+// Input may be split anywhere, so a sequence cut short at the end of Feed is
+// kept until more input arrives. Escape is both a key and the start of every
+// sequence; a lone Escape is only reported by Flush, which callers should
+// call when no more input has arrived for a short while.
+type InputDecoder struct {
+	pending []byte
+}
+
+// Feed decodes p, with any input left over from earlier calls, and returns
+// the events it completes
+func (d *InputDecoder) Feed(p []byte) []Event {
+	d.pending = append(d.pending, p...)
+	return d.decode(false)
+}
+
+// Flush decodes the input left over from Feed as if no more will follow, so
+// a lone Escape becomes KeyEscape and an unfinished paste is delivered
+func (d *InputDecoder) Flush() []Event {
+	return d.decode(true)
+}
+
+// Pending reports whether input is waiting for more to complete it
+func (d *InputDecoder) Pending() bool {
+	return len(d.pending) > 0
+}
+
+// decode decodes as much pending input as it can
+func (d *InputDecoder) decode(final bool) []Event {
+	var events []Event
+	p := d.pending
+	for len(p) > 0 {
+		ev, n := decodeEvent(p, final)
+		if n == 0 {
+			break
+		}
+		if ev != nil {
+			events = append(events, ev)
+		}
+		p = p[n:]
+	}
+	d.pending = append(d.pending[:0], p...)
+	return events
+}
+
+// decodeEvent decodes the event at the start of p and returns it with the
+// number of bytes it took
+// It returns 0 bytes if p is the start of an event cut short, unless final
+// is set, and a nil event for input that is dropped.
+func decodeEvent(p []byte, final bool) (Event, int) {
+	if bytes.HasPrefix(p, []byte(pasteStart)) {
+		end := bytes.Index(p, []byte(pasteEnd))
+		switch {
+		case end >= 0:
+			return pasteEvent(p[len(pasteStart):end]), end + len(pasteEnd)
+		case final:
+			return pasteEvent(p[len(pasteStart):]), len(p)
+		}
+		return nil, 0
+	}
+	if p[0] != 0x1b {
+		return decodeKey(p, final)
+	}
+
+	if len(p) == 1 {
+		if final {
+			return KeyEvent{Key: KeyEscape}, 1
+		}
+		return nil, 0
+	}
+	switch p[1] {
+	case '[':
+		if ev, n := decodeCSI(p); n > 0 || !final {
+			return ev, n
+		}
+	case 'O':
+		if len(p) > 2 {
+			if key, ok := finalKeys[p[2]]; ok {
+				return KeyEvent{Key: key}, 3
+			}
+			return nil, 3
+		}
+		if !final {
+			return nil, 0
+		}
+	case 0x1b:
+		// A second Escape makes the first one Alt if it begins a sequence,
+		// and a key of its own otherwise
+		if len(p) == 2 && !final {
+			return nil, 0
+		}
+		if len(p) == 2 || p[2] != '[' && p[2] != 'O' {
+			return KeyEvent{Key: KeyEscape}, 1
+		}
+		ev, n := decodeEvent(p[1:], final)
+		if n == 0 {
+			return nil, 0
+		}
+		if k, ok := ev.(KeyEvent); ok {
+			k.Modifiers |= ModAlt
+			ev = k
+		}
+		return ev, n + 1
+	}
+
+	// Escape before a key, or before a sequence cut short, is Alt
+	ev, n := decodeKey(p[1:], final)
+	if n == 0 {
+		return nil, 0
+	}
+	if k, ok := ev.(KeyEvent); ok {
+		k.Modifiers |= ModAlt
+		ev = k
+	}
+	return ev, n + 1
+}
+
+// decodeKey decodes the character or control key at the start of p
+func decodeKey(p []byte, final bool) (Event, int) {
+	switch b := p[0]; {
+	case b == '\r' || b == '\n':
+		return KeyEvent{Key: KeyEnter}, 1
+	case b == '\t':
+		return KeyEvent{Key: KeyTab}, 1
+	case b == 0x7f || b == 0x08:
+		return KeyEvent{Key: KeyBackspace}, 1
+	case b == 0:
+		return KeyEvent{Key: KeyRune, Rune: ' ', Modifiers: ModCtrl}, 1
+	case b < 0x1b:
+		return KeyEvent{Key: KeyRune, Rune: rune('a' + b - 1), Modifiers: ModCtrl}, 1
+	case b < 0x20:
+		return KeyEvent{Key: KeyRune, Rune: rune(b + '@'), Modifiers: ModCtrl}, 1
+	}
+	if !final && !utf8.FullRune(p) {
+		return nil, 0
+	}
+	r, n := utf8.DecodeRune(p)
+	return KeyEvent{Key: KeyRune, Rune: r}, n
+}
+
+// decodeCSI decodes the CSI sequence at the start of p, which begins with
+// Escape and [
+func decodeCSI(p []byte) (Event, int) {
+	// Parameters and intermediates run up to a final byte
+	end := 2
+	for end < len(p) && p[end] >= 0x20 && p[end] <= 0x3f {
+		end++
+	}
+	if end == len(p) {
+		return nil, 0
+	}
+	final := p[end]
+	n := end + 1
+	if final < 0x40 || final > 0x7e {
+		// Not a valid sequence: drop the introducer
+		return nil, 2
+	}
+	params := string(p[2:end])
+
+	if strings.HasPrefix(params, "<") && (final == 'M' || final == 'm') {
+		return decodeSGRMouse(params[1:], final == 'm'), n
+	}
+	if params == "" && final == 'M' {
+		if len(p) < n+3 {
+			return nil, 0
+		}
+		return decodeX10Mouse(p[n : n+3]), n + 3
+	}
+
+	fields := strings.Split(params, ";")
+	mods := Modifier(0)
+	if len(fields) > 1 {
+		mods = csiModifiers(fields[1])
+	}
+	switch final {
+	case '~':
+		code, _ := strconv.Atoi(fields[0])
+		if key, ok := csiTildeKeys[code]; ok {
+			return KeyEvent{Key: key, Modifiers: mods}, n
+		}
+	case 'Z':
+		return KeyEvent{Key: KeyTab, Modifiers: ModShift}, n
+	default:
+		if key, ok := finalKeys[final]; ok {
+			return KeyEvent{Key: key, Modifiers: mods}, n
+		}
+	}
+	return nil, n
+}
+
+// csiModifiers converts an xterm modifier parameter, 1 plus a bit for each
+// of Shift, Alt and Ctrl, into modifiers
+func csiModifiers(s string) Modifier {
+	m, err := strconv.Atoi(s)
+	if err != nil || m < 1 {
+		return 0
+	}
+	// Meta, the next bit up, is reported as Alt
+	m--
+	return Modifier(m&7) | Modifier(m>>3&1)*ModAlt
+}
+
+// decodeSGRMouse decodes the parameters of an SGR mouse report, b;x;y
+func decodeSGRMouse(params string, release bool) Event {
+	fields := strings.Split(params, ";")
+	if len(fields) != 3 {
+		return nil
+	}
+	var v [3]int
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil
+		}
+		v[i] = n
+	}
+	return mouseEvent(v[0], v[1]-1, v[2]-1, release)
+}
+
+// decodeX10Mouse decodes the three bytes of a legacy mouse report, each
+// offset by 32
+func decodeX10Mouse(p []byte) Event {
+	b := int(p[0]) - 32
+	release := b&3 == 3 && b&(32|64) == 0
+	return mouseEvent(b, int(p[1])-33, int(p[2])-33, release)
+}
+
+// mouseEvent builds the event for mouse report code b at cell (x, y)
+func mouseEvent(b, x, y int, release bool) Event {
+	ev := MouseEvent{X: x, Y: y, Button: MouseButton(b & 3), Action: MousePress}
+	if b&4 != 0 {
+		ev.Modifiers |= ModShift
+	}
+	if b&8 != 0 {
+		ev.Modifiers |= ModAlt
+	}
+	if b&16 != 0 {
+		ev.Modifiers |= ModCtrl
+	}
+	switch {
+	case b&64 != 0:
+		// Wheel; horizontal scrolling is not reported
+		if b&3 > 1 {
+			return nil
+		}
+		ev.Button = MouseWheelUp + MouseButton(b&1)
+		ev.Action = MouseScroll
+	case b&32 != 0:
+		ev.Action = MouseMotion
+	case release:
+		ev.Action = MouseRelease
+	}
+	return ev
+}
+
+// pasteEvent returns the event for pasted text, with line breaks as \n
+func pasteEvent(p []byte) PasteEvent {
+	text := strings.ReplaceAll(string(p), "\r\n", "\n")
+	return PasteEvent{Text: strings.ReplaceAll(text, "\r", "\n")}
+}
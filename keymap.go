@@ -0,0 +1,287 @@
+package goterm
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// keyNames are the names of the keys other than characters, as used by
+// ParseKey and KeyEvent.String
+var keyNames = []struct {
+	key  Key
+	name string
+}{
+	{KeyEnter, "Enter"},
+	{KeyTab, "Tab"},
+	{KeyBackspace, "Backspace"},
+	{KeyEscape, "Esc"},
+	{KeyUp, "Up"},
+	{KeyDown, "Down"},
+	{KeyLeft, "Left"},
+	{KeyRight, "Right"},
+	{KeyHome, "Home"},
+	{KeyEnd, "End"},
+	{KeyPageUp, "PgUp"},
+	{KeyPageDown, "PgDn"},
+	{KeyInsert, "Insert"},
+	{KeyDelete, "Delete"},
+	{KeyF1, "F1"},
+	{KeyF2, "F2"},
+	{KeyF3, "F3"},
+	{KeyF4, "F4"},
+	{KeyF5, "F5"},
+	{KeyF6, "F6"},
+	{KeyF7, "F7"},
+	{KeyF8, "F8"},
+	{KeyF9, "F9"},
+	{KeyF10, "F10"},
+	{KeyF11, "F11"},
+	{KeyF12, "F12"},
+}
+
+// keyAliases are alternative key names accepted by ParseKey
+var keyAliases = map[string]KeyEvent{
+	"return":   {Key: KeyEnter},
+	"escape":   {Key: KeyEscape},
+	"pageup":   {Key: KeyPageUp},
+	"pagedown": {Key: KeyPageDown},
+	"ins":      {Key: KeyInsert},
+	"del":      {Key: KeyDelete},
+	"space":    {Key: KeyRune, Rune: ' '},
+}
+
+// String returns the key in the form accepted by ParseKey, e.g. "Ctrl+C",
+// "Shift+Tab", "PgDn" or "?"
+func (k KeyEvent) String() string {
+	var b strings.Builder
+	for _, mod := range []struct {
+		mod  Modifier
+		name string
+	}{{ModCtrl, "Ctrl+"}, {ModAlt, "Alt+"}, {ModShift, "Shift+"}} {
+		if k.Modifiers&mod.mod != 0 {
+			b.WriteString(mod.name)
+		}
+	}
+	switch {
+	case k.Key != KeyRune:
+		for _, n := range keyNames {
+			if n.key == k.Key {
+				b.WriteString(n.name)
+				return b.String()
+			}
+		}
+		fmt.Fprintf(&b, "Key(%d)", int(k.Key))
+	case k.Rune == ' ':
+		b.WriteString("Space")
+	case k.Modifiers&ModCtrl != 0:
+		// Control characters are typed without Shift
+		b.WriteString(strings.ToUpper(string(k.Rune)))
+	default:
+		b.WriteRune(k.Rune)
+	}
+	return b.String()
+}
+
+// Matches reports whether ev is a press of key k
+// Shift is ignored for characters, since it is part of the character typed:
+// "?" matches whether or not the terminal reports Shift with it.
+func (k KeyEvent) Matches(ev KeyEvent) bool {
+	if k.Key != ev.Key {
+		return false
+	}
+	if k.Key != KeyRune {
+		return k.Modifiers == ev.Modifiers
+	}
+	const mask = ModCtrl | ModAlt
+	return k.Rune == ev.Rune && k.Modifiers&mask == ev.Modifiers&mask
+}
+
+// ParseKey parses a key name such as "q", "G", "ctrl+c", "shift+tab",
+// "alt+enter", "pgdn" or "f1"
+// Modifiers are joined to the key with '+' or '-'; names are matched
+// case-insensitively except for single characters, and Ctrl with a letter
+// gives the lower-case letter as terminals report it. Returns an error
+// wrapping ErrInvalidKey for unknown names.
+func ParseKey(s string) (KeyEvent, error) {
+	var ev KeyEvent
+	name := s
+	for {
+		i := strings.IndexAny(name, "+-")
+		if i <= 0 || i == len(name)-1 {
+			break
+		}
+		switch strings.ToLower(name[:i]) {
+		case "ctrl", "control", "c":
+			ev.Modifiers |= ModCtrl
+		case "alt", "meta", "option", "m":
+			ev.Modifiers |= ModAlt
+		case "shift", "s":
+			ev.Modifiers |= ModShift
+		default:
+			return KeyEvent{}, fmt.Errorf("%w: unknown modifier in %q", ErrInvalidKey, s)
+		}
+		name = name[i+1:]
+	}
+
+	if r, size := utf8.DecodeRuneInString(name); size == len(name) && r != utf8.RuneError {
+		ev.Key, ev.Rune = KeyRune, r
+		if ev.Modifiers&ModCtrl != 0 {
+			ev.Rune = []rune(strings.ToLower(name))[0]
+		}
+		return ev, nil
+	}
+	lower := strings.ToLower(name)
+	if alias, ok := keyAliases[lower]; ok {
+		alias.Modifiers = ev.Modifiers
+		return alias, nil
+	}
+	for _, n := range keyNames {
+		if strings.ToLower(n.name) == lower {
+			ev.Key = n.key
+			return ev, nil
+		}
+	}
+	return KeyEvent{}, fmt.Errorf("%w: unknown key %q", ErrInvalidKey, s)
+}
+
+// KeyBinding ties keys to an action, with a description for help screens
+type KeyBinding struct {
+	Keys   []KeyEvent
+	Group  string // Category the binding is listed under in help
+	Help   string // What the keys do; empty hides the binding from help
+	Short  string // Brief help for hint strips such as a status line
+	Action func() // Run when one of the keys is pressed; may be nil
+}
+
+// Keymap is a list of key bindings that both dispatches key presses and
+// describes them, so an application's help always matches its behavior
+type Keymap struct {
+	Bindings []KeyBinding
+}
+
+// Bind adds a binding for the keys named in keys, which are parsed with
+// ParseKey
+// Returns an error wrapping ErrInvalidKey, without adding the binding, if
+// a key name is not recognized.
+func (m *Keymap) Bind(group, help string, action func(), keys ...string) error {
+	b := KeyBinding{Group: group, Help: help, Action: action}
+	for _, name := range keys {
+		k, err := ParseKey(name)
+		if err != nil {
+			return err
+		}
+		b.Keys = append(b.Keys, k)
+	}
+	m.Bindings = append(m.Bindings, b)
+	return nil
+}
+
+// Lookup returns the first binding with a key matching ev, or nil
+func (m *Keymap) Lookup(ev KeyEvent) *KeyBinding {
+	for i := range m.Bindings {
+		for _, k := range m.Bindings[i].Keys {
+			if k.Matches(ev) {
+				return &m.Bindings[i]
+			}
+		}
+	}
+	return nil
+}
+
+// HandleEvent runs the action bound to a key event and reports whether
+// there was one
+func (m *Keymap) HandleEvent(ev Event) bool {
+	kev, ok := ev.(KeyEvent)
+	if !ok {
+		return false
+	}
+	b := m.Lookup(kev)
+	if b == nil || b.Action == nil {
+		return false
+	}
+	b.Action()
+	return true
+}
+
+// Groups returns the groups of the bindings with help, in the order they
+// first appear
+func (m *Keymap) Groups() []string {
+	var groups []string
+	seen := map[string]bool{}
+	for _, b := range m.Bindings {
+		if b.Help != "" && !seen[b.Group] {
+			seen[b.Group] = true
+			groups = append(groups, b.Group)
+		}
+	}
+	return groups
+}
+
+// KeysString returns the keys of b as shown in help, e.g. "k, Up"
+func (b KeyBinding) KeysString() string {
+	names := make([]string, len(b.Keys))
+	for i, k := range b.Keys {
+		names[i] = k.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// Hints returns the bindings for a short hint strip, such as a status line
+// reading "q quit  ? help", with the text to show for each
+// These are the bindings with Short set, or, if there are none, every
+// binding with help, described by its Help.
+func (m *Keymap) Hints() (bindings []KeyBinding, text []string) {
+	for _, b := range m.Bindings {
+		if b.Short != "" && len(b.Keys) > 0 {
+			bindings, text = append(bindings, b), append(text, b.Short)
+		}
+	}
+	if len(bindings) > 0 {
+		return bindings, text
+	}
+	for _, b := range m.Bindings {
+		if b.Help != "" && len(b.Keys) > 0 {
+			bindings, text = append(bindings, b), append(text, b.Help)
+		}
+	}
+	return bindings, text
+}
+
+// HelpText returns the bindings with help as plain text, one per line
+// under each group name, with the help aligned after the keys:
+//
+//	Navigation
+//	  k, Up    Move up
+//	  j, Down  Move down
+//
+// It has the content of a help screen in a form for documentation, e.g. a
+// README or a --help flag, generated from the same bindings.
+func (m *Keymap) HelpText() string {
+	var b strings.Builder
+	for i, group := range m.Groups() {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		indent := ""
+		if group != "" {
+			b.WriteString(group + "\n")
+			indent = "  "
+		}
+		keyWidth := 0
+		for _, kb := range m.Bindings {
+			if kb.Group == group && kb.Help != "" {
+				keyWidth = max(keyWidth, StringWidth(kb.KeysString()))
+			}
+		}
+		for _, kb := range m.Bindings {
+			if kb.Group != group || kb.Help == "" {
+				continue
+			}
+			keys := kb.KeysString()
+			pad := strings.Repeat(" ", keyWidth-StringWidth(keys)+2)
+			b.WriteString(indent + keys + pad + kb.Help + "\n")
+		}
+	}
+	return b.String()
+}
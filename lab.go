@@ -0,0 +1,116 @@
+package goterm
+
+import "math"
+
+// DistanceTo returns the perceptual difference between two colors as the
+// CIEDE2000 color difference in CIELAB space
+// 0 means identical, about 1 is the smallest difference people notice and
+// black to white is 100. Palette colors are compared by their standard RGB
+// values. The terminal's default color has no known RGB value: it is at
+// distance 0 from itself and infinitely far from every other color.
+func (c Color) DistanceTo(other Color) float64 {
+	r1, g1, b1, ok1 := c.resolveRGB()
+	r2, g2, b2, ok2 := other.resolveRGB()
+	if !ok1 || !ok2 {
+		if ok1 == ok2 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return deltaE2000(rgbToLab(r1, g1, b1), rgbToLab(r2, g2, b2))
+}
+
+// lab is a color in CIELAB space
+type lab struct {
+	l, a, b float64
+}
+
+// paletteLab caches the CIELAB values of the 256-color palette for the
+// quantizers
+var paletteLab = func() (p [256]lab) {
+	for i := range p {
+		p[i] = rgbToLab(paletteRGB(uint8(i))) // #nosec G115
+	}
+	return p
+}()
+
+// rgbToLab converts an sRGB color to CIELAB with the D65 white point
+func rgbToLab(r, g, b uint8) lab {
+	lr, lg, lb := srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+	x := (0.4124564*lr + 0.3575761*lg + 0.1804375*lb) / 0.95047
+	y := 0.2126729*lr + 0.7151522*lg + 0.0721750*lb
+	z := (0.0193339*lr + 0.1191920*lg + 0.9503041*lb) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+// deltaE2000 returns the CIEDE2000 difference between two CIELAB colors
+func deltaE2000(c1, c2 lab) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+	rad := math.Pi / 180
+
+	cBar := (math.Hypot(c1.a, c1.b) + math.Hypot(c2.a, c2.b)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cBar, 7)/(math.Pow(cBar, 7)+pow25to7)))
+	a1, a2 := (1+g)*c1.a, (1+g)*c2.a
+	cp1, cp2 := math.Hypot(a1, c1.b), math.Hypot(a2, c2.b)
+	hp1, hp2 := hueAngle(c1.b, a1), hueAngle(c2.b, a2)
+
+	dL := c2.l - c1.l
+	dC := cp2 - cp1
+	var dh float64
+	if cp1*cp2 != 0 {
+		dh = hp2 - hp1
+		switch {
+		case dh > 180:
+			dh -= 360
+		case dh < -180:
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(cp1*cp2) * math.Sin(dh/2*rad)
+
+	lBar := (c1.l + c2.l) / 2
+	cpBar := (cp1 + cp2) / 2
+	hBar := hp1 + hp2
+	if cp1*cp2 != 0 {
+		switch {
+		case math.Abs(hp1-hp2) <= 180:
+			hBar /= 2
+		case hBar < 360:
+			hBar = (hBar + 360) / 2
+		default:
+			hBar = (hBar - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos((hBar-30)*rad) + 0.24*math.Cos(2*hBar*rad) +
+		0.32*math.Cos((3*hBar+6)*rad) - 0.20*math.Cos((4*hBar-63)*rad)
+	dTheta := 30 * math.Exp(-math.Pow((hBar-275)/25, 2))
+	rC := 2 * math.Sqrt(math.Pow(cpBar, 7)/(math.Pow(cpBar, 7)+pow25to7))
+	l50 := (lBar - 50) * (lBar - 50)
+	sL := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sC := 1 + 0.045*cpBar
+	sH := 1 + 0.015*cpBar*t
+	rT := -math.Sin(2*dTheta*rad) * rC
+
+	return math.Sqrt(math.Pow(dL/sL, 2) + math.Pow(dC/sC, 2) + math.Pow(dH/sH, 2) + rT*(dC/sC)*(dH/sH))
+}
+
+// hueAngle returns the hue angle of a CIELAB color in degrees, in [0, 360)
+func hueAngle(b, a float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
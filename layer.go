@@ -0,0 +1,133 @@
+package goterm
+
+import "slices"
+
+// Layer is a screen-sized drawing surface composited over the screen by Show
+// Layers let backgrounds, UI, popups and tooltips be drawn independently:
+// hiding or clearing a popup layer reveals what is underneath without
+// redrawing it. Cells of a layer start out transparent (see
+// CellTransparent), so only cells that have been drawn on cover the content
+// below.
+type Layer struct {
+	View
+
+	screen  *Screen
+	buf     Buffer
+	z       int
+	visible bool
+}
+
+// AddLayer creates a visible layer at the given z-index
+// The screen's own content is always at the bottom; layers are composited
+// above it in ascending z order, with layers of equal z in creation order.
+func (s *Screen) AddLayer(z int) *Layer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addLayer(z)
+}
+
+// addLayer creates a visible layer at the given z-index
+// Must be called with the screen lock held.
+func (s *Screen) addLayer(z int) *Layer {
+	l := &Layer{screen: s, z: z, visible: true}
+	l.buf = *NewBuffer(s.buf.width, s.buf.height)
+	l.buf.blank = CellTransparent()
+	l.buf.Clear()
+	l.View = View{buf: &l.buf, mu: &s.mu}
+	l.fitView()
+
+	s.layers = append(s.layers, l)
+	s.sortLayers()
+	return l
+}
+
+// RemoveLayer detaches a layer from the screen
+func (s *Screen) RemoveLayer(l *Layer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layers = slices.DeleteFunc(s.layers, func(other *Layer) bool { return other == l })
+}
+
+// Z returns the layer's z-index
+func (l *Layer) Z() int {
+	l.screen.mu.RLock()
+	defer l.screen.mu.RUnlock()
+	return l.z
+}
+
+// SetZ moves the layer to a new z-index
+func (l *Layer) SetZ(z int) {
+	l.screen.mu.Lock()
+	defer l.screen.mu.Unlock()
+	l.z = z
+	l.screen.sortLayers()
+}
+
+// Visible reports whether the layer is composited by Show
+func (l *Layer) Visible() bool {
+	l.screen.mu.RLock()
+	defer l.screen.mu.RUnlock()
+	return l.visible
+}
+
+// SetVisible shows or hides the layer without discarding its content
+func (l *Layer) SetVisible(visible bool) {
+	l.screen.mu.Lock()
+	defer l.screen.mu.Unlock()
+	l.visible = visible
+}
+
+// Clear makes every cell of the layer transparent again
+func (l *Layer) Clear() {
+	l.screen.mu.Lock()
+	defer l.screen.mu.Unlock()
+	l.buf.Clear()
+}
+
+// fitView resizes the layer's view to cover its whole buffer
+func (l *Layer) fitView() {
+	l.origin = l.buf.Bounds()
+	l.clip = l.origin
+	l.clips = nil
+}
+
+// sortLayers orders layers by z-index, keeping creation order for ties
+// Must be called with the screen lock held.
+func (s *Screen) sortLayers() {
+	slices.SortStableFunc(s.layers, func(a, b *Layer) int { return a.z - b.z })
+}
+
+// Composite returns a copy of the screen with all visible layers drawn on
+// top, exactly as the next Show would render it
+func (s *Screen) Composite() *Buffer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b := NewBuffer(s.buf.width, s.buf.height)
+	copy(b.cells, s.composite())
+	return b
+}
+
+// composite returns the screen cells with all visible layers drawn on top
+// Must be called with the screen lock held.
+func (s *Screen) composite() []Cell {
+	cellImages := s.graphics == GraphicsCells && slices.ContainsFunc(s.images, func(im *Image) bool { return im.visible })
+	if !cellImages && !slices.ContainsFunc(s.layers, func(l *Layer) bool { return l.visible }) {
+		return s.buf.cells
+	}
+
+	out := Buffer{width: s.buf.width, height: s.buf.height, cells: slices.Clone(s.buf.cells)}
+	out.clip = out.Bounds()
+	if cellImages {
+		s.drawImages(&out)
+	}
+	for _, l := range s.layers {
+		if !l.visible {
+			continue
+		}
+		for y := 0; y < out.height; y++ {
+			out.overlay(0, y, l.buf.cells[y*out.width:(y+1)*out.width])
+		}
+	}
+	return out.cells
+}
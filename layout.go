@@ -0,0 +1,333 @@
+package goterm
+
+import (
+	"slices"
+	"sync"
+)
+
+// constraintKind is how a Constraint sizes its part
+type constraintKind int
+
+const (
+	constraintFixed constraintKind = iota
+	constraintPercent
+	constraintFill
+	constraintFit
+)
+
+// Constraint sizes one part of a rectangle split by SplitH or SplitV
+type Constraint struct {
+	kind    constraintKind
+	value   int
+	measure Measurable // For Fit
+}
+
+// Measurable is implemented by widgets with a natural size, so layouts can
+// size them to their content
+// Charts and scatter plots scale to the space they are given and prefer all
+// of it across their axes. Markdown has no widget: RenderMarkdown returns a
+// Buffer already sized to its content, and Buffer.Size gives that size.
+type Measurable interface {
+	// PreferredSize returns the size that fits the content, no larger than
+	// maxW×maxH
+	PreferredSize(maxW, maxH int) (w, h int)
+}
+
+// Fixed returns a constraint for a part of exactly n cells
+func Fixed(n int) Constraint {
+	return Constraint{kind: constraintFixed, value: max(0, n)}
+}
+
+// Percent returns a constraint for a part of p percent of the length split
+func Percent(p int) Constraint {
+	return Constraint{kind: constraintPercent, value: max(0, p)}
+}
+
+// Fill returns a constraint for a part sharing the space left by the Fixed
+// and Percent parts with the other Fill parts, in proportion to weight
+func Fill(weight int) Constraint {
+	return Constraint{kind: constraintFill, value: max(0, weight)}
+}
+
+// Fit returns a constraint for a part the size m prefers, measured with
+// the whole length split and the full size across it as the limits
+func Fit(m Measurable) Constraint {
+	return Constraint{kind: constraintFit, measure: m}
+}
+
+// SplitH splits r into columns side by side, from left to right, sized by
+// constraints
+// See SplitV for how sizes are computed.
+func SplitH(r Rect, constraints ...Constraint) []Rect {
+	sizes := splitSizes(max(0, r.W), constraints, func(m Measurable) int {
+		w, _ := m.PreferredSize(max(0, r.W), max(0, r.H))
+		return w
+	})
+	rects := make([]Rect, len(sizes))
+	x := r.X
+	for i, w := range sizes {
+		rects[i] = Rect{X: x, Y: r.Y, W: w, H: r.H}
+		x += w
+	}
+	return rects
+}
+
+// SplitV splits r into rows one above the other, from top to bottom, sized
+// by constraints
+// The parts are always adjacent: each starts where the previous one ends.
+// Fixed, Percent and Fit parts are sized first, and the Fill parts share
+// what is left. Percentages and Fill shares are rounded down cumulatively,
+// so percentages adding up to 100, or any set of Fill parts, cover their
+// space exactly without gaps. If the other parts need more space than there
+// is, they are cut short in order and later parts get none; if there is no
+// Fill part, space left over stays empty after the last part.
+func SplitV(r Rect, constraints ...Constraint) []Rect {
+	sizes := splitSizes(max(0, r.H), constraints, func(m Measurable) int {
+		_, h := m.PreferredSize(max(0, r.W), max(0, r.H))
+		return h
+	})
+	rects := make([]Rect, len(sizes))
+	y := r.Y
+	for i, h := range sizes {
+		rects[i] = Rect{X: r.X, Y: y, W: r.W, H: h}
+		y += h
+	}
+	return rects
+}
+
+// splitSizes returns the length of each part when length is split by
+// constraints, using measure for the length of Fit parts
+func splitSizes(length int, constraints []Constraint, measure func(Measurable) int) []int {
+	sizes := make([]int, len(constraints))
+	percent, weights := 0, 0
+	for i, c := range constraints {
+		switch c.kind {
+		case constraintFixed:
+			sizes[i] = c.value
+		case constraintPercent:
+			sizes[i] = (percent+c.value)*length/100 - percent*length/100
+			percent += c.value
+		case constraintFill:
+			weights += c.value
+		case constraintFit:
+			if c.measure != nil {
+				sizes[i] = max(0, measure(c.measure))
+			}
+		}
+	}
+
+	left := length
+	for i, c := range constraints {
+		if c.kind != constraintFill {
+			sizes[i] = min(sizes[i], left)
+			left -= sizes[i]
+		}
+	}
+
+	if weights > 0 {
+		share := 0
+		for i, c := range constraints {
+			if c.kind == constraintFill {
+				sizes[i] = (share+c.value)*left/weights - share*left/weights
+				share += c.value
+			}
+		}
+	}
+	return sizes
+}
+
+// Drawable is anything that draws itself onto a surface, such as a widget
+type Drawable interface {
+	Draw(s Surface)
+}
+
+// Placement is a Drawable and the region of the screen it is drawn in
+type Placement struct {
+	Rect   Rect
+	Widget Drawable
+}
+
+// LayoutFunc computes where the parts of an interface go on a screen whose
+// whole area is area
+type LayoutFunc func(area Rect) []Placement
+
+// Layout is a LayoutFunc registered with a screen, with the placements it
+// computed for the screen's current size
+// Registered layouts are recomputed and their widgets redrawn whenever the
+// screen is resized through HandleResize, so an interface described by a
+// LayoutFunc adapts to the terminal size by itself:
+//
+//	screen.AddLayout(func(area goterm.Rect) []goterm.Placement {
+//		rows := goterm.SplitV(area, goterm.Fill(1), goterm.Fixed(1))
+//		return []goterm.Placement{{Rect: rows[0], Widget: list}, {Rect: rows[1], Widget: status}}
+//	})
+type Layout struct {
+	screen     *Screen
+	fn         LayoutFunc
+	mu         sync.Mutex
+	placements []Placement
+}
+
+// AddLayout registers fn with the screen and computes its placements for
+// the current screen size
+// The widgets are not drawn until Draw or Relayout is called.
+func (s *Screen) AddLayout(fn LayoutFunc) *Layout {
+	l := &Layout{screen: s, fn: fn}
+	l.Update()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layouts = append(s.layouts, l)
+	return l
+}
+
+// RemoveLayout unregisters a layout from the screen
+func (s *Screen) RemoveLayout(l *Layout) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layouts = slices.DeleteFunc(s.layouts, func(other *Layout) bool { return other == l })
+}
+
+// Layouts returns the layouts registered with the screen, in the order
+// they were added
+func (s *Screen) Layouts() []*Layout {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.layouts)
+}
+
+// Relayout recomputes every registered layout for the current screen size,
+// clears the screen and redraws the layouts' widgets in the order the
+// layouts were added
+func (s *Screen) Relayout() {
+	layouts := s.Layouts()
+	for _, l := range layouts {
+		l.Update()
+	}
+	s.Clear()
+	for _, l := range layouts {
+		l.Draw()
+	}
+	s.drawLayoutDebug()
+}
+
+// HandleResize resizes the screen to the size in ev and lays it out again
+// with Relayout
+func (s *Screen) HandleResize(ev ResizeEvent) {
+	if ev.Width <= 0 || ev.Height <= 0 {
+		return
+	}
+	s.Resize(ev.Width, ev.Height)
+	s.Relayout()
+}
+
+// Update recomputes the placements for the screen's current size without
+// drawing
+func (l *Layout) Update() {
+	w, h := l.screen.Size()
+	placements := l.fn(Rect{W: w, H: h})
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.placements = placements
+}
+
+// Placements returns where the layout last placed its widgets
+func (l *Layout) Placements() []Placement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.placements)
+}
+
+// Rect returns the region widget was last placed in, and false if the
+// layout did not place it
+// widget is compared with the values the LayoutFunc returned, so it is
+// typically a pointer.
+func (l *Layout) Rect(widget Drawable) (Rect, bool) {
+	for _, p := range l.Placements() {
+		if p.Widget == widget {
+			return p.Rect, true
+		}
+	}
+	return Rect{}, false
+}
+
+// Draw draws each widget onto a view of the region it was placed in
+func (l *Layout) Draw() {
+	for _, p := range l.Placements() {
+		if p.Widget != nil && !p.Rect.Empty() {
+			p.Widget.Draw(l.screen.SubView(p.Rect.X, p.Rect.Y, p.Rect.W, p.Rect.H))
+		}
+	}
+}
+
+// DockEdge is the side of the remaining space a DockPanel is attached to
+type DockEdge int
+
+// Dock edge constants
+const (
+	DockTop DockEdge = iota
+	DockBottom
+	DockLeft
+	DockRight
+)
+
+// DockPanel is a panel of a DockLayout
+type DockPanel struct {
+	Edge   DockEdge
+	Size   int // Rows for top and bottom panels, columns for left and right; 0 hides the panel
+	Widget Drawable
+}
+
+// DockLayout attaches panels to the edges of an area and gives the rest to
+// the center, the classic arrangement of menu bar, sidebars, status bar and
+// main view
+// Panels are docked in order, each taking its size from the space left by
+// those before it, so the order decides which panels span the corners: a
+// top panel listed before a left one runs the full width above it. Panels
+// are cut short when the space runs out.
+//
+//	dock := &goterm.DockLayout{
+//		Panels: []goterm.DockPanel{
+//			{Edge: goterm.DockTop, Size: 1, Widget: menu},
+//			{Edge: goterm.DockBottom, Size: 1, Widget: status},
+//			{Edge: goterm.DockLeft, Size: 24, Widget: files},
+//		},
+//		Center: editor,
+//	}
+//	screen.AddLayout(dock.Layout)
+type DockLayout struct {
+	Panels []DockPanel
+	Center Drawable // Fills the space left by the panels; may be nil
+}
+
+// Layout places the panels and the center in area
+// It is a LayoutFunc, for use with Screen.AddLayout.
+func (d *DockLayout) Layout(area Rect) []Placement {
+	placements := make([]Placement, 0, len(d.Panels)+1)
+	rest := Rect{X: area.X, Y: area.Y, W: max(0, area.W), H: max(0, area.H)}
+	for _, p := range d.Panels {
+		var r Rect
+		switch p.Edge {
+		case DockTop:
+			r = Rect{X: rest.X, Y: rest.Y, W: rest.W, H: max(0, min(p.Size, rest.H))}
+			rest.Y += r.H
+			rest.H -= r.H
+		case DockBottom:
+			r = Rect{X: rest.X, W: rest.W, H: max(0, min(p.Size, rest.H))}
+			rest.H -= r.H
+			r.Y = rest.Y + rest.H
+		case DockLeft:
+			r = Rect{X: rest.X, Y: rest.Y, W: max(0, min(p.Size, rest.W)), H: rest.H}
+			rest.X += r.W
+			rest.W -= r.W
+		case DockRight:
+			r = Rect{Y: rest.Y, W: max(0, min(p.Size, rest.W)), H: rest.H}
+			rest.W -= r.W
+			r.X = rest.X + rest.W
+		}
+		placements = append(placements, Placement{Rect: r, Widget: p.Widget})
+	}
+	if d.Center != nil {
+		placements = append(placements, Placement{Rect: rest, Widget: d.Center})
+	}
+	return placements
+}
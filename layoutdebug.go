@@ -0,0 +1,78 @@
+package goterm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// LayoutDebugZ is the z-index of the layout debugging overlay, above every
+// other layer
+const LayoutDebugZ = math.MaxInt32
+
+// layoutDebugColors are the outline colors of successive placements
+var layoutDebugColors = []Color{ColorRed, ColorGreen, ColorYellow, ColorBlue, ColorMagenta, ColorCyan}
+
+// SetLayoutDebug shows or hides the layout debugging overlay
+// The overlay outlines the region of every widget placed by the registered
+// layouts on a layer above everything else, each in its own color and
+// labelled with its index, type and size, which makes gaps, overlaps and
+// off-by-one errors easy to spot. It is redrawn by every Relayout, so it
+// follows the layout as the screen is resized; turning it on again redraws
+// it after a layout has changed.
+func (s *Screen) SetLayoutDebug(enabled bool) {
+	s.mu.Lock()
+	switch {
+	case enabled && s.debug == nil:
+		s.debug = s.addLayer(LayoutDebugZ)
+	case !enabled && s.debug != nil:
+		s.layers = slices.DeleteFunc(s.layers, func(l *Layer) bool { return l == s.debug })
+		s.debug = nil
+	}
+	s.mu.Unlock()
+	s.drawLayoutDebug()
+}
+
+// LayoutDebug reports whether the layout debugging overlay is shown
+func (s *Screen) LayoutDebug() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.debug != nil
+}
+
+// ToggleLayoutDebug shows the layout debugging overlay if it is hidden and
+// hides it otherwise, e.g. from a key binding
+func (s *Screen) ToggleLayoutDebug() {
+	s.SetLayoutDebug(!s.LayoutDebug())
+}
+
+// drawLayoutDebug redraws the layout debugging overlay, if it is shown
+func (s *Screen) drawLayoutDebug() {
+	s.mu.RLock()
+	layer := s.debug
+	s.mu.RUnlock()
+	if layer == nil {
+		return
+	}
+
+	layer.Clear()
+	// Outlines keep the background of the cells they are drawn over; labels
+	// are black on the outline color so they can be read over any content
+	bg := ColorRGBA(0, 0, 0, 0)
+	i := 0
+	for _, l := range s.Layouts() {
+		for _, p := range l.Placements() {
+			r := p.Rect
+			fg := layoutDebugColors[i%len(layoutDebugColors)]
+			label := fmt.Sprintf("%d %s %dx%d", i, strings.TrimPrefix(fmt.Sprintf("%T", p.Widget), "*"), r.W, r.H)
+			if r.W >= 2 && r.H >= 2 {
+				layer.DrawBox(r.X, r.Y, r.W, r.H, BorderSingle, fg, bg)
+				layer.DrawText(r.X+1, r.Y, Truncate(label, r.W-2, "…"), ColorBlack, fg, StyleNone)
+			} else if !r.Empty() {
+				layer.DrawText(r.X, r.Y, Truncate(label, r.W, "…"), ColorBlack, fg, StyleNone)
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package goterm
+
+// lineWeight is the thickness of one arm of a box-drawing character
+type lineWeight uint8
+
+const (
+	weightNone lineWeight = iota
+	weightLight
+	weightHeavy
+	weightDouble
+)
+
+// junction describes which arms of a box-drawing character are present
+type junction struct {
+	up, down, left, right lineWeight
+}
+
+// junctionRunes maps box-drawing characters to the arms they connect
+var junctionRunes = map[rune]junction{
+	// Light
+	'─': {0, 0, 1, 1}, '│': {1, 1, 0, 0},
+	'┌': {0, 1, 0, 1}, '┐': {0, 1, 1, 0}, '└': {1, 0, 0, 1}, '┘': {1, 0, 1, 0},
+	'├': {1, 1, 0, 1}, '┤': {1, 1, 1, 0}, '┬': {0, 1, 1, 1}, '┴': {1, 0, 1, 1},
+	'┼': {1, 1, 1, 1},
+	// Heavy
+	'━': {0, 0, 2, 2}, '┃': {2, 2, 0, 0},
+	'┏': {0, 2, 0, 2}, '┓': {0, 2, 2, 0}, '┗': {2, 0, 0, 2}, '┛': {2, 0, 2, 0},
+	'┣': {2, 2, 0, 2}, '┫': {2, 2, 2, 0}, '┳': {0, 2, 2, 2}, '┻': {2, 0, 2, 2},
+	'╋': {2, 2, 2, 2},
+	// Double
+	'═': {0, 0, 3, 3}, '║': {3, 3, 0, 0},
+	'╔': {0, 3, 0, 3}, '╗': {0, 3, 3, 0}, '╚': {3, 0, 0, 3}, '╝': {3, 0, 3, 0},
+	'╠': {3, 3, 0, 3}, '╣': {3, 3, 3, 0}, '╦': {0, 3, 3, 3}, '╩': {3, 0, 3, 3},
+	'╬': {3, 3, 3, 3},
+	// Mixed light and double
+	'╒': {0, 1, 0, 3}, '╓': {0, 3, 0, 1}, '╕': {0, 1, 3, 0}, '╖': {0, 3, 1, 0},
+	'╘': {1, 0, 0, 3}, '╙': {3, 0, 0, 1}, '╛': {1, 0, 3, 0}, '╜': {3, 0, 1, 0},
+	'╞': {1, 1, 0, 3}, '╟': {3, 3, 0, 1}, '╡': {1, 1, 3, 0}, '╢': {3, 3, 1, 0},
+	'╤': {0, 1, 3, 3}, '╥': {0, 3, 1, 1}, '╧': {1, 0, 3, 3}, '╨': {3, 0, 1, 1},
+	'╪': {1, 1, 3, 3}, '╫': {3, 3, 1, 1},
+	// Mixed light and heavy
+	'┝': {1, 1, 0, 2}, '┠': {2, 2, 0, 1}, '┥': {1, 1, 2, 0}, '┨': {2, 2, 1, 0},
+	'┯': {0, 1, 2, 2}, '┰': {0, 2, 1, 1}, '┷': {1, 0, 2, 2}, '┸': {2, 0, 1, 1},
+	'┿': {1, 1, 2, 2}, '╂': {2, 2, 1, 1},
+}
+
+// roundedCorners are read as light corners when merging
+var roundedCorners = map[rune]junction{
+	'╭': {0, 1, 0, 1}, '╮': {0, 1, 1, 0}, '╰': {1, 0, 0, 1}, '╯': {1, 0, 1, 0},
+}
+
+// runeForJunction is the reverse of junctionRunes
+var runeForJunction = func() map[junction]rune {
+	m := make(map[junction]rune, len(junctionRunes))
+	for r, j := range junctionRunes {
+		m[j] = r
+	}
+	return m
+}()
+
+// lookupJunction returns the arms of a box-drawing character
+func lookupJunction(r rune) (junction, bool) {
+	if j, ok := junctionRunes[r]; ok {
+		return j, true
+	}
+	j, ok := roundedCorners[r]
+	return j, ok
+}
+
+// borderWeights returns the weights of the horizontal and vertical lines
+// drawn by a border style
+func borderWeights(border BorderStyle) (horizontal, vertical lineWeight) {
+	switch border {
+	case BorderThick:
+		return weightHeavy, weightHeavy
+	case BorderDouble:
+		return weightDouble, weightDouble
+	case BorderDoubleHorizontal:
+		return weightDouble, weightLight
+	case BorderDoubleVertical:
+		return weightLight, weightDouble
+	}
+	return weightLight, weightLight
+}
+
+// mergeJunction combines an existing box-drawing character with new arms
+// If no single character draws the combination, the existing arms are
+// redrawn at the new weight; failing that, plain is returned.
+func mergeJunction(existing rune, add junction, weight lineWeight, plain rune) rune {
+	j, ok := lookupJunction(existing)
+	if !ok {
+		return plain
+	}
+
+	merged := j
+	if add.up != weightNone {
+		merged.up = add.up
+	}
+	if add.down != weightNone {
+		merged.down = add.down
+	}
+	if add.left != weightNone {
+		merged.left = add.left
+	}
+	if add.right != weightNone {
+		merged.right = add.right
+	}
+	if r, ok := runeForJunction[merged]; ok {
+		return r
+	}
+
+	uniform := func(w lineWeight) lineWeight {
+		if w == weightNone {
+			return weightNone
+		}
+		return weight
+	}
+	merged = junction{uniform(merged.up), uniform(merged.down), uniform(merged.left), uniform(merged.right)}
+	if r, ok := runeForJunction[merged]; ok {
+		return r
+	}
+	return plain
+}
+
+// mergeASCII combines ASCII border characters, turning crossings into '+'
+func mergeASCII(existing, plain rune) rune {
+	switch existing {
+	case '+', '|', '-':
+		if existing != plain {
+			return '+'
+		}
+	}
+	return plain
+}
+
+// DrawHLine draws a horizontal line of the given length starting at (x, y)
+// Where the line meets existing box-drawing characters, the matching
+// junction (├ ┼ ┤ ┬ ┴ and their heavy/double forms) is substituted so
+// dividers and grids connect cleanly to surrounding boxes.
+func (s *Screen) DrawHLine(x, y, length int, border BorderStyle, fg, bg Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawHLine(x, y, length, border, fg, bg)
+}
+
+// DrawVLine draws a vertical line of the given length starting at (x, y)
+// Junctions with existing box-drawing characters are merged as in DrawHLine.
+func (s *Screen) DrawVLine(x, y, length int, border BorderStyle, fg, bg Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawVLine(x, y, length, border, fg, bg)
+}
+
+// DrawHLine draws a horizontal line of the given length starting at (x, y)
+// Where the line meets existing box-drawing characters, the matching
+// junction (├ ┼ ┤ ┬ ┴ and their heavy/double forms) is substituted so
+// dividers and grids connect cleanly to surrounding boxes.
+func (b *Buffer) DrawHLine(x, y, length int, border BorderStyle, fg, bg Color) {
+	plain := border.Chars().Horizontal
+	weight, _ := borderWeights(border)
+
+	for i := 0; i < length; i++ {
+		add := junction{left: weight, right: weight}
+		if i == 0 {
+			add.left = weightNone
+		}
+		if i == length-1 {
+			add.right = weightNone
+		}
+		b.drawLineCell(x+i, y, plain, add, weight, border == BorderASCII, fg, bg)
+	}
+}
+
+// DrawVLine draws a vertical line of the given length starting at (x, y)
+// Junctions with existing box-drawing characters are merged as in DrawHLine.
+func (b *Buffer) DrawVLine(x, y, length int, border BorderStyle, fg, bg Color) {
+	plain := border.Chars().Vertical
+	_, weight := borderWeights(border)
+
+	for i := 0; i < length; i++ {
+		add := junction{up: weight, down: weight}
+		if i == 0 {
+			add.up = weightNone
+		}
+		if i == length-1 {
+			add.down = weightNone
+		}
+		b.drawLineCell(x, y+i, plain, add, weight, border == BorderASCII, fg, bg)
+	}
+}
+
+// drawLineCell draws one cell of a line, merging it with what is underneath
+func (b *Buffer) drawLineCell(x, y int, plain rune, add junction, weight lineWeight, ascii bool, fg, bg Color) {
+	existing := b.GetCell(x, y).Ch
+
+	ch := plain
+	if ascii {
+		ch = mergeASCII(existing, plain)
+	} else if add == (junction{}) {
+		// A line of length one has no arms of its own
+		if _, ok := lookupJunction(existing); ok {
+			ch = existing
+		}
+	} else {
+		ch = mergeJunction(existing, add, weight, plain)
+	}
+
+	b.SetCell(x, y, NewCell(ch, fg, bg, StyleNone))
+}
@@ -0,0 +1,64 @@
+package goterm
+
+import "strings"
+
+// SetLink makes every cell in rect a hyperlink to url, keeping the cells'
+// content
+// Show marks linked cells with OSC 8 escape sequences, which terminals that
+// support them turn into clickable links; other terminals ignore them and
+// show the text alone. Like tags, links travel with cells through Blit,
+// sprites and layers, and drawing over a cell removes its link. An empty
+// url removes links. The region is clipped to the screen bounds.
+func (s *Screen) SetLink(rect Rect, url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.SetLink(rect, url)
+}
+
+// LinkAt returns the URL of the cell shown at (x, y), taking visible layers
+// into account, or "" if the cell is not a link or out of bounds
+func (s *Screen) LinkAt(x, y int) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if x < 0 || y < 0 || x >= s.buf.width || y >= s.buf.height {
+		return ""
+	}
+	return s.composite()[y*s.buf.width+x].Link
+}
+
+// SetLink makes every cell in rect a hyperlink to url
+// See Screen.SetLink.
+func (b *Buffer) SetLink(rect Rect, url string) {
+	b.ForEach(rect, func(_, _ int, c Cell) Cell {
+		c.Link = url
+		return c
+	})
+}
+
+// LinkAt returns the URL of the cell at (x, y), or "" if out of bounds
+func (b *Buffer) LinkAt(x, y int) string {
+	return b.GetCell(x, y).Link
+}
+
+// SetLink makes every cell in rect, given in view coordinates, a hyperlink
+// to url
+// See Screen.SetLink.
+func (v *View) SetLink(rect Rect, url string) {
+	rect.X += v.origin.X
+	rect.Y += v.origin.Y
+	v.draw(func(b *Buffer) { b.SetLink(rect, url) })
+}
+
+// linkSequence returns the OSC 8 sequence that starts a hyperlink to url,
+// or ends the current one if url is empty
+// Control characters are dropped so that a URL cannot end the sequence
+// early and inject escape sequences of its own.
+func linkSequence(url string) string {
+	url = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, url)
+	return "\x1b]8;;" + url + "\x1b\\"
+}
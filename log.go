@@ -0,0 +1,95 @@
+package goterm
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// logTimeFormat is the time stamp at the start of each log line
+const logTimeFormat = "15:04:05.000"
+
+// Logger writes a line for every input read, message handled and frame
+// drawn by an App to a writer, typically a file, since the terminal is
+// taken by the interface
+// Raw input is logged quoted, byte for byte, before it is decoded, followed
+// by the events decoded from it, which makes it easy to see what an unusual
+// terminal sends and how it was understood:
+//
+//	f, _ := os.Create("app.log")
+//	goterm.NewLogger(f).Attach(app)
+//
+// A Logger is safe for concurrent use. Errors writing the log are ignored
+// except for the first, which Err reports.
+type Logger struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewLogger creates a logger writing to w
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// Attach makes the logger log the input, messages and frames of app
+// The logger runs before any middleware already set, so it sees every
+// message, and calls any OnInput and OnFrame hooks already set.
+func (l *Logger) Attach(app *App) {
+	app.Middleware = append([]Middleware{l.Middleware}, app.Middleware...)
+
+	onInput := app.OnInput
+	app.OnInput = func(raw []byte) {
+		l.Input(raw)
+		if onInput != nil {
+			onInput(raw)
+		}
+	}
+	onFrame := app.OnFrame
+	app.OnFrame = func(stats FrameStats) {
+		l.Frame(stats)
+		if onFrame != nil {
+			onFrame(stats)
+		}
+	}
+}
+
+// Input logs input as read from the terminal
+func (l *Logger) Input(raw []byte) {
+	l.printf("input %q", raw)
+}
+
+// Middleware logs msg, and how long handling it took, once next returns
+func (l *Logger) Middleware(msg Msg, next func(Msg)) {
+	start := time.Now()
+	next(msg)
+	kind := "message"
+	if _, ok := msg.(Event); ok {
+		kind = "event"
+	}
+	l.printf("%s %T %+v (%v)", kind, msg, msg, time.Since(start))
+}
+
+// Frame logs the statistics of a frame
+func (l *Logger) Frame(stats FrameStats) {
+	l.printf("frame %d interval=%v update=%v draw=%v show=%v changed=%d bytes=%d queued=%d",
+		stats.Frame, stats.Interval, stats.Update, stats.Draw, stats.Show, stats.Changed, stats.Bytes, stats.Queued)
+}
+
+// Err returns the first error writing the log, if any
+func (l *Logger) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// printf writes a time-stamped line to the log
+func (l *Logger) printf(format string, args ...any) {
+	line := time.Now().Format(logTimeFormat) + " " + fmt.Sprintf(format, args...) + "\n"
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := io.WriteString(l.w, line); err != nil && l.err == nil {
+		l.err = err
+	}
+}
@@ -0,0 +1,187 @@
+package goterm
+
+import (
+	"slices"
+	"sync"
+)
+
+// hitTarget is something registered with a MouseRouter: a handler for a
+// region or for a tag, or a layout whose widgets handle events
+type hitTarget struct {
+	rect    Rect
+	tag     int
+	layout  *Layout
+	handler EventHandler
+}
+
+// MouseRouter delivers each mouse event to the handler of what is under the
+// pointer, in that handler's own coordinates
+// Handlers are registered for a region of the screen with Add, for the
+// cells carrying a tag with AddTag, or for every widget a layout places
+// that handles events with AddLayout, which follows the layout as it is
+// recomputed. Where targets overlap, the one added last is on top.
+//
+// The event is moved so that (0, 0) is the top left corner of the target's
+// region, or of the smallest rectangle around the tagged cells, which is
+// what widgets expect. A button press captures the mouse: until the button
+// is released, drags and the release go to the same handler, even when the
+// pointer leaves its region.
+//
+//	router := goterm.NewMouseRouter(screen)
+//	router.AddLayout(layout)
+//	router.Add(goterm.Rect{X: 0, Y: 0, W: 10, H: 1}, menuButton)
+//	app.Handlers = append(app.Handlers, router)
+type MouseRouter struct {
+	screen  *Screen
+	mu      sync.Mutex
+	targets []hitTarget // Bottom first
+	capture *hitTarget  // Target of the button held down
+}
+
+// NewMouseRouter creates a router for mouse events on screen
+func NewMouseRouter(screen *Screen) *MouseRouter {
+	return &MouseRouter{screen: screen}
+}
+
+// Add routes mouse events in rect to handler
+func (r *MouseRouter) Add(rect Rect, handler EventHandler) {
+	r.add(hitTarget{rect: rect, handler: handler})
+}
+
+// AddTag routes mouse events on cells tagged tag to handler
+func (r *MouseRouter) AddTag(tag int, handler EventHandler) {
+	r.add(hitTarget{tag: tag, handler: handler})
+}
+
+// AddLayout routes mouse events to the widgets placed by l that implement
+// EventHandler, in the regions they were last placed in
+func (r *MouseRouter) AddLayout(l *Layout) {
+	r.add(hitTarget{layout: l})
+}
+
+// add puts target on top of the others
+func (r *MouseRouter) add(target hitTarget) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets = append(r.targets, target)
+}
+
+// Remove unregisters every target of handler
+func (r *MouseRouter) Remove(handler EventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets = slices.DeleteFunc(r.targets, func(t hitTarget) bool { return t.handler == handler })
+	if r.capture != nil && r.capture.handler == handler {
+		r.capture = nil
+	}
+}
+
+// RemoveLayout stops routing mouse events to the widgets of l
+func (r *MouseRouter) RemoveLayout(l *Layout) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets = slices.DeleteFunc(r.targets, func(t hitTarget) bool { return t.layout == l })
+}
+
+// Clear unregisters every target and releases the capture
+func (r *MouseRouter) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets = nil
+	r.capture = nil
+}
+
+// HitTest returns the handler on top at (x, y) and its region, and false
+// if nothing is there
+func (r *MouseRouter) HitTest(x, y int) (EventHandler, Rect, bool) {
+	t, ok := r.hit(x, y)
+	return t.handler, t.rect, ok
+}
+
+// hit finds the target on top at (x, y), with the region of its handler
+func (r *MouseRouter) hit(x, y int) (hitTarget, bool) {
+	r.mu.Lock()
+	targets := slices.Clone(r.targets)
+	r.mu.Unlock()
+
+	tag := -1
+	for i := len(targets) - 1; i >= 0; i-- {
+		t := targets[i]
+		switch {
+		case t.layout != nil:
+			placements := t.layout.Placements()
+			for j := len(placements) - 1; j >= 0; j-- {
+				p := placements[j]
+				if h, ok := p.Widget.(EventHandler); ok && p.Rect.Contains(x, y) {
+					return hitTarget{rect: p.Rect, handler: h}, true
+				}
+			}
+		case t.tag != 0:
+			if tag < 0 {
+				tag = r.screen.TagAt(x, y)
+			}
+			if tag == t.tag {
+				t.rect = r.screen.tagBounds(tag)
+				return t, true
+			}
+		case t.rect.Contains(x, y):
+			return t, true
+		}
+	}
+	return hitTarget{}, false
+}
+
+// HandleEvent delivers a mouse event to the handler under the pointer, or
+// to the one holding the capture, and reports whether it was used
+func (r *MouseRouter) HandleEvent(ev Event) bool {
+	mev, ok := ev.(MouseEvent)
+	if !ok {
+		return false
+	}
+
+	r.mu.Lock()
+	captured := r.capture
+	if mev.Action == MouseRelease {
+		r.capture = nil
+	}
+	r.mu.Unlock()
+
+	var target hitTarget
+	switch {
+	case captured != nil && (mev.Action == MouseMotion || mev.Action == MouseRelease):
+		target = *captured
+	default:
+		if target, ok = r.hit(mev.X, mev.Y); !ok {
+			return false
+		}
+		if mev.Action == MousePress && mev.Button <= MouseRight {
+			r.mu.Lock()
+			r.capture = &target
+			r.mu.Unlock()
+		}
+	}
+
+	mev.X -= target.rect.X
+	mev.Y -= target.rect.Y
+	return target.handler.HandleEvent(mev)
+}
+
+// tagBounds returns the smallest rectangle around the cells shown with tag
+func (s *Screen) tagBounds(tag int) Rect {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	width, height := s.buf.Size()
+	minX, minY, maxX, maxY := width, height, -1, -1
+	for i, cell := range s.composite() {
+		if cell.Tag != tag {
+			continue
+		}
+		x, y := i%width, i/width
+		minX, minY = min(minX, x), min(minY, y)
+		maxX, maxY = max(maxX, x), max(maxY, y)
+	}
+	if maxX < 0 {
+		return Rect{}
+	}
+	return Rect{X: minX, Y: minY, W: maxX - minX + 1, H: maxY - minY + 1}
+}
@@ -0,0 +1,77 @@
+package goterm
+
+import "context"
+
+// Model is the state of an application run by a Program, in the
+// Model-Update-View style
+// The model is never changed in place by the runtime: each message produces
+// the next model, and the screen is drawn from the current one.
+type Model interface {
+	// Update returns the model that results from msg, which is an Event or
+	// a message produced by a Cmd, and optionally a Cmd to run
+	Update(msg Msg) (Model, Cmd)
+	// View draws the model onto the screen, which has been cleared
+	View(s *Screen)
+}
+
+// Program runs a Model on an App: events and the messages of commands go
+// into the model's Update, and every frame is drawn by its View
+// It is for applications that prefer to keep all their state in one value
+// changed only by messages, while still drawing with the full Screen API:
+//
+//	type counter int
+//
+//	func (c counter) Update(msg goterm.Msg) (goterm.Model, goterm.Cmd) {
+//		if k, ok := msg.(goterm.KeyEvent); ok && k.Rune == '+' {
+//			return c + 1, nil
+//		}
+//		return c, nil
+//	}
+//
+//	func (c counter) View(s *goterm.Screen) {
+//		s.DrawText(0, 0, strconv.Itoa(int(c)), goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+//	}
+//
+//	final, err := goterm.NewProgram(counter(0)).Run(ctx)
+//
+// Events the model has seen are still offered to the App's Handlers, and an
+// unhandled Ctrl+C quits.
+type Program struct {
+	App *App // Runs the program; its OnEvent, OnMessage and Draw are replaced
+
+	model Model
+}
+
+// NewProgram creates a program for model on a default App
+func NewProgram(model Model) *Program {
+	return &Program{App: &App{}, model: model}
+}
+
+// Run runs the program until a command returns Quit, the App is stopped or
+// ctx is done, and returns the final model
+func (p *Program) Run(ctx context.Context) (Model, error) {
+	p.App.OnEvent = func(ev Event) bool {
+		p.update(ev)
+		return false
+	}
+	p.App.OnMessage = p.update
+	p.App.Draw = func(s *Screen) {
+		p.model.View(s)
+	}
+	err := p.App.Run(ctx)
+	return p.model, err
+}
+
+// Send passes msg to the model's Update from the event loop
+// It is safe to call from any goroutine.
+func (p *Program) Send(msg Msg) {
+	p.App.Post(msg)
+}
+
+// update passes msg to the model's Update on the event loop and runs the
+// command it returns
+func (p *Program) update(msg Msg) {
+	var cmd Cmd
+	p.model, cmd = p.model.Update(msg)
+	p.App.Go(cmd)
+}
@@ -0,0 +1,103 @@
+package goterm
+
+// Shade is the density of a block shading character
+type Shade int
+
+// Shade constants, from empty to solid
+const (
+	ShadeNone   Shade = iota // ' '
+	ShadeLight               // ░
+	ShadeMedium              // ▒
+	ShadeDark                // ▓
+	ShadeFull                // █
+)
+
+// shadeRunes maps each shade to its block character
+var shadeRunes = [...]rune{' ', '░', '▒', '▓', '█'}
+
+// Rune returns the block character for the shade
+// Out of range shades are clamped to ShadeNone or ShadeFull.
+func (s Shade) Rune() rune {
+	return shadeRunes[max(ShadeNone, min(s, ShadeFull))]
+}
+
+// Orientation is the direction along which a pattern or widget runs
+type Orientation int
+
+// Orientation constants
+const (
+	Horizontal Orientation = iota
+	Vertical
+)
+
+// FillShade fills rect with a shade block drawn in fg over bg
+func (s *Screen) FillShade(rect Rect, shade Shade, fg, bg Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FillShade(rect, shade, fg, bg)
+}
+
+// FillChecker fills rect with a checkerboard of w×h squares alternating
+// between first and second, starting with first in the top-left corner
+// Squares twice as wide as they are tall look square on most terminals.
+func (s *Screen) FillChecker(rect Rect, w, h int, first, second Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FillChecker(rect, w, h, first, second)
+}
+
+// FillDithered fills rect with a gradient from one color to another along
+// the given orientation, dithered with shade blocks
+// Each cell draws a shade block in to over from, so the gradient works with
+// any two colors on 16 and 256-color terminals where smooth RGB gradients are
+// not possible.
+func (s *Screen) FillDithered(rect Rect, from, to Color, orientation Orientation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FillDithered(rect, from, to, orientation)
+}
+
+// FillShade fills rect with a shade block drawn in fg over bg
+func (b *Buffer) FillShade(rect Rect, shade Shade, fg, bg Color) {
+	b.Fill(rect.X, rect.Y, rect.W, rect.H, NewCell(shade.Rune(), fg, bg, StyleNone))
+}
+
+// FillChecker fills rect with a checkerboard of w×h squares alternating
+// between first and second
+// See Screen.FillChecker.
+func (b *Buffer) FillChecker(rect Rect, w, h int, first, second Cell) {
+	w, h = max(w, 1), max(h, 1)
+	for y := 0; y < rect.H; y++ {
+		for x := 0; x < rect.W; x++ {
+			cell := first
+			if (x/w+y/h)%2 == 1 {
+				cell = second
+			}
+			b.SetCell(rect.X+x, rect.Y+y, cell)
+		}
+	}
+}
+
+// FillDithered fills rect with a dithered gradient from one color to another
+// See Screen.FillDithered.
+func (b *Buffer) FillDithered(rect Rect, from, to Color, orientation Orientation) {
+	steps := rect.W
+	if orientation == Vertical {
+		steps = rect.H
+	}
+
+	for y := 0; y < rect.H; y++ {
+		for x := 0; x < rect.W; x++ {
+			i := x
+			if orientation == Vertical {
+				i = y
+			}
+			// Round to the nearest of the five shade levels
+			shade := ShadeFull
+			if steps > 1 {
+				shade = Shade((i*int(ShadeFull)*2 + steps - 1) / (2 * (steps - 1)))
+			}
+			b.SetCell(rect.X+x, rect.Y+y, NewCell(shade.Rune(), to, from, StyleNone))
+		}
+	}
+}
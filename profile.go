@@ -0,0 +1,120 @@
+package goterm
+
+import (
+	"os"
+	"strings"
+)
+
+// SetColorProfile sets the color capability of the terminal
+// Show converts every color to the profile before output: true colors
+// become their nearest 256 or 16-color equivalents and, with
+// ColorModeDefault, all colors are dropped for monochrome terminals. This
+// lets applications use RGB colors throughout and still render correctly
+// everywhere. Screens created with NewScreen use ColorModeTrueColor, which
+// outputs colors unchanged; Init uses DetectColorProfile.
+func (s *Screen) SetColorProfile(mode ColorMode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profile = mode
+}
+
+// ColorProfile returns the color capability colors are converted to by Show
+func (s *Screen) ColorProfile() ColorMode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profile
+}
+
+// SetBoldAsBright sets whether Show promotes bold text in one of the 8 basic
+// ANSI colors to its bright variant (indexes 8-15)
+// Some terminals display bold text in bright colors and others only make it
+// heavier; enabling promotion gives bold text the bright colors everywhere.
+// It is disabled by default.
+func (s *Screen) SetBoldAsBright(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.boldBright = enabled
+}
+
+// SetSupportedStyles declares which style flags the terminal renders
+// Show replaces unsupported flags with a supported substitute (italic and
+// overline become underline, blinking becomes bold) or drops them, instead
+// of emitting codes the terminal ignores or misrenders. Screens created with
+// NewScreen support StyleAll; Init uses DetectSupportedStyles.
+func (s *Screen) SetSupportedStyles(supported Style) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.styles = supported
+}
+
+// SupportedStyles returns the style flags Show passes to the terminal
+func (s *Screen) SupportedStyles() Style {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.styles
+}
+
+// DetectSupportedStyles guesses the style flags the terminal renders from
+// the TERM variable
+// The Linux console and classic terminals such as vt100 and GNU screen lack
+// italic, strikethrough and overline; TERM=dumb supports no styles. Other
+// terminals are assumed to support every style.
+func DetectSupportedStyles() Style {
+	basic := StyleBold | StyleDim | StyleUnderline | StyleSlowBlink | StyleReverse | StyleConceal
+
+	termName := os.Getenv("TERM")
+	switch {
+	case termName == "dumb":
+		return StyleNone
+	case termName == "linux", strings.HasPrefix(termName, "vt"), termName == "screen",
+		strings.HasPrefix(termName, "screen."), strings.HasPrefix(termName, "screen-"):
+		return basic
+	}
+	return StyleAll
+}
+
+// DetectColorProfile guesses the color capability of the terminal from the
+// environment
+// COLORTERM=truecolor (or 24bit) selects true color, a TERM ending in
+// "256color" selects 256 colors, TERM=dumb or a non-empty NO_COLOR variable
+// disables colors, and anything else gets the 16 ANSI colors.
+func DetectColorProfile() ColorMode {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorModeDefault
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorModeTrueColor
+	}
+
+	termName := os.Getenv("TERM")
+	switch {
+	case termName == "dumb":
+		return ColorModeDefault
+	case strings.HasSuffix(termName, "256color"):
+		return ColorMode256
+	}
+	return ColorMode16
+}
+
+// convert returns the color as it can be displayed with the given profile
+func (c Color) convert(profile ColorMode) Color {
+	switch profile {
+	case ColorModeDefault:
+		return ColorDefault()
+	case ColorMode16:
+		return c.To16()
+	case ColorMode256:
+		return c.To256()
+	}
+	return c
+}
+
+// brighten returns the bright variant of the 8 basic ANSI colors and leaves
+// other colors unchanged
+func (c Color) brighten() Color {
+	if c.mode == ColorMode16 && c.index < 8 {
+		return ColorIndex(c.index + 8)
+	}
+	return c
+}
@@ -0,0 +1,74 @@
+package goterm
+
+import (
+	"image"
+	"image/color"
+)
+
+// QuantizeImage reduces an image to the 256-color or 16-color terminal
+// palette, the first step of drawing images with cells on terminals without
+// true color
+// mode selects the palette: ColorMode16 for the 16 ANSI colors and
+// ColorMode256 (or any other mode) for the 256-color palette. Each pixel
+// index of the result is a palette index, so ColorIndex turns it into a
+// Color. Colors are matched as by Color.To16 and Color.To256; with dither,
+// Floyd-Steinberg error diffusion spreads the matching error to neighboring
+// pixels, which preserves gradients and detail at the cost of noise.
+// Translucent pixels are treated as drawn over black.
+func QuantizeImage(img image.Image, mode ColorMode, dither bool) *image.Paletted {
+	size := 256
+	if mode == ColorMode16 {
+		size = 16
+	}
+	palette := make(color.Palette, size)
+	for i := range palette {
+		r, g, b := paletteRGB(uint8(i)) // #nosec G115
+		palette[i] = color.RGBA{R: r, G: g, B: b, A: 255}
+	}
+
+	bounds := img.Bounds()
+	out := image.NewPaletted(bounds, palette)
+	w := bounds.Dx()
+
+	// Accumulated error for the current and next row, 3 channels per pixel
+	// with a column of padding on both sides
+	cur := make([]float64, 3*(w+2))
+	next := make([]float64, 3*(w+2))
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			i := 3 * (x - bounds.Min.X + 1)
+			r, g, b, _ := img.At(x, y).RGBA()
+			want := [3]float64{float64(r>>8) + cur[i], float64(g>>8) + cur[i+1], float64(b>>8) + cur[i+2]}
+
+			c := ColorRGB(clampByte(want[0]), clampByte(want[1]), clampByte(want[2]))
+			if mode == ColorMode16 {
+				c = c.To16()
+			} else {
+				c = c.To256()
+			}
+			out.SetColorIndex(x, y, c.Index())
+			if !dither {
+				continue
+			}
+
+			pr, pg, pb := paletteRGB(c.Index())
+			got := [3]float64{float64(pr), float64(pg), float64(pb)}
+			for ch := range 3 {
+				e := want[ch] - got[ch]
+				cur[i+3+ch] += e * 7 / 16
+				next[i-3+ch] += e * 3 / 16
+				next[i+ch] += e * 5 / 16
+				next[i+3+ch] += e * 1 / 16
+			}
+		}
+		cur, next = next, cur
+		clear(next)
+	}
+	return out
+}
+
+// clampByte rounds f to the nearest value in 0-255
+func clampByte(f float64) uint8 {
+	return uint8(max(0, min(255, f+0.5)))
+}
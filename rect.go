@@ -0,0 +1,30 @@
+package goterm
+
+// Rect describes a rectangular region of cells
+type Rect struct {
+	X, Y int // Top-left corner
+	W, H int // Width and height in cells
+}
+
+// Empty reports whether the rectangle contains no cells
+func (r Rect) Empty() bool {
+	return r.W <= 0 || r.H <= 0
+}
+
+// Contains reports whether the cell (x, y) lies inside the rectangle
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && y >= r.Y && x < r.X+r.W && y < r.Y+r.H
+}
+
+// Intersect returns the region covered by both rectangles
+// The result is empty if they do not overlap.
+func (r Rect) Intersect(other Rect) Rect {
+	x0 := max(r.X, other.X)
+	y0 := max(r.Y, other.Y)
+	x1 := min(r.X+r.W, other.X+other.W)
+	y1 := min(r.Y+r.H, other.Y+other.H)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{}
+	}
+	return Rect{X: x0, Y: y0, W: x1 - x0, H: y1 - y0}
+}
@@ -0,0 +1,72 @@
+package goterm
+
+// ApplyStyle replaces every cell in rect with the value fn returns for it
+// It is the position-independent form of ForEach, for restyling content that
+// has already been drawn, such as marking a panel focused or disabled. fn
+// must not call methods of the screen. The region is clipped to the screen
+// bounds.
+func (s *Screen) ApplyStyle(rect Rect, fn func(Cell) Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.ApplyStyle(rect, fn)
+}
+
+// DimRegion draws every cell in rect with StyleDim, e.g. for disabled
+// controls or content behind a modal dialog
+func (s *Screen) DimRegion(rect Rect) {
+	s.ApplyStyle(rect, dimCell)
+}
+
+// InvertRegion toggles StyleReverse on every cell in rect
+// Inverting a region twice restores it, which suits highlighting a focused
+// or selected area.
+func (s *Screen) InvertRegion(rect Rect) {
+	s.ApplyStyle(rect, invertCell)
+}
+
+// TintRegion recolors the foreground of every cell in rect, keeping its
+// characters, background and style
+func (s *Screen) TintRegion(rect Rect, color Color) {
+	s.ApplyStyle(rect, tintCell(color))
+}
+
+// ApplyStyle replaces every cell in rect with the value fn returns for it
+// See Screen.ApplyStyle.
+func (b *Buffer) ApplyStyle(rect Rect, fn func(Cell) Cell) {
+	b.ForEach(rect, func(_, _ int, c Cell) Cell { return fn(c) })
+}
+
+// DimRegion draws every cell in rect with StyleDim
+func (b *Buffer) DimRegion(rect Rect) {
+	b.ApplyStyle(rect, dimCell)
+}
+
+// InvertRegion toggles StyleReverse on every cell in rect
+func (b *Buffer) InvertRegion(rect Rect) {
+	b.ApplyStyle(rect, invertCell)
+}
+
+// TintRegion recolors the foreground of every cell in rect
+func (b *Buffer) TintRegion(rect Rect, color Color) {
+	b.ApplyStyle(rect, tintCell(color))
+}
+
+// dimCell returns the cell with StyleDim set
+func dimCell(c Cell) Cell {
+	c.Style = c.Style.Set(StyleDim)
+	return c
+}
+
+// invertCell returns the cell with StyleReverse toggled
+func invertCell(c Cell) Cell {
+	c.Style = c.Style.Toggle(StyleReverse)
+	return c
+}
+
+// tintCell returns a function that sets the foreground of a cell to color
+func tintCell(color Color) func(Cell) Cell {
+	return func(c Cell) Cell {
+		c.Fg = color
+		return c
+	}
+}
@@ -0,0 +1,210 @@
+package goterm
+
+import (
+	"math"
+	"time"
+)
+
+// Scene is a top-level view of an application, such as a menu, a game or a
+// game-over screen, managed by a SceneManager
+type Scene interface {
+	// Update advances the scene by dt while it is on top
+	Update(dt time.Duration)
+	// Draw draws the scene onto the cleared screen
+	Draw(s *Screen)
+	// HandleEvent reports whether ev was used
+	HandleEvent(ev Event) bool
+}
+
+// Transition draws the frame that is t of the way, from 0 to 1, from one
+// scene to the next into dst
+// from is the last frame shown before the switch and to the current frame
+// of the new scene; dst has the size of to and every cell must be written.
+type Transition func(dst, from, to *Buffer, t float64)
+
+// defaultTransitionDuration is the length of transitions when
+// SceneManager.Duration is not set
+const defaultTransitionDuration = 300 * time.Millisecond
+
+// SceneManager keeps a stack of scenes and runs the one on top
+// Push shows a scene over the current one, such as a pause menu over a game,
+// Pop goes back to the one below, and Replace swaps the top scene for
+// another. Only the top scene is updated, drawn and given events. With a
+// Transition set, each switch blends from the last frame of the old scene to
+// the new one over Duration.
+//
+// A SceneManager is itself a Scene, so it plugs straight into an App:
+//
+//	scenes := &goterm.SceneManager{Transition: goterm.Wipe}
+//	scenes.Push(menu)
+//	app := &goterm.App{Update: scenes.Update, Draw: scenes.Draw, Handlers: []goterm.EventHandler{scenes}}
+//
+// Like widgets, it is meant to be used from the event loop and is not safe
+// for concurrent use.
+type SceneManager struct {
+	Transition Transition    // Effect between scenes; nil switches at once
+	Duration   time.Duration // Length of a transition; defaults to 300ms
+
+	scenes  []Scene // Bottom first
+	from    *Buffer // Frame the running transition starts from, or nil
+	last    *Buffer // Last frame drawn, kept while a Transition is set
+	elapsed time.Duration
+}
+
+// Push puts scene on top of the stack
+func (m *SceneManager) Push(scene Scene) {
+	m.scenes = append(m.scenes, scene)
+	m.begin()
+}
+
+// Pop removes the top scene and returns it, or nil if there is none
+func (m *SceneManager) Pop() Scene {
+	n := len(m.scenes)
+	if n == 0 {
+		return nil
+	}
+	top := m.scenes[n-1]
+	m.scenes = m.scenes[:n-1]
+	m.begin()
+	return top
+}
+
+// Replace swaps the top scene for scene and returns the one replaced, or
+// nil if the stack was empty
+func (m *SceneManager) Replace(scene Scene) Scene {
+	var top Scene
+	if n := len(m.scenes); n > 0 {
+		top = m.scenes[n-1]
+		m.scenes = m.scenes[:n-1]
+	}
+	m.scenes = append(m.scenes, scene)
+	m.begin()
+	return top
+}
+
+// Top returns the scene on top, or nil if the stack is empty
+func (m *SceneManager) Top() Scene {
+	if len(m.scenes) == 0 {
+		return nil
+	}
+	return m.scenes[len(m.scenes)-1]
+}
+
+// Len returns the number of scenes on the stack
+func (m *SceneManager) Len() int {
+	return len(m.scenes)
+}
+
+// Transitioning reports whether a transition is running
+func (m *SceneManager) Transitioning() bool {
+	return m.from != nil
+}
+
+// begin starts a transition from the last frame drawn
+func (m *SceneManager) begin() {
+	m.from, m.elapsed = nil, 0
+	if m.Transition != nil && m.last != nil {
+		m.from = m.last
+	}
+}
+
+// duration returns the length of a transition
+func (m *SceneManager) duration() time.Duration {
+	if m.Duration <= 0 {
+		return defaultTransitionDuration
+	}
+	return m.Duration
+}
+
+// Update advances the running transition and the top scene by dt
+func (m *SceneManager) Update(dt time.Duration) {
+	if m.from != nil {
+		m.elapsed += dt
+		if m.elapsed >= m.duration() {
+			m.from = nil
+		}
+	}
+	if top := m.Top(); top != nil {
+		top.Update(dt)
+	}
+}
+
+// Draw draws the top scene, blended with the previous one while a
+// transition runs
+func (m *SceneManager) Draw(s *Screen) {
+	if top := m.Top(); top != nil {
+		top.Draw(s)
+	}
+	if m.Transition == nil {
+		m.last = nil
+		return
+	}
+	frame := s.Snapshot()
+	if m.from != nil {
+		w, h := frame.Size()
+		dst := NewBuffer(w, h)
+		m.Transition(dst, m.from, frame, float64(m.elapsed)/float64(m.duration()))
+		s.Restore(dst)
+		frame = dst
+	}
+	m.last = frame
+}
+
+// HandleEvent passes ev to the top scene
+func (m *SceneManager) HandleEvent(ev Event) bool {
+	if top := m.Top(); top != nil {
+		return top.HandleEvent(ev)
+	}
+	return false
+}
+
+// Wipe is a Transition that uncovers the new scene from left to right
+func Wipe(dst, from, to *Buffer, t float64) {
+	w, h := dst.Size()
+	edge := int(math.Round(t * float64(w)))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < edge {
+				dst.SetCell(x, y, to.GetCell(x, y))
+			} else {
+				dst.SetCell(x, y, from.GetCell(x, y))
+			}
+		}
+	}
+}
+
+// Slide is a Transition that slides the new scene in from the right,
+// pushing the old one out to the left
+func Slide(dst, from, to *Buffer, t float64) {
+	w, h := dst.Size()
+	shift := int(math.Round(t * float64(w)))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x+shift < w {
+				dst.SetCell(x, y, from.GetCell(x+shift, y))
+			} else {
+				dst.SetCell(x, y, to.GetCell(x+shift-w, y))
+			}
+		}
+	}
+}
+
+// Dissolve is a Transition that replaces the cells of the old scene with
+// those of the new one in a scattered order
+func Dissolve(dst, from, to *Buffer, t float64) {
+	w, h := dst.Size()
+	threshold := uint32(t * 1024)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// A cheap hash of the position decides when each cell turns
+			n := uint32(x)*73856093 ^ uint32(y)*19349663
+			n ^= n >> 13
+			n *= 0x5bd1e995
+			if (n^n>>15)%1024 < threshold {
+				dst.SetCell(x, y, to.GetCell(x, y))
+			} else {
+				dst.SetCell(x, y, from.GetCell(x, y))
+			}
+		}
+	}
+}
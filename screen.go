@@ -4,17 +4,41 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sync"
 
 	"golang.org/x/term"
 )
 
 // Screen represents a terminal screen buffer with cells for rendering
+// All methods are safe for concurrent use; drawing is delegated to an
+// internal Buffer under the screen's lock.
 type Screen struct {
-	width  int
-	height int
-	cells  []Cell
-	mu     sync.RWMutex
+	buf     Buffer
+	layers  []*Layer  // Sorted by z-index
+	layouts []*Layout // In the order added
+	debug   *Layer    // Layout debugging overlay, nil when off
+	images  []*Image  // In the order added
+	theme   Theme
+	mu      sync.RWMutex
+
+	// Rendering options
+	dark       bool      // Terminal background is dark
+	profile    ColorMode // Colors are converted to this mode by Show
+	boldBright bool      // Show promotes bold basic colors to bright ones
+	styles     Style     // Style flags the terminal renders
+	sgr        *sgrCache // Composed attribute escape sequences
+	graphics   GraphicsProtocol
+
+	nextImageID uint32
+
+	// Cells as last shown, to count the cells each Show changes
+	frameMu sync.Mutex
+	shown   []Cell
+	changed int
+
+	// Images to free in the terminal at the next Show
+	deletedImages []uint32
 
 	// Terminal state
 	fd       int
@@ -29,24 +53,22 @@ func NewScreen(width, height int) *Screen {
 		panic(fmt.Sprintf("invalid screen dimensions: width=%d, height=%d", width, height))
 	}
 
-	s := &Screen{
-		width:  width,
-		height: height,
-		cells:  make([]Cell, width*height),
-		out:    os.Stdout,
+	return &Screen{
+		buf:     *NewBuffer(width, height),
+		theme:   DefaultTheme(),
+		dark:    true,
+		profile: ColorModeTrueColor,
+		styles:  StyleAll,
+		sgr:     newSGRCache(sgrCacheSize),
+		out:     os.Stdout,
 	}
-
-	// Initialize all cells to defaults
-	s.Clear()
-
-	return s
 }
 
 // Size returns the current screen dimensions
 func (s *Screen) Size() (width, height int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.width, s.height
+	return s.buf.Size()
 }
 
 // SetCell sets the cell at the specified position
@@ -54,12 +76,26 @@ func (s *Screen) Size() (width, height int) {
 func (s *Screen) SetCell(x, y int, cell Cell) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.buf.SetCell(x, y, cell)
+}
 
-	if x < 0 || y < 0 || x >= s.width || y >= s.height {
-		return
-	}
+// SetContent sets the cell at the specified position to primary followed by
+// any combining runes, as in tcell
+// This is a richer alternative to SetCell for decomposed Unicode, e.g.
+// SetContent(x, y, 'e', []rune{'\u0301'}, ...) displays "é". A wide character
+// also claims the next column, which is filled with a blank cell.
+func (s *Screen) SetContent(x, y int, primary rune, combining []rune, fg, bg Color, style Style) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.SetContent(x, y, primary, combining, fg, bg, style)
+}
 
-	s.cells[y*s.width+x] = cell
+// GetContent returns the runes, colors, style and display width of the cell
+// at the specified position, as in tcell
+func (s *Screen) GetContent(x, y int) (primary rune, combining []rune, fg, bg Color, style Style, width int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.buf.GetContent(x, y)
 }
 
 // GetCell returns the cell at the specified position
@@ -67,81 +103,246 @@ func (s *Screen) SetCell(x, y int, cell Cell) {
 func (s *Screen) GetCell(x, y int) Cell {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.buf.GetCell(x, y)
+}
 
-	if x < 0 || y < 0 || x >= s.width || y >= s.height {
-		return NewCell(' ', ColorDefault(), ColorDefault(), StyleNone)
-	}
+// GetRow returns a copy of row y
+// Returns nil if y is out of bounds
+func (s *Screen) GetRow(y int) []Cell {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.buf.GetRow(y)
+}
 
-	return s.cells[y*s.width+x]
+// CopyInto copies the cells of rect into dst row by row, so the cell at
+// (rect.X+x, rect.Y+y) lands at dst[y*rect.W+x], and returns the number of
+// cells written
+// The copy is made under a single lock, which makes it far cheaper than
+// calling GetCell per cell. Positions outside the screen are written as
+// default empty cells; copying stops when dst is full.
+func (s *Screen) CopyInto(dst []Cell, rect Rect) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.buf.CopyInto(dst, rect)
 }
 
 // Clear resets all cells to their default state
 func (s *Screen) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.buf.Clear()
+}
+
+// Fill sets every cell of the w×h region at (x, y) to cell
+// The region is clipped to the screen bounds.
+func (s *Screen) Fill(x, y, w, h int, cell Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Fill(x, y, w, h, cell)
+}
+
+// FillStyle changes the colors and style of every cell in rect while keeping
+// the characters, e.g. to highlight a panel or selection
+// The region is clipped to the screen bounds.
+func (s *Screen) FillStyle(rect Rect, fg, bg Color, style Style) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FillStyle(rect, fg, bg, style)
+}
+
+// ForEach calls fn for every cell in rect and replaces the cell with the
+// value fn returns, e.g. to dim everything behind a modal dialog
+// All cells are visited under a single lock, so fn must not call methods of
+// the screen. The region is clipped to the screen bounds.
+func (s *Screen) ForEach(rect Rect, fn func(x, y int, c Cell) Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.ForEach(rect, fn)
+}
+
+// Scroll shifts the whole screen content vertically by dy rows and fills the
+// vacated rows with fill
+// Positive dy moves content up (new rows appear at the bottom, as in a log
+// pane); negative dy moves it down.
+func (s *Screen) Scroll(dy int, fill Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Scroll(dy, fill)
+}
+
+// ScrollRegion shifts the content inside rect vertically by dy rows, leaving
+// cells outside rect untouched, and fills the vacated rows with fill
+// The direction of dy is the same as for Scroll.
+func (s *Screen) ScrollRegion(rect Rect, dy int, fill Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.ScrollRegion(rect, dy, fill)
+}
+
+// InsertLines inserts n rows filled with fill at row y, shifting the rows
+// below down; rows pushed past the bottom are discarded
+// This is the natural primitive for editors and list views inserting rows.
+func (s *Screen) InsertLines(y, n int, fill Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.InsertLines(y, n, fill)
+}
+
+// DeleteLines removes n rows starting at row y, shifting the rows below up
+// and filling the rows vacated at the bottom with fill
+func (s *Screen) DeleteLines(y, n int, fill Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DeleteLines(y, n, fill)
+}
+
+// Blit copies the srcRect region of src onto the screen with its top-left
+// corner at (dstX, dstY), clipped to both the source and the screen
+// Pre-rendered panels, sprites and cached widgets can be stamped this way
+// without redrawing them cell by cell.
+func (s *Screen) Blit(dstX, dstY int, src *Buffer, srcRect Rect) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Blit(dstX, dstY, src, srcRect)
+}
+
+// PushClip restricts all subsequent drawing to rect, within any clip region
+// already active, until the matching PopClip
+// SetCell, DrawText and the other drawing methods silently discard cells
+// outside the clip region, so a widget can be kept from scribbling over its
+// neighbors. Resize drops all clip regions.
+func (s *Screen) PushClip(rect Rect) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.PushClip(rect)
+}
+
+// PopClip restores the clip region active before the last PushClip
+func (s *Screen) PopClip() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.PopClip()
+}
+
+// Snapshot returns a copy of the screen's cell grid
+// Layers are not included; see Composite for the rendered result.
+func (s *Screen) Snapshot() *Buffer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b := NewBuffer(s.buf.width, s.buf.height)
+	copy(b.cells, s.buf.cells)
+	return b
+}
+
+// Restore replaces the screen's cells with those of a snapshot, e.g. to put
+// back exactly what a modal dialog covered
+// Unlike Blit, transparent cells are restored as they are. If the screen has
+// been resized since the snapshot was taken, the overlapping region is
+// restored and the rest is cleared.
+func (s *Screen) Restore(snapshot *Buffer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	defaultCell := NewCell(' ', ColorDefault(), ColorDefault(), StyleNone)
-	for i := range s.cells {
-		s.cells[i] = defaultCell
+	s.buf.Clear()
+	w := min(s.buf.width, snapshot.width)
+	h := min(s.buf.height, snapshot.height)
+	for y := 0; y < h; y++ {
+		copy(s.buf.cells[y*s.buf.width:y*s.buf.width+w], snapshot.cells[y*snapshot.width:y*snapshot.width+w])
 	}
 }
 
 // DrawText draws text at the specified position with the given colors and style
-// Text that extends beyond the screen width is clipped
+// Text is split into grapheme clusters so combining marks and emoji sequences
+// (ZWJ, variation selectors, skin tones, flags) share one cell with their base
+// character; zero-width runes at the start of text attach to the cell left of
+// x. Wide characters occupy two columns; the second column is filled with a
+// blank cell. Text that extends beyond the screen width is clipped, and a wide
+// character that does not fit in the last column is replaced by a blank.
+//
+// Tabs advance to the next tab stop (counted from x, see SetTabWidth), '\n'
+// (or "\r\n") continues on the next row at column x, and other C0 control
+// characters are drawn as their visible Control Pictures symbol (␀-␟, ␡).
+// When bidi reordering is enabled, right-to-left runs are drawn in visual
+// order.
 func (s *Screen) DrawText(x, y int, text string, fg, bg Color, style Style) {
-	runes := []rune(text)
-	for i, ch := range runes {
-		cell := NewCell(ch, fg, bg, style)
-		s.SetCell(x+i, y, cell)
-	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawText(x, y, text, fg, bg, style)
 }
 
-// Resize changes the screen dimensions
-// Content is preserved where it fits in the new dimensions
-func (s *Screen) Resize(width, height int) {
+// DrawTextf formats according to a format specifier and draws the result at
+// the specified position like DrawText
+func (s *Screen) DrawTextf(x, y int, fg, bg Color, style Style, format string, args ...any) {
+	s.DrawText(x, y, fmt.Sprintf(format, args...), fg, bg, style)
+}
+
+// DrawTextWrapped draws text wrapped to the given width starting at (x, y)
+// using Wrap, and returns the number of lines drawn
+func (s *Screen) DrawTextWrapped(x, y, width int, text string, fg, bg Color, style Style) int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.buf.DrawTextWrapped(x, y, width, text, fg, bg, style)
+}
 
-	if width <= 0 || height <= 0 {
-		return
-	}
+// SetUnprintablePolicy sets how DrawText displays runes the terminal is
+// unlikely to render, such as invalid UTF-8 or C1 control characters
+// The fallback rune is used by UnprintableFallback.
+func (s *Screen) SetUnprintablePolicy(policy UnprintablePolicy, fallback rune) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.text.unprintable = policy
+	s.buf.text.fallback = fallback
+}
 
-	// Create new buffer
-	newCells := make([]Cell, width*height)
-	defaultCell := NewCell(' ', ColorDefault(), ColorDefault(), StyleNone)
-	for i := range newCells {
-		newCells[i] = defaultCell
+// SetTabWidth sets the distance between tab stops used by DrawText
+// Values below 1 are ignored. The default is 8.
+func (s *Screen) SetTabWidth(width int) {
+	if width < 1 {
+		return
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.text.tabWidth = width
+}
 
-	// Copy existing content that fits
-	minWidth := width
-	if s.width < minWidth {
-		minWidth = s.width
-	}
-	minHeight := height
-	if s.height < minHeight {
-		minHeight = s.height
-	}
+// SetBidi enables or disables the bidirectional reordering pass applied by
+// DrawText, which displays Arabic, Hebrew and other right-to-left text in
+// visual order. Leave it disabled (the default) on terminals that perform
+// their own bidi processing, or the text would be reversed twice.
+func (s *Screen) SetBidi(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.text.bidi = enabled
+}
 
-	for y := 0; y < minHeight; y++ {
-		for x := 0; x < minWidth; x++ {
-			oldIdx := y*s.width + x
-			newIdx := y*width + x
-			newCells[newIdx] = s.cells[oldIdx]
-		}
+// Resize changes the screen dimensions
+// Content is preserved where it fits in the new dimensions
+func (s *Screen) Resize(width, height int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Resize(width, height)
+	for _, l := range s.layers {
+		l.buf.Resize(width, height)
+		l.fitView()
 	}
-
-	s.width = width
-	s.height = height
-	s.cells = newCells
+	s.invalidateImages()
 }
 
-// Show renders the screen buffer to the terminal
+// Show renders the screen buffer, with visible layers composited on top, to
+// the terminal, converting colors to the color profile
 // This is where the actual terminal escape sequences are written
 func (s *Screen) Show() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	cells := s.composite()
+	s.countChanged(cells)
+	skip, err := s.showImages(cells)
+	if err != nil {
+		return err
+	}
+
 	// Move cursor to home position
 	if _, err := fmt.Fprint(s.out, "\x1b[H"); err != nil {
 		return fmt.Errorf("failed to move cursor: %w", err)
@@ -149,38 +350,39 @@ func (s *Screen) Show() error {
 
 	var lastFg, lastBg Color
 	var lastStyle Style
+	var lastLink string
 	needsReset := false
 
-	for y := 0; y < s.height; y++ {
-		for x := 0; x < s.width; x++ {
-			cell := s.cells[y*s.width+x]
-
-			// Output color/style changes only when needed
-			if cell.Fg != lastFg || cell.Bg != lastBg || cell.Style != lastStyle || needsReset {
-				// Reset before applying new attributes
-				if _, err := fmt.Fprint(s.out, "\x1b[0m"); err != nil {
-					return fmt.Errorf("failed to reset attributes: %w", err)
-				}
-
-				// Set foreground color
-				if cell.Fg.Mode() != ColorModeDefault {
-					if _, err := fmt.Fprint(s.out, cell.Fg.ansiCode(true)); err != nil {
-						return fmt.Errorf("failed to set foreground color: %w", err)
-					}
+	backdrop := s.backdrop()
+	width, height := s.buf.Size()
+	for y := 0; y < height; y++ {
+		gap := 0 // Cells skipped since the last one written
+		for x := 0; x < width; x++ {
+			if skip != nil && skip[y*width+x] {
+				gap++
+				continue
+			}
+			if gap > 0 {
+				if _, err := fmt.Fprintf(s.out, "\x1b[%dC", gap); err != nil {
+					return fmt.Errorf("failed to move cursor: %w", err)
 				}
+				gap = 0
+			}
 
-				// Set background color
-				if cell.Bg.Mode() != ColorModeDefault {
-					if _, err := fmt.Fprint(s.out, cell.Bg.ansiCode(false)); err != nil {
-						return fmt.Errorf("failed to set background color: %w", err)
-					}
-				}
+			cell := cells[y*width+x]
+			cell.Bg = cell.Bg.over(backdrop).convert(s.profile)
+			cell.Fg = cell.Fg.over(cell.Bg).over(backdrop).convert(s.profile)
+			if s.boldBright && cell.Style.Has(StyleBold) {
+				cell.Fg = cell.Fg.brighten()
+			}
+			cell = cell.resolveReverse()
+			cell.Style = cell.Style.filter(s.styles)
 
-				// Set style
-				if cell.Style != StyleNone {
-					if _, err := fmt.Fprint(s.out, cell.Style.ansiCode()); err != nil {
-						return fmt.Errorf("failed to set style: %w", err)
-					}
+			// Output color/style changes only when needed
+			if cell.Fg != lastFg || cell.Bg != lastBg || cell.Style != lastStyle || needsReset {
+				// Reset and apply the new attributes in one sequence
+				if _, err := s.out.Write(s.sgr.get(cell.Fg, cell.Bg, cell.Style)); err != nil {
+					return fmt.Errorf("failed to set attributes: %w", err)
 				}
 
 				lastFg = cell.Fg
@@ -189,21 +391,42 @@ func (s *Screen) Show() error {
 				needsReset = false
 			}
 
+			if cell.Link != lastLink {
+				if _, err := fmt.Fprint(s.out, linkSequence(cell.Link)); err != nil {
+					return fmt.Errorf("failed to set hyperlink: %w", err)
+				}
+				lastLink = cell.Link
+			}
+
 			// Output the character
-			if _, err := fmt.Fprint(s.out, string(cell.Ch)); err != nil {
+			text := cell.text()
+			if cell.Transparent() {
+				text = " "
+			}
+			if _, err := fmt.Fprint(s.out, text); err != nil {
 				return fmt.Errorf("failed to write character: %w", err)
 			}
+
+			// Wide characters also cover the next column, so skip its cell
+			if cell.width() == 2 && x+1 < width {
+				x++
+			}
 		}
 
 		// Move to next line if not last line
-		if y < s.height-1 {
+		if y < height-1 {
 			if _, err := fmt.Fprint(s.out, "\r\n"); err != nil {
 				return fmt.Errorf("failed to write newline: %w", err)
 			}
 		}
 	}
 
-	// Reset attributes at end
+	// Close any open hyperlink and reset attributes at end
+	if lastLink != "" {
+		if _, err := fmt.Fprint(s.out, linkSequence("")); err != nil {
+			return fmt.Errorf("failed to close hyperlink: %w", err)
+		}
+	}
 	if _, err := fmt.Fprint(s.out, "\x1b[0m"); err != nil {
 		return fmt.Errorf("failed to reset final attributes: %w", err)
 	}
@@ -211,9 +434,39 @@ func (s *Screen) Show() error {
 	return nil
 }
 
+// countChanged records how many of cells differ from the cells last shown,
+// and keeps them for the next Show
+func (s *Screen) countChanged(cells []Cell) {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+	if len(s.shown) != len(cells) {
+		s.changed = len(cells)
+		s.shown = slices.Clone(cells)
+		return
+	}
+	s.changed = 0
+	for i, cell := range cells {
+		if cell != s.shown[i] {
+			s.changed++
+		}
+	}
+	copy(s.shown, cells)
+}
+
+// lastChanged returns the number of cells the last Show changed
+func (s *Screen) lastChanged() int {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+	return s.changed
+}
+
 // Sync flushes any buffered output to the terminal
 func (s *Screen) Sync() error {
-	if f, ok := s.out.(*os.File); ok {
+	out := s.out
+	if c, ok := out.(*countingWriter); ok {
+		out = c.w
+	}
+	if f, ok := out.(*os.File); ok {
 		return f.Sync()
 	}
 	return nil
@@ -249,9 +502,18 @@ func Init() (*Screen, error) {
 		return nil, fmt.Errorf("%w: %v", ErrTerminalSetupFailed, err)
 	}
 
+	// Account for emoji width quirks of the running terminal emulator
+	if !ApplyTerminalWidthOverrides(os.Getenv("TERM_PROGRAM")) {
+		ApplyTerminalWidthOverrides(os.Getenv("TERM"))
+	}
+
 	screen := NewScreen(width, height)
 	screen.fd = fd
 	screen.oldState = oldState
+	screen.dark = DetectDarkBackground()
+	screen.profile = DetectColorProfile()
+	screen.styles = DetectSupportedStyles()
+	screen.graphics = DetectGraphicsProtocol()
 
 	// Clear screen and hide cursor
 	if _, err := fmt.Fprint(screen.out, "\x1b[2J\x1b[H\x1b[?25l"); err != nil {
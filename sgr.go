@@ -0,0 +1,243 @@
+package goterm
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSGR converts SGR (Select Graphic Rendition) escape sequences such as
+// "\x1b[1;38;5;208m" into the colors and style they select
+// seq may hold several sequences back to back, which are applied in order
+// starting from the default colors and no style, as a terminal would.
+// Both the ';' and ':' separated forms of 256-color and RGB parameters are
+// understood; attributes without a Style equivalent are ignored. Returns an
+// error wrapping ErrInvalidSGR if seq is not a series of SGR sequences.
+func ParseSGR(seq []byte) (fg, bg Color, style Style, err error) {
+	st := sgrState{fg: ColorDefault(), bg: ColorDefault()}
+	rest := seq
+	if len(rest) == 0 {
+		return fg, bg, style, fmt.Errorf("%w: empty sequence", ErrInvalidSGR)
+	}
+	for len(rest) > 0 {
+		if !bytes.HasPrefix(rest, []byte("\x1b[")) {
+			return fg, bg, style, fmt.Errorf("%w: %q", ErrInvalidSGR, seq)
+		}
+		end := bytes.IndexByte(rest, 'm')
+		if end < 0 {
+			return fg, bg, style, fmt.Errorf("%w: %q", ErrInvalidSGR, seq)
+		}
+		if err := st.apply(string(rest[2:end])); err != nil {
+			return fg, bg, style, fmt.Errorf("%w: %q: %v", ErrInvalidSGR, seq, err)
+		}
+		rest = rest[end+1:]
+	}
+	return st.fg, st.bg, st.style, nil
+}
+
+// ParseANSI splits text colored with SGR escape sequences, such as the
+// output of ls --color or git diff, into styled lines
+// The rendition carries over from one line to the next as on a terminal.
+// Other CSI and OSC sequences are dropped, as are SGR sequences that cannot
+// be parsed.
+func ParseANSI(text string) []StyledText {
+	st := sgrState{fg: ColorDefault(), bg: ColorDefault()}
+	lines := []StyledText{nil}
+	var run strings.Builder
+	flush := func() {
+		if run.Len() > 0 {
+			last := len(lines) - 1
+			lines[last] = lines[last].Add(run.String(), st.fg, st.bg, st.style)
+			run.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\n':
+			flush()
+			lines = append(lines, nil)
+		case c == '\r':
+		case c != 0x1b:
+			run.WriteByte(c)
+		case i+1 < len(text) && text[i+1] == '[':
+			// CSI: parameters and intermediates up to a final byte in 0x40-0x7e
+			end := i + 2
+			for end < len(text) && (text[end] < 0x40 || text[end] > 0x7e) {
+				end++
+			}
+			if end < len(text) && text[end] == 'm' {
+				next := st
+				if next.apply(text[i+2:end]) == nil {
+					flush()
+					st = next
+				}
+			}
+			i = end
+		case i+1 < len(text) && text[i+1] == ']':
+			// OSC: ends with BEL or ST
+			end := i + 2
+			for end < len(text) && text[end] != '\a' && !strings.HasPrefix(text[end:], "\x1b\\") {
+				end++
+			}
+			if strings.HasPrefix(text[end:], "\x1b\\") {
+				end++
+			}
+			i = end
+		default:
+			i++ // Two-byte escape
+		}
+	}
+	flush()
+	return lines
+}
+
+// sgrState is the rendition built up by successive SGR sequences
+type sgrState struct {
+	fg, bg Color
+	style  Style
+}
+
+// sgrStyles maps SGR attribute codes to the style they set
+var sgrStyles = map[int]Style{
+	1: StyleBold, 2: StyleDim, 3: StyleItalic, 4: StyleUnderline,
+	5: StyleSlowBlink, 6: StyleRapidBlink, 7: StyleReverse, 8: StyleConceal,
+	9: StyleStrikethrough, 21: StyleUnderline, 53: StyleOverline,
+}
+
+// sgrResets maps SGR codes to the styles they turn off
+var sgrResets = map[int]Style{
+	22: StyleBold | StyleDim, 23: StyleItalic, 24: StyleUnderline,
+	25: StyleSlowBlink | StyleRapidBlink, 27: StyleReverse, 28: StyleConceal,
+	29: StyleStrikethrough, 55: StyleOverline,
+}
+
+// apply applies the parameter string of one SGR sequence
+func (st *sgrState) apply(params string) error {
+	fields := strings.Split(params, ";")
+	for i := 0; i < len(fields); i++ {
+		// Sub-parameters (e.g. "38:2::255:0:0" or "4:3") are separated by ':'
+		sub := strings.Split(fields[i], ":")
+		code, err := sgrNumber(sub[0])
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case code == 0:
+			*st = sgrState{fg: ColorDefault(), bg: ColorDefault()}
+		case code == 4 && len(sub) > 1:
+			// Underline shape; 4:0 turns underlining off
+			if sub[1] == "0" {
+				st.style = st.style.Clear(StyleUnderline)
+			} else {
+				st.style = st.style.Set(StyleUnderline)
+			}
+		case sgrStyles[code] != 0:
+			st.style = st.style.Set(sgrStyles[code])
+		case sgrResets[code] != 0:
+			st.style = st.style.Clear(sgrResets[code])
+		case code >= 30 && code <= 37:
+			st.fg = ColorIndex(uint8(code - 30)) // #nosec G115
+		case code >= 40 && code <= 47:
+			st.bg = ColorIndex(uint8(code - 40)) // #nosec G115
+		case code >= 90 && code <= 97:
+			st.fg = ColorIndex(uint8(code - 90 + 8)) // #nosec G115
+		case code >= 100 && code <= 107:
+			st.bg = ColorIndex(uint8(code - 100 + 8)) // #nosec G115
+		case code == 39:
+			st.fg = ColorDefault()
+		case code == 49:
+			st.bg = ColorDefault()
+		case code == 38 || code == 48:
+			var c Color
+			if len(sub) > 1 {
+				c, err = sgrExtendedColor(sub[1:], true)
+			} else {
+				c, err = sgrExtendedColor(fields[i+1:], false)
+				i += sgrExtendedLen(fields[i+1:])
+			}
+			if err != nil {
+				return err
+			}
+			if code == 38 {
+				st.fg = c
+			} else {
+				st.bg = c
+			}
+		}
+	}
+	return nil
+}
+
+// sgrExtendedLen returns how many ';' separated fields the extended color
+// after a 38 or 48 code occupies
+func sgrExtendedLen(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "5":
+			return 2
+		case "2":
+			return 4
+		}
+	}
+	return 0
+}
+
+// sgrExtendedColor parses the arguments of an extended color: "5;n" for a
+// palette index or "2;r;g;b" for RGB
+// In the ':' separated form the RGB values may be preceded by a color space
+// ID, which is ignored.
+func sgrExtendedColor(args []string, colon bool) (Color, error) {
+	if len(args) == 0 {
+		return Color{}, fmt.Errorf("missing color type")
+	}
+	switch args[0] {
+	case "5":
+		if len(args) < 2 {
+			return Color{}, fmt.Errorf("missing palette index")
+		}
+		n, err := sgrByte(args[1])
+		return ColorIndex(n), err
+	case "2":
+		rgb := args[1:]
+		if colon && len(rgb) >= 4 {
+			rgb = rgb[1:]
+		}
+		if len(rgb) < 3 {
+			return Color{}, fmt.Errorf("incomplete RGB color")
+		}
+		var v [3]uint8
+		for i := range v {
+			n, err := sgrByte(rgb[i])
+			if err != nil {
+				return Color{}, err
+			}
+			v[i] = n
+		}
+		return ColorRGB(v[0], v[1], v[2]), nil
+	}
+	return Color{}, fmt.Errorf("unknown color type %q", args[0])
+}
+
+// sgrNumber parses an SGR parameter; an empty parameter is 0
+func sgrNumber(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid parameter %q", s)
+	}
+	return n, nil
+}
+
+// sgrByte parses an SGR parameter that must fit in a byte
+func sgrByte(s string) (uint8, error) {
+	n, err := sgrNumber(s)
+	if err != nil || n > 255 {
+		return 0, fmt.Errorf("invalid color value %q", s)
+	}
+	return uint8(n), nil // #nosec G115
+}
@@ -0,0 +1,86 @@
+package goterm
+
+import (
+	"container/list"
+	"sync"
+)
+
+// sgrCacheSize is the number of attribute combinations whose escape
+// sequences Show keeps composed
+// Screens typically use a few dozen combinations, so this covers all of them
+// while bounding memory for content with many distinct true colors.
+const sgrCacheSize = 256
+
+// sgrKey identifies a combination of rendering attributes
+type sgrKey struct {
+	fg, bg Color
+	style  Style
+}
+
+// sgrEntry is a cached escape sequence
+type sgrEntry struct {
+	key sgrKey
+	seq []byte
+}
+
+// sgrCache is a least-recently-used cache of the escape sequences that
+// switch the terminal to an attribute combination
+// It has its own lock because Show only holds the screen's read lock.
+type sgrCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[sgrKey]*list.Element
+	order    *list.List // Most recently used first
+}
+
+// newSGRCache creates a cache holding up to capacity sequences
+func newSGRCache(capacity int) *sgrCache {
+	return &sgrCache{
+		capacity: capacity,
+		entries:  make(map[sgrKey]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// get returns the sequence that resets the attributes and selects fg, bg
+// and style, composing and caching it on first use
+// The returned slice must not be modified.
+func (c *sgrCache) get(fg, bg Color, style Style) []byte {
+	key := sgrKey{fg: fg, bg: bg, style: style}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*sgrEntry).seq
+	}
+
+	seq := composeSGR(fg, bg, style)
+	c.entries[key] = c.order.PushFront(&sgrEntry{key: key, seq: seq})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*sgrEntry).key)
+	}
+	return seq
+}
+
+// len returns the number of cached sequences
+func (c *sgrCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// composeSGR builds the sequence that resets the attributes and selects fg,
+// bg and style
+func composeSGR(fg, bg Color, style Style) []byte {
+	seq := []byte("\x1b[0m")
+	if fg.Mode() != ColorModeDefault {
+		seq = append(seq, fg.ansiCode(true)...)
+	}
+	if bg.Mode() != ColorModeDefault {
+		seq = append(seq, bg.ansiCode(false)...)
+	}
+	return append(seq, style.ansiCode()...)
+}
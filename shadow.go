@@ -0,0 +1,56 @@
+package goterm
+
+// shadowLevel is the fraction of an RGB color's brightness kept in a shadow
+const shadowLevel = 0.4
+
+// DrawShadow darkens the cells just below and to the right of rect, giving
+// dialogs and menus drawn in rect visual depth
+// The shadow is two columns wide on the right, to match the roughly 2:1
+// shape of terminal cells, and one row high at the bottom. Characters are
+// kept; RGB colors are blended toward black and cells with other colors are
+// drawn with StyleDim.
+func (s *Screen) DrawShadow(rect Rect) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawShadow(rect)
+}
+
+// DrawShadow darkens the cells just below and to the right of rect
+// See Screen.DrawShadow.
+func (b *Buffer) DrawShadow(rect Rect) {
+	if rect.Empty() {
+		return
+	}
+
+	right := Rect{X: rect.X + rect.W, Y: rect.Y + 1, W: 2, H: rect.H}
+	bottom := Rect{X: rect.X + 2, Y: rect.Y + rect.H, W: rect.W - 2, H: 1}
+	for _, r := range []Rect{right, bottom} {
+		r = r.Intersect(b.clip)
+		for y := r.Y; y < r.Y+r.H; y++ {
+			for x := r.X; x < r.X+r.W; x++ {
+				c := &b.cells[y*b.width+x]
+				*c = shadeCell(*c)
+			}
+		}
+	}
+}
+
+// shadeCell returns the cell as it appears in a shadow
+func shadeCell(c Cell) Cell {
+	if c.Fg.Mode() == ColorModeTrueColor {
+		c.Fg = scaleRGB(c.Fg, shadowLevel)
+	} else {
+		c.Style = c.Style.Set(StyleDim)
+	}
+	if c.Bg.Mode() == ColorModeTrueColor {
+		c.Bg = scaleRGB(c.Bg, shadowLevel)
+	}
+	return c
+}
+
+// scaleRGB multiplies each channel of an RGB color by f, blending it toward
+// black for f < 1
+func scaleRGB(c Color, f float64) Color {
+	r, g, b := c.RGB()
+	return ColorRGB(uint8(float64(r)*f), uint8(float64(g)*f), uint8(float64(b)*f))
+}
@@ -0,0 +1,183 @@
+package goterm
+
+// DrawLine draws a straight line of cell from (x1, y1) to (x2, y2), both ends
+// included, using Bresenham's algorithm
+func (s *Screen) DrawLine(x1, y1, x2, y2 int, cell Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawLine(x1, y1, x2, y2, cell)
+}
+
+// DrawSlopedLine draws a line from (x1, y1) to (x2, y2) like DrawLine, picking
+// '-', '|', '\' or '/' for each cell according to the direction of the line
+// at that point
+func (s *Screen) DrawSlopedLine(x1, y1, x2, y2 int, fg, bg Color, style Style) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawSlopedLine(x1, y1, x2, y2, fg, bg, style)
+}
+
+// DrawLine draws a straight line of cell from (x1, y1) to (x2, y2)
+// See Screen.DrawLine.
+func (b *Buffer) DrawLine(x1, y1, x2, y2 int, cell Cell) {
+	for _, p := range linePoints(x1, y1, x2, y2) {
+		b.SetCell(p[0], p[1], cell)
+	}
+}
+
+// DrawSlopedLine draws a line from (x1, y1) to (x2, y2) with slope characters
+// See Screen.DrawSlopedLine.
+func (b *Buffer) DrawSlopedLine(x1, y1, x2, y2 int, fg, bg Color, style Style) {
+	points := linePoints(x1, y1, x2, y2)
+	for i, p := range points {
+		// Use the step into the next point, or out of the previous one at
+		// the end of the line
+		var dx, dy int
+		switch {
+		case i+1 < len(points):
+			dx, dy = points[i+1][0]-p[0], points[i+1][1]-p[1]
+		case i > 0:
+			dx, dy = p[0]-points[i-1][0], p[1]-points[i-1][1]
+		}
+		b.SetCell(p[0], p[1], NewCell(slopeRune(dx, dy), fg, bg, style))
+	}
+}
+
+// slopeRune returns the character drawn for a step of (dx, dy)
+// Rows grow downward, so a step right and down is drawn as '\'.
+func slopeRune(dx, dy int) rune {
+	switch {
+	case dy == 0:
+		return '-'
+	case dx == 0:
+		return '|'
+	case (dx > 0) == (dy > 0):
+		return '\\'
+	}
+	return '/'
+}
+
+// linePoints returns the cells of the line from (x1, y1) to (x2, y2) in order
+func linePoints(x1, y1, x2, y2 int) [][2]int {
+	dx, dy := abs(x2-x1), -abs(y2-y1)
+	sx, sy := 1, 1
+	if x1 > x2 {
+		sx = -1
+	}
+	if y1 > y2 {
+		sy = -1
+	}
+
+	points := make([][2]int, 0, max(dx, -dy)+1)
+	err := dx + dy
+	for {
+		points = append(points, [2]int{x1, y1})
+		if x1 == x2 && y1 == y2 {
+			return points
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// cellAspect is the approximate height-to-width ratio of a terminal cell
+const cellAspect = 2
+
+// DrawEllipse draws the outline of an ellipse centered at (cx, cy) with
+// horizontal radius rx and vertical radius ry in cells
+func (s *Screen) DrawEllipse(cx, cy, rx, ry int, cell Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawEllipse(cx, cy, rx, ry, cell)
+}
+
+// FillEllipse draws a filled ellipse centered at (cx, cy) with horizontal
+// radius rx and vertical radius ry in cells
+func (s *Screen) FillEllipse(cx, cy, rx, ry int, cell Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.FillEllipse(cx, cy, rx, ry, cell)
+}
+
+// DrawCircle draws the outline of a circle of radius r rows centered at
+// (cx, cy)
+// The horizontal radius is doubled so the circle looks round on terminals
+// whose cells are about twice as tall as they are wide.
+func (s *Screen) DrawCircle(cx, cy, r int, cell Cell) {
+	s.DrawEllipse(cx, cy, r*cellAspect, r, cell)
+}
+
+// FillCircle draws a filled circle of radius r rows centered at (cx, cy),
+// corrected for the cell aspect ratio like DrawCircle
+func (s *Screen) FillCircle(cx, cy, r int, cell Cell) {
+	s.FillEllipse(cx, cy, r*cellAspect, r, cell)
+}
+
+// DrawEllipse draws the outline of an ellipse
+// See Screen.DrawEllipse.
+func (b *Buffer) DrawEllipse(cx, cy, rx, ry int, cell Cell) {
+	b.plotEllipse(cx, cy, rx, ry, cell, true)
+}
+
+// FillEllipse draws a filled ellipse
+// See Screen.FillEllipse.
+func (b *Buffer) FillEllipse(cx, cy, rx, ry int, cell Cell) {
+	b.plotEllipse(cx, cy, rx, ry, cell, false)
+}
+
+// DrawCircle draws the outline of a circle corrected for the cell aspect ratio
+// See Screen.DrawCircle.
+func (b *Buffer) DrawCircle(cx, cy, r int, cell Cell) {
+	b.DrawEllipse(cx, cy, r*cellAspect, r, cell)
+}
+
+// FillCircle draws a filled circle corrected for the cell aspect ratio
+// See Screen.FillCircle.
+func (b *Buffer) FillCircle(cx, cy, r int, cell Cell) {
+	b.FillEllipse(cx, cy, r*cellAspect, r, cell)
+}
+
+// plotEllipse sets the cells inside an ellipse, or only those on its edge
+// when outline is true
+// A cell is on the edge when it is inside but one of its four neighbors is
+// not, which keeps the outline connected at every size.
+func (b *Buffer) plotEllipse(cx, cy, rx, ry int, cell Cell, outline bool) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+
+	// Measuring against radii half a cell larger includes the extreme
+	// cells and keeps degenerate ellipses one cell thick
+	fx, fy := float64(rx)+0.5, float64(ry)+0.5
+	inside := func(dx, dy int) bool {
+		nx, ny := float64(dx)/fx, float64(dy)/fy
+		return nx*nx+ny*ny <= 1
+	}
+
+	for dy := -ry; dy <= ry; dy++ {
+		for dx := -rx; dx <= rx; dx++ {
+			if !inside(dx, dy) {
+				continue
+			}
+			if outline && inside(dx-1, dy) && inside(dx+1, dy) && inside(dx, dy-1) && inside(dx, dy+1) {
+				continue
+			}
+			b.SetCell(cx+dx, cy+dy, cell)
+		}
+	}
+}
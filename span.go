@@ -0,0 +1,66 @@
+package goterm
+
+import "strings"
+
+// Span is a segment of text drawn with its own colors and style
+type Span struct {
+	Text  string
+	Fg    Color
+	Bg    Color
+	Style Style
+}
+
+// StyledText is a sequence of spans forming one logical line of mixed
+// colors and styles
+// Build it by appending segments:
+//
+//	line := goterm.StyledText{}.
+//		Add("Score: ", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone).
+//		Add("1200", goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleBold)
+type StyledText []Span
+
+// Add returns the text with a new span appended
+func (t StyledText) Add(text string, fg, bg Color, style Style) StyledText {
+	return append(t, Span{Text: text, Fg: fg, Bg: bg, Style: style})
+}
+
+// String returns the plain text of all spans
+func (t StyledText) String() string {
+	var sb strings.Builder
+	for _, span := range t {
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
+
+// Width returns the display width of the text in columns
+func (t StyledText) Width() int {
+	return StringWidth(t.String())
+}
+
+// DrawSpans draws the spans one after another starting at (x, y) and returns
+// the column after the last one
+// Text is laid out as by DrawText, with tab stops counted from x and '\n'
+// continuing on the next row at column x. Bidi reordering is not applied.
+func (s *Screen) DrawSpans(x, y int, spans []Span) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.DrawSpans(x, y, spans)
+}
+
+// DrawSpans draws the spans one after another starting at (x, y) and returns
+// the column after the last one
+// See Screen.DrawSpans.
+func (b *Buffer) DrawSpans(x, y int, spans []Span) int {
+	col := x
+	for _, span := range spans {
+		for i, line := range strings.Split(span.Text, "\n") {
+			if i > 0 {
+				y++
+				col = x
+			}
+			col = b.drawRun(x, col, y, strings.TrimSuffix(line, "\r"), span.Fg, span.Bg, span.Style)
+		}
+	}
+	return col
+}
@@ -0,0 +1,133 @@
+package goterm
+
+import "strings"
+
+// Sprite is a small multi-cell graphic for games and animations
+// A sprite is a Buffer whose transparent cells form its mask: they are
+// skipped when the sprite is drawn, so it can have any shape.
+type Sprite struct {
+	*Buffer
+}
+
+// NewSprite creates a sprite of the given dimensions with all cells
+// transparent
+// Panics if width or height are <= 0
+func NewSprite(width, height int) *Sprite {
+	b := NewBuffer(width, height)
+	b.blank = CellTransparent()
+	b.Clear()
+	return &Sprite{Buffer: b}
+}
+
+// SpriteFromText creates a sprite from ASCII art, one row per line
+// Spaces are transparent; every other character is drawn in fg over bg. The
+// sprite is as wide as the widest line.
+func SpriteFromText(art string, fg, bg Color) *Sprite {
+	lines := strings.Split(art, "\n")
+	width := 1
+	for _, line := range lines {
+		width = max(width, StringWidth(line))
+	}
+
+	sp := NewSprite(width, len(lines))
+	for y, line := range lines {
+		sp.DrawText(0, y, line, fg, bg, StyleNone)
+		for x := 0; x < width; x++ {
+			// Keep the blank second half of wide characters opaque
+			if c := sp.cells[y*width+x]; c.Ch == ' ' && (x == 0 || sp.cells[y*width+x-1].width() != 2) {
+				sp.cells[y*width+x] = CellTransparent()
+			}
+		}
+	}
+	return sp
+}
+
+// SpriteOptions controls how DrawSprite stamps a sprite
+// The zero value draws the sprite as it is.
+type SpriteOptions struct {
+	FlipH   bool  // Mirror left to right
+	FlipV   bool  // Mirror top to bottom
+	Recolor bool  // Draw every opaque cell with Fg instead of its own color
+	Fg      Color // Foreground used when Recolor is set
+}
+
+// Mirror images of characters for flipped sprites
+var (
+	mirrorH = pairMap("/\\", "()", "<>", "[]", "{}", "┌┐", "└┘", "├┤", "╭╮", "╰╯",
+		"┏┓", "┗┛", "┣┫", "╔╗", "╚╝", "╠╣", "▌▐", "◀▶", "◢◣", "◤◥", "«»")
+	mirrorV = pairMap("/\\", "┌└", "┐┘", "┬┴", "╭╰", "╮╯", "┏┗", "┓┛", "┳┻",
+		"╔╚", "╗╝", "╦╩", "▀▄", "▲▼", "◢◥", "◣◤")
+)
+
+// pairMap builds a symmetric rune mapping from two-rune strings
+func pairMap(pairs ...string) map[rune]rune {
+	m := make(map[rune]rune, 2*len(pairs))
+	for _, p := range pairs {
+		r := []rune(p)
+		m[r[0]], m[r[1]] = r[1], r[0]
+	}
+	return m
+}
+
+// DrawSprite draws the sprite with its top-left corner at (x, y)
+// Transparent cells let the content underneath show through. Flipped sprites
+// also mirror directional characters such as slashes, brackets and box
+// corners, and keep wide characters intact.
+func (s *Screen) DrawSprite(x, y int, sp *Sprite, opts SpriteOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.DrawSprite(x, y, sp, opts)
+}
+
+// DrawSprite draws the sprite with its top-left corner at (x, y)
+// See Screen.DrawSprite.
+func (b *Buffer) DrawSprite(x, y int, sp *Sprite, opts SpriteOptions) {
+	src := sp.Buffer
+	if !opts.FlipH && !opts.FlipV && !opts.Recolor {
+		b.Blit(x, y, src, src.Bounds())
+		return
+	}
+
+	out := NewSprite(src.width, src.height).Buffer
+	for sy := 0; sy < src.height; sy++ {
+		dy := sy
+		if opts.FlipV {
+			dy = src.height - 1 - sy
+		}
+		for sx := 0; sx < src.width; sx++ {
+			c := src.cells[sy*src.width+sx]
+			if sx > 0 && src.cells[sy*src.width+sx-1].width() == 2 {
+				continue // Moved together with its wide character
+			}
+
+			span := 1
+			if c.width() == 2 && sx+1 < src.width {
+				span = 2
+			}
+			dx := sx
+			if opts.FlipH {
+				dx = src.width - sx - span
+			}
+
+			for i := 0; i < span; i++ {
+				cell := src.cells[sy*src.width+sx+i]
+				if cell.Transparent() {
+					continue
+				}
+				if cell.Comb == "" {
+					if m, ok := mirrorH[cell.Ch]; ok && opts.FlipH {
+						cell.Ch = m
+					}
+					if m, ok := mirrorV[cell.Ch]; ok && opts.FlipV {
+						cell.Ch = m
+					}
+				}
+				if opts.Recolor {
+					cell.Fg = opts.Fg
+				}
+				out.cells[dy*src.width+dx+i] = cell
+			}
+		}
+	}
+	b.Blit(x, y, out, out.Bounds())
+}
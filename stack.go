@@ -0,0 +1,147 @@
+package goterm
+
+import (
+	"slices"
+	"sync"
+)
+
+// Stack draws its children over the same region of the screen, each on a
+// layer of its own above the one before
+// Because each child has a layer, a popover, dropdown or context menu
+// pushed onto the stack covers only the cells it draws, and popping it
+// reveals what is underneath without redrawing. The children occupy the
+// z-indexes from the stack's base upwards.
+//
+// A Stack is a Drawable, so it can be placed by a layout like any widget:
+// drawing it onto a view of the screen draws every child onto its layer in
+// the region of that view.
+type Stack struct {
+	screen *Screen
+	z      int
+	mu     sync.Mutex
+	items  []stackItem // Bottom first
+	rect   Rect        // Region the children were last drawn in
+}
+
+// stackItem is a child of a Stack and the layer it is drawn on
+type stackItem struct {
+	widget Drawable
+	layer  *Layer
+}
+
+// NewStack creates an empty stack on screen whose bottom child is drawn on
+// a layer at z-index z
+func NewStack(screen *Screen, z int) *Stack {
+	return &Stack{screen: screen, z: z}
+}
+
+// Push adds widget on top of the stack and returns the layer it is drawn
+// on
+// The widget is drawn at once in the region the stack was last drawn in.
+func (st *Stack) Push(widget Drawable) *Layer {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	item := stackItem{widget: widget, layer: st.screen.AddLayer(st.z + len(st.items))}
+	st.items = append(st.items, item)
+	st.drawItem(item)
+	return item.layer
+}
+
+// Pop removes the top child and its layer, and returns the child, or nil
+// if the stack is empty
+func (st *Stack) Pop() Drawable {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	n := len(st.items)
+	if n == 0 {
+		return nil
+	}
+	item := st.items[n-1]
+	st.items = st.items[:n-1]
+	st.screen.RemoveLayer(item.layer)
+	return item.widget
+}
+
+// Remove takes widget and its layer out of the stack, moving the children
+// above it down, and reports whether it was in the stack
+func (st *Stack) Remove(widget Drawable) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	i := slices.IndexFunc(st.items, func(item stackItem) bool { return item.widget == widget })
+	if i < 0 {
+		return false
+	}
+	st.screen.RemoveLayer(st.items[i].layer)
+	st.items = slices.Delete(st.items, i, i+1)
+	for j := i; j < len(st.items); j++ {
+		st.items[j].layer.SetZ(st.z + j)
+	}
+	return true
+}
+
+// Top returns the top child, or nil if the stack is empty
+func (st *Stack) Top() Drawable {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.items) == 0 {
+		return nil
+	}
+	return st.items[len(st.items)-1].widget
+}
+
+// Len returns the number of children
+func (st *Stack) Len() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return len(st.items)
+}
+
+// Layer returns the layer widget is drawn on, or nil if it is not in the
+// stack
+// Hiding the layer hides the child without removing it.
+func (st *Stack) Layer(widget Drawable) *Layer {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for _, item := range st.items {
+		if item.widget == widget {
+			return item.layer
+		}
+	}
+	return nil
+}
+
+// Rect returns the region of the screen the children were last drawn in
+func (st *Stack) Rect() Rect {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.rect
+}
+
+// Draw draws every child onto its layer in the region of s, which must be
+// a view of the stack's screen
+// Drawn onto any other surface, the children are drawn onto it directly,
+// bottom first.
+func (st *Stack) Draw(s Surface) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	v, ok := s.(*View)
+	if !ok || v.buf != &st.screen.buf {
+		for _, item := range st.items {
+			item.widget.Draw(s)
+		}
+		return
+	}
+	st.rect = v.Rect()
+	for _, item := range st.items {
+		st.drawItem(item)
+	}
+}
+
+// drawItem clears the layer of item and draws the child onto it
+// Must be called with the stack lock held.
+func (st *Stack) drawItem(item stackItem) {
+	item.layer.Clear()
+	if !st.rect.Empty() {
+		item.widget.Draw(item.layer.SubView(st.rect.X, st.rect.Y, st.rect.W, st.rect.H))
+	}
+}
@@ -1,5 +1,11 @@
 package goterm
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // Style represents text styling attributes as a bitmask
 type Style uint16
 
@@ -15,6 +21,11 @@ const (
 	StyleReverse       Style = 1 << 6 // Swap foreground/background colors
 	StyleConceal       Style = 1 << 7 // Hidden text
 	StyleStrikethrough Style = 1 << 8 // Crossed-out text
+	StyleOverline      Style = 1 << 9 // Line above the text
+
+	// StyleAll combines every style flag
+	StyleAll = StyleBold | StyleDim | StyleItalic | StyleUnderline | StyleSlowBlink |
+		StyleRapidBlink | StyleReverse | StyleConceal | StyleStrikethrough | StyleOverline
 )
 
 // Has checks if a style flag is set
@@ -37,6 +48,109 @@ func (s Style) Toggle(flag Style) Style {
 	return s ^ flag
 }
 
+// styleName pairs a style flag with its name
+type styleName struct {
+	style Style
+	name  string
+}
+
+// styleNames lists the style flags in bit order with their names
+var styleNames = []styleName{
+	{StyleBold, "bold"},
+	{StyleDim, "dim"},
+	{StyleItalic, "italic"},
+	{StyleUnderline, "underline"},
+	{StyleSlowBlink, "slowblink"},
+	{StyleRapidBlink, "rapidblink"},
+	{StyleReverse, "reverse"},
+	{StyleConceal, "conceal"},
+	{StyleStrikethrough, "strikethrough"},
+	{StyleOverline, "overline"},
+}
+
+// String returns the names of the set flags joined by '|', e.g.
+// "bold|italic|underline", or "none"
+// Unknown bits are shown in hexadecimal.
+func (s Style) String() string {
+	if s == StyleNone {
+		return "none"
+	}
+
+	var names []string
+	for _, n := range styleNames {
+		if s.Has(n.style) {
+			names = append(names, n.name)
+			s = s.Clear(n.style)
+		}
+	}
+	if s != StyleNone {
+		names = append(names, fmt.Sprintf("0x%x", uint16(s)))
+	}
+	return strings.Join(names, "|")
+}
+
+// styleAliases are alternative names accepted by ParseStyle
+var styleAliases = map[string]Style{
+	"none":    StyleNone,
+	"faint":   StyleDim,
+	"blink":   StyleSlowBlink,
+	"inverse": StyleReverse,
+	"hidden":  StyleConceal,
+	"strike":  StyleStrikethrough,
+}
+
+// ParseStyle parses a list of style names such as "bold,underline,italic"
+// Names are those produced by Style.String plus the aliases "none",
+// "faint", "blink", "inverse", "hidden" and "strike", matched
+// case-insensitively and separated by commas, '|', '+' or spaces. Returns
+// an error wrapping ErrInvalidStyle for unknown names.
+func ParseStyle(s string) (Style, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '|' || r == '+' || r == ' ' || r == '\t'
+	})
+
+	style := StyleNone
+	for _, field := range fields {
+		name := strings.ToLower(field)
+		if flag, ok := styleAliases[name]; ok {
+			style |= flag
+			continue
+		}
+		i := slices.IndexFunc(styleNames, func(n styleName) bool { return n.name == name })
+		if i < 0 {
+			return StyleNone, fmt.Errorf("%w: unknown style %q", ErrInvalidStyle, field)
+		}
+		style |= styleNames[i].style
+	}
+	return style, nil
+}
+
+// styleFallbacks lists the substitutes for styles a terminal cannot render
+var styleFallbacks = []struct {
+	style, fallback Style
+}{
+	{StyleItalic, StyleUnderline},
+	{StyleSlowBlink, StyleBold},
+	{StyleRapidBlink, StyleBold},
+	{StyleOverline, StyleUnderline},
+}
+
+// filter returns the style restricted to the supported flags
+// Unsupported flags are replaced by their fallback, if that is supported,
+// and dropped otherwise.
+func (s Style) filter(supported Style) Style {
+	if s&^supported == 0 {
+		return s
+	}
+	out := s & supported
+	for _, f := range styleFallbacks {
+		if s.Has(f.style) && !supported.Has(f.style) {
+			out |= f.fallback & supported
+		}
+	}
+	return out
+}
+
 // ansiCode returns the ANSI escape codes for this style
 func (s Style) ansiCode() string {
 	if s == StyleNone {
@@ -71,6 +185,9 @@ func (s Style) ansiCode() string {
 	if s.Has(StyleStrikethrough) {
 		codes += "\x1b[9m"
 	}
+	if s.Has(StyleOverline) {
+		codes += "\x1b[53m"
+	}
 
 	return codes
 }
@@ -0,0 +1,56 @@
+package goterm
+
+// SetTag attaches tag to every cell in rect, keeping the cells' content
+// Tags are opaque IDs chosen by the application, such as a widget or
+// hyperlink ID. They are not rendered, travel with cells through Blit,
+// sprites and layers, and are reported by TagAt, so a mouse click can be
+// mapped to what was drawn there. Drawing over a cell replaces its tag; tag 0
+// means untagged. The region is clipped to the screen bounds.
+func (s *Screen) SetTag(rect Rect, tag int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.SetTag(rect, tag)
+}
+
+// TagAt returns the tag of the cell shown at (x, y), taking visible layers
+// into account, or 0 if the cell is untagged or out of bounds
+// A translucent blank on a layer keeps the tag of the cell it tints unless it
+// has a tag of its own.
+func (s *Screen) TagAt(x, y int) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if x < 0 || y < 0 || x >= s.buf.width || y >= s.buf.height {
+		return 0
+	}
+	return s.composite()[y*s.buf.width+x].Tag
+}
+
+// SetTag attaches tag to every cell in rect
+// See Screen.SetTag.
+func (b *Buffer) SetTag(rect Rect, tag int) {
+	b.ForEach(rect, func(_, _ int, c Cell) Cell {
+		c.Tag = tag
+		return c
+	})
+}
+
+// TagAt returns the tag of the cell at (x, y), or 0 if out of bounds
+func (b *Buffer) TagAt(x, y int) int {
+	return b.GetCell(x, y).Tag
+}
+
+// SetTag attaches tag to every cell in rect, given in view coordinates
+// See Screen.SetTag.
+func (v *View) SetTag(rect Rect, tag int) {
+	rect.X += v.origin.X
+	rect.Y += v.origin.Y
+	v.draw(func(b *Buffer) { b.SetTag(rect, tag) })
+}
+
+// TagAt returns the tag of the cell at the specified view position, or 0 if
+// it is outside the view
+// On a layer this reports the layer's own cell; use Screen.TagAt for what is
+// displayed.
+func (v *View) TagAt(x, y int) int {
+	return v.GetCell(x, y).Tag
+}
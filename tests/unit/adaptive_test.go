@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestAdaptiveColor(t *testing.T) {
+	a := goterm.AdaptiveColor{Light: goterm.ColorBlack, Dark: goterm.ColorWhite}
+
+	screen := goterm.NewScreen(2, 2)
+	if !screen.DarkBackground() {
+		t.Error("NewScreen should assume a dark background")
+	}
+	if got := screen.Resolve(a); got != goterm.ColorWhite {
+		t.Errorf("Resolve() on dark = %v, want white", got)
+	}
+
+	screen.SetDarkBackground(false)
+	if got := screen.Resolve(a); got != goterm.ColorBlack {
+		t.Errorf("Resolve() on light = %v, want black", got)
+	}
+}
+
+func TestDetectDarkBackground(t *testing.T) {
+	tests := []struct {
+		value string
+		dark  bool
+	}{
+		{"", true},
+		{"15;0", true},
+		{"0;15", false},
+		{"0;7", false},
+		{"7;8", true},
+		{"0;default;11", false},
+		{"12;4", true},
+		{"garbage", true},
+		{"0;99", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("COLORFGBG", tt.value)
+			if got := goterm.DetectDarkBackground(); got != tt.dark {
+				t.Errorf("DetectDarkBackground() with COLORFGBG=%q = %v, want %v", tt.value, got, tt.dark)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestColorHSLRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   goterm.Color
+		h, s, l float64
+	}{
+		{"red", goterm.ColorRGB(255, 0, 0), 0, 1, 0.5},
+		{"green", goterm.ColorRGB(0, 255, 0), 120, 1, 0.5},
+		{"blue", goterm.ColorRGB(0, 0, 255), 240, 1, 0.5},
+		{"magenta", goterm.ColorRGB(255, 0, 255), 300, 1, 0.5},
+		{"gray", goterm.ColorRGB(128, 128, 128), 0, 0, 0.502},
+		{"palette", goterm.ColorIndex(21), 240, 1, 0.5},
+		{"default", goterm.ColorDefault(), 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, s, l := tt.color.HSL()
+			if math.Abs(h-tt.h) > 0.01 || math.Abs(s-tt.s) > 0.01 || math.Abs(l-tt.l) > 0.01 {
+				t.Errorf("HSL() = (%.2f, %.2f, %.3f), want (%v, %v, %v)", h, s, l, tt.h, tt.s, tt.l)
+			}
+		})
+	}
+
+	orchid := goterm.ColorRGB(218, 112, 214)
+	if got := goterm.ColorHSL(orchid.HSL()); got != orchid {
+		t.Errorf("ColorHSL(HSL()) = %v, want %v", got, orchid)
+	}
+}
+
+func TestColorAdjust(t *testing.T) {
+	red := goterm.ColorRGB(255, 0, 0)
+
+	tests := []struct {
+		name string
+		got  goterm.Color
+		want goterm.Color
+	}{
+		{"lighten", red.Lighten(0.25), goterm.ColorRGB(255, 128, 128)},
+		{"lighten_clamped", red.Lighten(2), goterm.ColorRGB(255, 255, 255)},
+		{"darken", red.Darken(0.25), goterm.ColorRGB(128, 0, 0)},
+		{"darken_clamped", red.Darken(2), goterm.ColorRGB(0, 0, 0)},
+		{"desaturate", red.Saturate(-0.5), goterm.ColorRGB(191, 64, 64)},
+		{"desaturate_fully", red.Saturate(-1), goterm.ColorRGB(128, 128, 128)},
+		{"saturate", goterm.ColorRGB(191, 64, 64).Saturate(0.5), red},
+		{"palette", goterm.ColorIndex(196).Darken(0.25), goterm.ColorRGB(128, 0, 0)},
+		{"default_lighten", goterm.ColorDefault().Lighten(0.5), goterm.ColorDefault()},
+		{"grayscale_white", goterm.ColorRGB(255, 255, 255).Grayscale(), goterm.ColorRGB(255, 255, 255)},
+		{"grayscale_green", goterm.ColorRGB(0, 255, 0).Grayscale(), goterm.ColorRGB(220, 220, 220)},
+		{"grayscale_blue", goterm.ColorRGB(0, 0, 255).Grayscale(), goterm.ColorRGB(76, 76, 76)},
+		{"grayscale_default", goterm.ColorDefault().Grayscale(), goterm.ColorDefault()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorHSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   goterm.Color
+		h, s, v float64
+	}{
+		{"red", goterm.ColorRGB(255, 0, 0), 0, 1, 1},
+		{"dark_green", goterm.ColorRGB(0, 128, 0), 120, 1, 0.502},
+		{"pale_blue", goterm.ColorRGB(128, 128, 255), 240, 0.498, 1},
+		{"gray", goterm.ColorRGB(128, 128, 128), 0, 0, 0.502},
+		{"black", goterm.ColorRGB(0, 0, 0), 0, 0, 0},
+		{"palette", goterm.ColorIndex(21), 240, 1, 1},
+		{"default", goterm.ColorDefault(), 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, s, v := tt.color.HSV()
+			if math.Abs(h-tt.h) > 0.01 || math.Abs(s-tt.s) > 0.01 || math.Abs(v-tt.v) > 0.01 {
+				t.Errorf("HSV() = (%.2f, %.3f, %.3f), want (%v, %v, %v)", h, s, v, tt.h, tt.s, tt.v)
+			}
+		})
+	}
+
+	orchid := goterm.ColorRGB(218, 112, 214)
+	if got := goterm.ColorHSV(orchid.HSV()); got != orchid {
+		t.Errorf("ColorHSV(HSV()) = %v, want %v", got, orchid)
+	}
+}
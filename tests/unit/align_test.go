@@ -0,0 +1,77 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestDrawTextAligned(t *testing.T) {
+	rect := goterm.Rect{X: 1, Y: 1, W: 6, H: 3}
+
+	tests := []struct {
+		name   string
+		text   string
+		halign goterm.HAlign
+		valign goterm.VAlign
+		want   []string
+	}{
+		{"top_left", "ab", goterm.AlignLeft, goterm.AlignTop, []string{"........", ".ab    .", ".      .", ".      .", "........"}},
+		{"center_middle", "ab", goterm.AlignCenter, goterm.AlignMiddle, []string{"........", ".      .", ".  ab  .", ".      .", "........"}},
+		{"right_bottom", "ab", goterm.AlignRight, goterm.AlignBottom, []string{"........", ".      .", ".      .", ".    ab.", "........"}},
+		{"wrapped_center", "one two", goterm.AlignCenter, goterm.AlignTop, []string{"........", ". one  .", ". two  .", ".      .", "........"}},
+		{"blank_lines", "a\n\nb", goterm.AlignRight, goterm.AlignTop, []string{"........", ".     a.", ".      .", ".     b.", "........"}},
+		{"clipped_bottom", "1\n2\n3\n4", goterm.AlignLeft, goterm.AlignBottom, []string{"........", ".2     .", ".3     .", ".4     .", "........"}},
+		{"wide_center", "世界", goterm.AlignCenter, goterm.AlignTop, []string{"........", ". 世 界  .", ".      .", ".      .", "........"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(8, 5)
+			screen.Fill(0, 0, 8, 5, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			screen.Fill(rect.X, rect.Y, rect.W, rect.H, goterm.NewCell(' ', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			screen.DrawTextAligned(rect, tt.text, tt.halign, tt.valign, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+			for y, want := range tt.want {
+				if got := rowText(screen, 0, y, 8); got != want {
+					t.Errorf("row %d = %q, want %q", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestViewDrawTextAligned(t *testing.T) {
+	screen := goterm.NewScreen(10, 1)
+	view := screen.SubView(2, 0, 6, 1)
+	view.DrawTextAligned(goterm.Rect{W: 6, H: 1}, "hi", goterm.AlignRight, goterm.AlignTop, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	if got := rowText(screen, 0, 0, 10); got != "      hi  " {
+		t.Errorf("row = %q, want %q", got, "      hi  ")
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	screen := goterm.Rect{X: 0, Y: 0, W: 80, H: 24}
+	tests := []struct {
+		name string
+		got  goterm.Rect
+		want goterm.Rect
+	}{
+		{"top_left", goterm.AlignTopLeft(screen, 10, 1, 0), goterm.Rect{X: 0, Y: 0, W: 10, H: 1}},
+		{"top_right", goterm.AlignTopRight(screen, 8, 1, 1), goterm.Rect{X: 71, Y: 1, W: 8, H: 1}},
+		{"bottom_left", goterm.AlignBottomLeft(screen, 5, 2, 2), goterm.Rect{X: 2, Y: 20, W: 5, H: 2}},
+		{"bottom_right", goterm.AlignBottomRight(screen, 6, 3, 0), goterm.Rect{X: 74, Y: 21, W: 6, H: 3}},
+		{"centered", goterm.Centered(screen, 21, 5), goterm.Rect{X: 29, Y: 9, W: 21, H: 5}},
+		{"offset_rect", goterm.AlignTopRight(goterm.Rect{X: 10, Y: 5, W: 20, H: 10}, 4, 1, 1), goterm.Rect{X: 25, Y: 6, W: 4, H: 1}},
+		{"shrunk", goterm.AlignBottomRight(goterm.Rect{W: 6, H: 3}, 10, 5, 1), goterm.Rect{X: 1, Y: 1, W: 4, H: 1}},
+		{"middle_right", goterm.Anchor(screen, 4, 2, goterm.AlignRight, goterm.AlignMiddle, 1), goterm.Rect{X: 75, Y: 11, W: 4, H: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
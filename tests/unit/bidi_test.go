@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// cellGetter is implemented by both Screen and Buffer
+type cellGetter interface {
+	GetCell(x, y int) goterm.Cell
+}
+
+// rowText reads n cells of row y starting at x, skipping wide-character padding
+func rowText(screen cellGetter, x, y, n int) string {
+	var out []rune
+	for i := x; i < x+n; i++ {
+		cell := screen.GetCell(i, y)
+		out = append(out, cell.Ch)
+		out = append(out, []rune(cell.Comb)...)
+	}
+	return string(out)
+}
+
+func TestDrawTextBidi(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"ltr_unchanged", "hello world", "hello world"},
+		{"hebrew", "שלום", "םולש"},
+		{"hebrew_in_english", "say שלום now", "say םולש now"},
+		{"english_in_hebrew", "שלום abc", "abc םולש"},
+		{"numbers_in_hebrew", "שלום 123", "123 םולש"},
+		{"mirrored_brackets", "(שלום)", "(םולש)"},
+		{"arabic", "مرحبا", "ابحرم"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(30, 1)
+			screen.SetBidi(true)
+			screen.DrawText(0, 0, tt.text, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+			if got := rowText(screen, 0, 0, goterm.StringWidth(tt.text)); got != tt.want {
+				t.Errorf("DrawText(%q) with bidi = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrawTextBidiDisabled(t *testing.T) {
+	screen := goterm.NewScreen(10, 1)
+	screen.DrawText(0, 0, "שלום", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	if got := rowText(screen, 0, 0, 4); got != "שלום" {
+		t.Errorf("DrawText() without bidi = %q, want logical order", got)
+	}
+}
@@ -0,0 +1,136 @@
+package unit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestBigFontRender(t *testing.T) {
+	tests := []struct {
+		name string
+		font *widgets.BigFont
+		text string
+		want []string
+	}{
+		{"block", widgets.FontBlock, "Hi", []string{
+			"█   █ ███",
+			"█   █  █ ",
+			"█████  █ ",
+			"█   █  █ ",
+			"█   █ ███",
+		}},
+		{"compact", widgets.FontCompact, "1:", []string{
+			"▄█  ▄",
+			" █  ▄",
+			"▀▀▀  ",
+		}},
+		{"lines", widgets.FontCompact, "-\n.", []string{
+			"    ",
+			"▀▀▀▀",
+			"    ",
+			"",
+			" ",
+			" ",
+			"▀",
+		}},
+		{"unknown_skipped", widgets.FontBlock, "~I", []string{"███", " █ ", " █ ", " █ ", "███"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.font.Render(tt.text)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.text, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+
+	if w, h := widgets.FontBlock.Size("12:30"); w != 3+1+5+1+1+1+5+1+5 || h != 5 {
+		t.Errorf("Size() = %d×%d", w, h)
+	}
+}
+
+func TestBigTextDraw(t *testing.T) {
+	gradient := goterm.NewGradient(goterm.ColorRGB(255, 0, 0), goterm.ColorRGB(0, 0, 255))
+	text := widgets.BigText{Text: "I", Font: widgets.FontCompact, Align: goterm.AlignCenter, Gradient: &gradient}
+	buf := goterm.NewBuffer(7, 3)
+	text.Draw(buf)
+
+	want := []string{"  ▀█▀  ", "   █   ", "  ▀▀▀  "}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 7); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+	if got := buf.GetCell(2, 0).Fg; got != goterm.ColorRGB(255, 0, 0) {
+		t.Errorf("left fg = %v, want gradient start", got)
+	}
+	if got := buf.GetCell(4, 2).Fg; got != goterm.ColorRGB(0, 0, 255) {
+		t.Errorf("right fg = %v, want gradient end", got)
+	}
+
+	text = widgets.BigText{Text: "I", Font: widgets.FontCompact, Align: goterm.AlignRight, Fg: goterm.ColorGreen}
+	text.Draw(buf)
+	if got := rowText(buf, 0, 2, 7); got != "    ▀▀▀" {
+		t.Errorf("right aligned = %q", got)
+	}
+	if got := buf.GetCell(6, 2).Fg; got != goterm.ColorGreen {
+		t.Errorf("fg = %v, want green", got)
+	}
+}
+
+// testFIGletFont is a FIGlet font 2 rows tall with '$' hard blanks, a
+// comment, and glyphs only for '!' and code-tagged 'π'
+func testFIGletFont() string {
+	var sb strings.Builder
+	sb.WriteString("flf2a$ 2 1 4 0 1\nA test font\n")
+	for r := ' '; r <= '~'; r++ {
+		switch r {
+		case '!':
+			sb.WriteString("|$@\n.$@@\n")
+		case 'A':
+			sb.WriteString("/\\@\n/\\@@\n")
+		default:
+			sb.WriteString("@\n@@\n")
+		}
+	}
+	sb.WriteString("0x3C0  GREEK SMALL LETTER PI\n__##\n||##\n")
+	return sb.String()
+}
+
+func TestParseFIGletFont(t *testing.T) {
+	font, err := widgets.ParseFIGletFont([]byte(testFIGletFont()))
+	if err != nil {
+		t.Fatalf("ParseFIGletFont() error = %v", err)
+	}
+	if font.Height != 2 {
+		t.Errorf("Height = %d, want 2", font.Height)
+	}
+	got := font.Render("A!π")
+	want := []string{"/\\| __", "/\\. ||"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestParseFIGletFontInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":      "",
+		"signature":  "tlf2a$ 2 1 4 0 0\n",
+		"height":     "flf2a$ x 1 4 0 0\n",
+		"comments":   "flf2a$ 2 1 4 0 -1\n",
+		"truncated":  "flf2a$ 2 1 4 0 0\n@\n@@\n",
+		"bad_tag":    testFIGletFont() + "pi\n@\n@@\n",
+		"short_font": strings.Join(strings.Split(testFIGletFont(), "\n")[:50], "\n"),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := widgets.ParseFIGletFont([]byte(data)); !errors.Is(err, widgets.ErrInvalidFont) {
+				t.Errorf("error = %v, want ErrInvalidFont", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestColorBlend(t *testing.T) {
+	black := goterm.ColorRGB(0, 0, 0)
+	white := goterm.ColorRGB(255, 255, 255)
+
+	tests := []struct {
+		name    string
+		got     goterm.Color
+		r, g, b uint8
+	}{
+		{"start", black.Blend(white, 0), 0, 0, 0},
+		{"end", black.Blend(white, 1), 255, 255, 255},
+		{"middle", black.Blend(white, 0.5), 128, 128, 128},
+		{"quarter", goterm.ColorRGB(200, 0, 100).Blend(goterm.ColorRGB(0, 200, 100), 0.25), 150, 50, 100},
+		{"clamped_low", black.Blend(white, -1), 0, 0, 0},
+		{"clamped_high", black.Blend(white, 2), 255, 255, 255},
+		{"palette", goterm.ColorIndex(196).Blend(goterm.ColorIndex(21), 0.5), 128, 0, 128},
+		{"lerp", goterm.Lerp(black, white, 0.5), 128, 128, 128},
+		{"linear_middle", black.BlendLinear(white, 0.5), 188, 188, 188},
+		{"linear_ends", black.BlendLinear(white, 1), 255, 255, 255},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Mode() != goterm.ColorModeTrueColor {
+				t.Fatalf("Mode() = %v, want truecolor", tt.got.Mode())
+			}
+			if r, g, b := tt.got.RGB(); r != tt.r || g != tt.g || b != tt.b {
+				t.Errorf("RGB() = (%d, %d, %d), want (%d, %d, %d)", r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
+func TestColorBlendDefault(t *testing.T) {
+	red := goterm.ColorRGB(255, 0, 0)
+	if got := red.Blend(goterm.ColorDefault(), 0.4); got != red {
+		t.Errorf("Blend(default, 0.4) = %v, want red", got)
+	}
+	if got := red.Blend(goterm.ColorDefault(), 0.6); got != goterm.ColorDefault() {
+		t.Errorf("Blend(default, 0.6) = %v, want default", got)
+	}
+}
@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestDrawBoxStyles(t *testing.T) {
+	tests := []struct {
+		border  goterm.BorderStyle
+		corners [4]rune
+		h, v    rune
+	}{
+		{goterm.BorderSingle, [4]rune{'┌', '┐', '└', '┘'}, '─', '│'},
+		{goterm.BorderDouble, [4]rune{'╔', '╗', '╚', '╝'}, '═', '║'},
+		{goterm.BorderRounded, [4]rune{'╭', '╮', '╰', '╯'}, '─', '│'},
+		{goterm.BorderThick, [4]rune{'┏', '┓', '┗', '┛'}, '━', '┃'},
+		{goterm.BorderASCII, [4]rune{'+', '+', '+', '+'}, '-', '|'},
+		{goterm.BorderDoubleHorizontal, [4]rune{'╒', '╕', '╘', '╛'}, '═', '│'},
+		{goterm.BorderDoubleVertical, [4]rune{'╓', '╖', '╙', '╜'}, '─', '║'},
+	}
+
+	for _, tt := range tests {
+		screen := goterm.NewScreen(10, 6)
+		screen.DrawBox(1, 1, 5, 4, tt.border, goterm.ColorCyan, goterm.ColorDefault())
+
+		positions := [4][2]int{{1, 1}, {5, 1}, {1, 4}, {5, 4}}
+		for i, p := range positions {
+			if got := screen.GetCell(p[0], p[1]).Ch; got != tt.corners[i] {
+				t.Errorf("border %d corner (%d, %d) = %q, want %q", tt.border, p[0], p[1], got, tt.corners[i])
+			}
+		}
+		if got := screen.GetCell(3, 1).Ch; got != tt.h {
+			t.Errorf("border %d top edge = %q, want %q", tt.border, got, tt.h)
+		}
+		if got := screen.GetCell(5, 2).Ch; got != tt.v {
+			t.Errorf("border %d right edge = %q, want %q", tt.border, got, tt.v)
+		}
+		if got := screen.GetCell(3, 2).Ch; got != ' ' {
+			t.Errorf("border %d interior = %q, want untouched", tt.border, got)
+		}
+	}
+}
+
+func TestDrawBoxTooSmall(t *testing.T) {
+	screen := goterm.NewScreen(5, 5)
+	screen.DrawBox(0, 0, 1, 3, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+
+	if got := screen.GetCell(0, 0).Ch; got != ' ' {
+		t.Errorf("DrawBox() with width 1 drew %q", got)
+	}
+}
+
+func TestDrawTitledBox(t *testing.T) {
+	tests := []struct {
+		name  string
+		pos   goterm.TitlePosition
+		x, y  int
+		title string
+	}{
+		{"top_left", goterm.TitleTopLeft, 2, 0, " Hi "},
+		{"top_center", goterm.TitleTopCenter, 3, 0, " Hi "},
+		{"top_right", goterm.TitleTopRight, 4, 0, " Hi "},
+		{"bottom_left", goterm.TitleBottomLeft, 2, 3, " Hi "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(12, 5)
+			screen.DrawTitledBox(0, 0, 10, 4, goterm.BorderSingle, "Hi", tt.pos, goterm.ColorWhite, goterm.ColorDefault())
+
+			for i, ch := range tt.title {
+				if got := screen.GetCell(tt.x+i, tt.y).Ch; got != ch {
+					t.Errorf("title cell (%d, %d) = %q, want %q", tt.x+i, tt.y, got, ch)
+				}
+			}
+		})
+	}
+
+	// Long titles are truncated and keep the corners and the border cells
+	// next to them intact
+	for _, pos := range []goterm.TitlePosition{goterm.TitleTopLeft, goterm.TitleTopCenter, goterm.TitleTopRight} {
+		screen := goterm.NewScreen(10, 3)
+		screen.DrawTitledBox(0, 0, 8, 3, goterm.BorderSingle, "A very long title", pos, goterm.ColorWhite, goterm.ColorDefault())
+		if got := rowText(screen, 0, 0, 8); got != "┌─ A… ─┐" {
+			t.Errorf("top border with long title at %d = %q, want %q", pos, got, "┌─ A… ─┐")
+		}
+	}
+	screen := goterm.NewScreen(10, 3)
+	screen.DrawTitledBox(0, 0, 8, 3, goterm.BorderSingle, "A very long title", goterm.TitleBottomRight, goterm.ColorWhite, goterm.ColorDefault())
+	if got := rowText(screen, 0, 2, 8); got != "└─ A… ─┘" {
+		t.Errorf("bottom border with long title = %q, want %q", got, "└─ A… ─┘")
+	}
+}
@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestRectInset(t *testing.T) {
+	r := goterm.Rect{X: 2, Y: 3, W: 10, H: 6}
+	tests := []struct {
+		name string
+		in   goterm.Insets
+		want goterm.Rect
+	}{
+		{"none", goterm.Insets{}, r},
+		{"all", goterm.InsetsAll(1), goterm.Rect{X: 3, Y: 4, W: 8, H: 4}},
+		{"xy", goterm.InsetsXY(2, 1), goterm.Rect{X: 4, Y: 4, W: 6, H: 4}},
+		{"sides", goterm.Insets{Top: 1, Right: 2, Bottom: 3, Left: 4}, goterm.Rect{X: 6, Y: 4, W: 4, H: 2}},
+		{"exact", goterm.InsetsXY(5, 3), goterm.Rect{X: 7, Y: 6, W: 0, H: 0}},
+		{"too_big", goterm.InsetsAll(20), goterm.Rect{X: 12, Y: 9, W: 0, H: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Inset(tt.in); got != tt.want {
+				t.Errorf("Inset(%+v) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	in := goterm.Insets{Top: 1, Right: 2, Bottom: 3, Left: 4}
+	if got := r.Inset(in).Outset(in); got != r {
+		t.Errorf("Outset(Inset(r)) = %+v, want %+v", got, r)
+	}
+	if in.Width() != 6 || in.Height() != 4 {
+		t.Errorf("Width(), Height() = %d, %d, want 6, 4", in.Width(), in.Height())
+	}
+}
+
+func TestBox(t *testing.T) {
+	r := goterm.Rect{X: 0, Y: 0, W: 20, H: 10}
+	tests := []struct {
+		name    string
+		box     goterm.Box
+		border  goterm.Rect
+		content goterm.Rect
+	}{
+		{"empty", goterm.Box{}, r, r},
+		{"border", goterm.Box{Border: true}, r, goterm.Rect{X: 1, Y: 1, W: 18, H: 8}},
+		{"padding", goterm.Box{Border: true, Padding: goterm.InsetsXY(1, 0)}, r, goterm.Rect{X: 2, Y: 1, W: 16, H: 8}},
+		{"margin", goterm.Box{Margin: goterm.InsetsAll(1), Border: true, Padding: goterm.InsetsAll(1)},
+			goterm.Rect{X: 1, Y: 1, W: 18, H: 8}, goterm.Rect{X: 3, Y: 3, W: 14, H: 4}},
+		{"no_border", goterm.Box{Margin: goterm.Insets{Left: 2}, Padding: goterm.Insets{Top: 1}},
+			goterm.Rect{X: 2, Y: 0, W: 18, H: 10}, goterm.Rect{X: 2, Y: 1, W: 18, H: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.box.BorderRect(r); got != tt.border {
+				t.Errorf("BorderRect() = %+v, want %+v", got, tt.border)
+			}
+			content := tt.box.ContentRect(r)
+			if content != tt.content {
+				t.Errorf("ContentRect() = %+v, want %+v", content, tt.content)
+			}
+			if got := tt.box.OuterRect(content); got != r {
+				t.Errorf("OuterRect() = %+v, want %+v", got, r)
+			}
+		})
+	}
+}
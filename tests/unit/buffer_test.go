@@ -0,0 +1,142 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// letterBuffer returns a buffer whose cells hold consecutive letters row by row
+func letterBuffer(w, h int) *goterm.Buffer {
+	buf := goterm.NewBuffer(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			buf.SetCell(x, y, goterm.NewCell(rune('a'+y*w+x), goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold))
+		}
+	}
+	return buf
+}
+
+func TestNewBuffer(t *testing.T) {
+	buf := goterm.NewBuffer(10, 4)
+	w, h := buf.Size()
+	if w != 10 || h != 4 {
+		t.Errorf("Size() = (%d, %d), want (10, 4)", w, h)
+	}
+	if got := buf.GetCell(9, 3).Ch; got != ' ' {
+		t.Errorf("new buffer cell = %q, want ' '", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBuffer(0, 4) did not panic")
+		}
+	}()
+	goterm.NewBuffer(0, 4)
+}
+
+func TestBufferDrawing(t *testing.T) {
+	buf := goterm.NewBuffer(10, 3)
+	buf.DrawText(0, 0, "hi\tx", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	buf.DrawBox(0, 1, 3, 2, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+
+	if got := rowText(buf, 0, 0, 2); got != "hi" {
+		t.Errorf("row 0 = %q, want %q", got, "hi")
+	}
+	if got := buf.GetCell(8, 0).Ch; got != 'x' {
+		t.Errorf("tab did not advance to column 8: cell 8 = %q", got)
+	}
+	if got := rowText(buf, 0, 1, 3); got != "┌─┐" {
+		t.Errorf("row 1 = %q, want %q", got, "┌─┐")
+	}
+}
+
+func TestScreenBlit(t *testing.T) {
+	src := letterBuffer(4, 3) // abcd / efgh / ijkl
+
+	tests := []struct {
+		name       string
+		dstX, dstY int
+		srcRect    goterm.Rect
+		want       []string
+	}{
+		{"whole", 1, 0, goterm.Rect{X: 0, Y: 0, W: 4, H: 3}, []string{".abcd.", ".efgh.", ".ijkl."}},
+		{"sub_region", 0, 1, goterm.Rect{X: 1, Y: 1, W: 2, H: 2}, []string{"......", "fg....", "jk...."}},
+		{"clipped_by_screen", 4, 2, goterm.Rect{X: 0, Y: 0, W: 4, H: 3}, []string{"......", "......", "....ab"}},
+		{"negative_destination", -2, -1, goterm.Rect{X: 0, Y: 0, W: 4, H: 3}, []string{"gh....", "kl....", "......"}},
+		{"clipped_by_source", 0, 0, goterm.Rect{X: 2, Y: 2, W: 5, H: 5}, []string{"kl....", "......", "......"}},
+		{"outside", 6, 0, goterm.Rect{X: 0, Y: 0, W: 4, H: 3}, []string{"......", "......", "......"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(6, 3)
+			screen.Fill(0, 0, 6, 3, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			screen.Blit(tt.dstX, tt.dstY, src, tt.srcRect)
+
+			for y, want := range tt.want {
+				if got := rowText(screen, 0, y, 6); got != want {
+					t.Errorf("row %d = %q, want %q", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlitCopiesAttributes(t *testing.T) {
+	src := letterBuffer(2, 1)
+	screen := goterm.NewScreen(4, 1)
+	screen.Blit(0, 0, src, goterm.Rect{W: 2, H: 1})
+
+	want := goterm.NewCell('b', goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold)
+	if got := screen.GetCell(1, 0); !got.Equal(want) {
+		t.Errorf("GetCell(1, 0) = %+v, want %+v", got, want)
+	}
+}
+
+func TestBufferBlitOverlapping(t *testing.T) {
+	buf := letterBuffer(4, 3)
+	buf.Blit(1, 1, buf, goterm.Rect{W: 3, H: 2})
+
+	want := []string{"abcd", "eabc", "iefg"}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 4); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+}
+
+func TestBlitTransparent(t *testing.T) {
+	// A diamond-shaped sprite: transparent corners let the background through
+	src := goterm.NewBuffer(3, 3)
+	src.Fill(0, 0, 3, 3, goterm.CellTransparent())
+	star := goterm.NewCell('*', goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleNone)
+	src.SetCell(1, 0, star)
+	src.Fill(0, 1, 3, 1, star)
+	src.SetCell(1, 2, star)
+
+	screen := goterm.NewScreen(5, 3)
+	screen.Fill(0, 0, 5, 3, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+	screen.Blit(1, 0, src, src.Bounds())
+
+	want := []string{"..*..", ".***.", "..*.."}
+	for y, row := range want {
+		if got := rowText(screen, 0, y, 5); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+}
+
+func TestBlitOverWideCharacter(t *testing.T) {
+	src := goterm.NewBuffer(1, 1)
+	src.SetCell(0, 0, goterm.NewCell('x', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+
+	screen := goterm.NewScreen(4, 1)
+	screen.DrawText(0, 0, "世", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.Blit(1, 0, src, src.Bounds())
+
+	// Only the first half of the wide character is left, drawn as a blank
+	if got := rowText(screen, 0, 0, 2); got != " x" {
+		t.Errorf("row = %q, want %q", got, " x")
+	}
+}
@@ -116,3 +116,15 @@ func TestCellUnicode(t *testing.T) {
 		}
 	}
 }
+
+func TestCellTransparent(t *testing.T) {
+	if !goterm.CellTransparent().Transparent() {
+		t.Error("CellTransparent().Transparent() = false, want true")
+	}
+	if !(goterm.Cell{}).Transparent() {
+		t.Error("zero Cell is not transparent")
+	}
+	if goterm.NewCell(' ', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone).Transparent() {
+		t.Error("blank cell reported as transparent")
+	}
+}
@@ -0,0 +1,101 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestCanvas(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(c *widgets.Canvas)
+		want string
+	}{
+		{"empty", func(c *widgets.Canvas) {}, "  "},
+		{"corners", func(c *widgets.Canvas) {
+			c.Set(0, 0, goterm.ColorRed)
+			c.Set(1, 3, goterm.ColorRed)
+		}, "⢁ "},
+		{"full_cell", func(c *widgets.Canvas) {
+			for y := 0; y < 4; y++ {
+				c.Set(0, y, goterm.ColorRed)
+				c.Set(1, y, goterm.ColorRed)
+			}
+		}, "⣿ "},
+		{"horizontal_line", func(c *widgets.Canvas) { c.Line(0, 3, 3, 3, goterm.ColorRed) }, "⣀⣀"},
+		{"diagonal_line", func(c *widgets.Canvas) { c.Line(0, 0, 3, 3, goterm.ColorRed) }, "⠑⢄"},
+		{"out_of_bounds", func(c *widgets.Canvas) { c.Set(4, 0, goterm.ColorRed) }, "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canvas := widgets.NewCanvas(2, 1)
+			tt.draw(canvas)
+			buf := goterm.NewBuffer(2, 1)
+			canvas.Draw(buf)
+			if got := rowText(buf, 0, 0, 2); got != tt.want {
+				t.Errorf("Draw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	chart := widgets.LineChart{
+		Series: []widgets.Series{
+			{Name: "up", Values: []float64{0, 1, 2, 3}, Color: goterm.ColorGreen},
+			{Name: "gap", Values: []float64{3, math.NaN(), 3, 3}, Color: goterm.ColorRed},
+		},
+		Axes:   true,
+		Legend: true,
+	}
+	buf := goterm.NewBuffer(8, 4)
+	chart.Draw(buf)
+
+	want := []string{
+		"3│⠁  ⡨⠛⠉",
+		"0│⡠⠔⠊   ",
+		" └──────",
+		"  ● up …",
+	}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 8); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+	if got := buf.GetCell(2, 1).Fg; got != goterm.ColorGreen {
+		t.Errorf("line color = %v, want green", got)
+	}
+}
+
+func TestScatterPlot(t *testing.T) {
+	plot := widgets.ScatterPlot{
+		Series: []widgets.ScatterSeries{
+			{Name: "a", Points: []widgets.Point{{X: 0, Y: 0}, {X: 10, Y: 10}}, Color: goterm.ColorGreen},
+			{Name: "b", Points: []widgets.Point{{X: 10, Y: 0}, {X: math.NaN(), Y: 5}}, Color: goterm.ColorRed, Marker: 'x'},
+		},
+		Axes:   true,
+		Legend: true,
+	}
+	buf := goterm.NewBuffer(9, 5)
+	plot.Draw(buf)
+
+	want := []string{
+		"10│     ⠈",
+		" 0│⡀    x",
+		"  └──────",
+		"   0   10",
+		"   ● a  …",
+	}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 9); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+	if got := buf.GetCell(8, 1).Fg; got != goterm.ColorRed {
+		t.Errorf("marker color = %v, want red", got)
+	}
+}
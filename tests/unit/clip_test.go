@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestScreenClipStack(t *testing.T) {
+	screen := goterm.NewScreen(8, 3)
+	hash := goterm.NewCell('#', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	screen.PushClip(goterm.Rect{X: 1, Y: 0, W: 5, H: 2})
+	screen.PushClip(goterm.Rect{X: 3, Y: 1, W: 5, H: 2}) // Intersected with the outer clip
+	screen.Fill(0, 0, 8, 3, hash)
+	screen.PopClip()
+	screen.DrawText(0, 0, "abcdefgh", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.PopClip()
+	screen.PopClip() // Extra pops are ignored
+	screen.SetCell(7, 2, hash)
+
+	want := []string{
+		" bcdef  ",
+		"   ###  ",
+		"       #",
+	}
+	for y, row := range want {
+		if got := rowText(screen, 0, y, 8); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+}
+
+func TestClipWideCharacter(t *testing.T) {
+	screen := goterm.NewScreen(6, 1)
+	screen.PushClip(goterm.Rect{W: 3, H: 1})
+	screen.DrawText(0, 0, "a世界", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.PopClip()
+
+	// A wide character crossing the clip edge is replaced by a blank
+	if got := rowText(screen, 0, 0, 6); got != "a世    " {
+		t.Errorf("row = %q, want %q", got, "a世    ")
+	}
+}
+
+func TestViewClipStack(t *testing.T) {
+	screen := goterm.NewScreen(8, 1)
+	view := screen.SubView(2, 0, 5, 1)
+
+	view.PushClip(goterm.Rect{X: 1, W: 2, H: 1})
+	view.DrawText(0, 0, "vwxyz", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	view.PopClip()
+	view.SetCell(4, 0, goterm.NewCell('!', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+
+	if got := rowText(screen, 0, 0, 8); got != "   wx ! " {
+		t.Errorf("row = %q, want %q", got, "   wx ! ")
+	}
+}
+
+func TestResizeDropsClip(t *testing.T) {
+	screen := goterm.NewScreen(4, 1)
+	screen.PushClip(goterm.Rect{W: 1, H: 1})
+	screen.Resize(5, 1)
+	screen.DrawText(0, 0, "hello", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	if got := rowText(screen, 0, 0, 5); got != "hello" {
+		t.Errorf("row = %q, want %q", got, "hello")
+	}
+}
@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestGoHighlighter(t *testing.T) {
+	tok := func(text string, kind widgets.TokenKind) widgets.Token {
+		return widgets.Token{Text: text, Kind: kind}
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  [][]widgets.Token
+	}{
+		{"keywords", []string{"func f(n int) error {"}, [][]widgets.Token{{
+			tok("func", widgets.TokenKeyword), tok(" f(n ", widgets.TokenPlain), tok("int", widgets.TokenType),
+			tok(") ", widgets.TokenPlain), tok("error", widgets.TokenType), tok(" {", widgets.TokenPlain),
+		}}},
+		{"strings", []string{`x := "a\"b" + 'c'`}, [][]widgets.Token{{
+			tok("x := ", widgets.TokenPlain), tok(`"a\"b"`, widgets.TokenString), tok(" + ", widgets.TokenPlain), tok("'c'", widgets.TokenString),
+		}}},
+		{"numbers", []string{"n := 0x1F + 2.5"}, [][]widgets.Token{{
+			tok("n := ", widgets.TokenPlain), tok("0x1F", widgets.TokenNumber), tok(" + ", widgets.TokenPlain), tok("2.5", widgets.TokenNumber),
+		}}},
+		{"identifier_digits", []string{"v2"}, [][]widgets.Token{{tok("v2", widgets.TokenPlain)}}},
+		{"line_comment", []string{"return // done"}, [][]widgets.Token{{
+			tok("return", widgets.TokenKeyword), tok(" ", widgets.TokenPlain), tok("// done", widgets.TokenComment),
+		}}},
+		{"block_comment", []string{"a /* one", "two */ b"}, [][]widgets.Token{
+			{tok("a ", widgets.TokenPlain), tok("/* one", widgets.TokenComment)},
+			{tok("two */", widgets.TokenComment), tok(" b", widgets.TokenPlain)},
+		}},
+		{"raw_string", []string{"s := `one", "two` + x"}, [][]widgets.Token{
+			{tok("s := ", widgets.TokenPlain), tok("`one", widgets.TokenString)},
+			{tok("two`", widgets.TokenString), tok(" + x", widgets.TokenPlain)},
+		}},
+		{"empty", []string{""}, [][]widgets.Token{nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := widgets.GoHighlighter.Highlight(tt.lines)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Highlight() = %d lines, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if len(got[i]) != len(tt.want[i]) {
+					t.Fatalf("line %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+				for j := range got[i] {
+					if got[i][j] != tt.want[i][j] {
+						t.Errorf("line %d token %d = %+v, want %+v", i, j, got[i][j], tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCodeViewDraw(t *testing.T) {
+	code := widgets.CodeView{Highlighter: widgets.GoHighlighter, LineNumbers: true, NumberFg: goterm.ColorIndex(8)}
+	src := "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n"
+	for i := 0; i < 6; i++ {
+		src += "// filler\n"
+	}
+	code.SetText(src)
+	buf := goterm.NewBuffer(12, 4)
+	code.Draw(buf)
+
+	want := []string{" 1 package m", " 2          ", " 3 func main", " 4     print"}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 12); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+	if got := buf.GetCell(3, 0); got.Fg != goterm.ColorMagenta || !got.Style.Has(goterm.StyleBold) {
+		t.Errorf("keyword cell = %+v, want bold magenta", got)
+	}
+	if got := buf.GetCell(1, 0).Fg; got != goterm.ColorIndex(8) {
+		t.Errorf("line number fg = %v", got)
+	}
+
+	code.HandleEvent(key(goterm.KeyEnd))
+	code.HandleEvent(key(goterm.KeyRight))
+	code.HandleEvent(key(goterm.KeyRight))
+	code.Draw(buf)
+	if x, y := code.Offset(); x != 2 || y != 8 {
+		t.Errorf("Offset() = (%d, %d), want (2, 8)", x, y)
+	}
+	want = []string{" 9  filler  ", "10  filler  ", "11  filler  ", "12          "}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 12); got != row {
+			t.Errorf("scrolled row %d = %q, want %q", y, got, row)
+		}
+	}
+}
+
+func TestCodeViewScrolling(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []goterm.Event
+		x, y   int
+	}{
+		{"down", []goterm.Event{key(goterm.KeyDown), key(goterm.KeyDown)}, 0, 2},
+		{"page", []goterm.Event{key(goterm.KeyPageDown)}, 0, 2},
+		{"right_stops", []goterm.Event{key(goterm.KeyRight), key(goterm.KeyRight), key(goterm.KeyRight)}, 2, 0},
+		{"wheel", []goterm.Event{goterm.MouseEvent{Button: goterm.MouseWheelDown}}, 0, 3},
+		{"shift_wheel", []goterm.Event{goterm.MouseEvent{Button: goterm.MouseWheelDown, Modifiers: goterm.ModShift}}, 2, 0},
+		{"home", []goterm.Event{key(goterm.KeyEnd), key(goterm.KeyHome)}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code widgets.CodeView
+			code.SetText("0123456789\n1\n2\n3\n4\n5\n6\n7\n8\n9")
+			code.Draw(goterm.NewBuffer(8, 3))
+			for _, ev := range tt.events {
+				code.HandleEvent(ev)
+			}
+			if x, y := code.Offset(); x != tt.x || y != tt.y {
+				t.Errorf("Offset() = (%d, %d), want (%d, %d)", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
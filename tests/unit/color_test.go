@@ -1,6 +1,8 @@
 package unit
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/dshills/goterm"
@@ -34,6 +36,146 @@ func TestColorRGB(t *testing.T) {
 	}
 }
 
+func TestColorHex(t *testing.T) {
+	tests := []struct {
+		input   string
+		r, g, b uint8
+		wantErr bool
+	}{
+		{"#ff8000", 255, 128, 0, false},
+		{"#FF8000", 255, 128, 0, false},
+		{"1e90ff", 30, 144, 255, false},
+		{"#f80", 255, 136, 0, false},
+		{"#000", 0, 0, 0, false},
+		{"", 0, 0, 0, true},
+		{"#ff80", 0, 0, 0, true},
+		{"#gg0000", 0, 0, 0, true},
+		{"#+f0000", 0, 0, 0, true},
+		{"##f80", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := goterm.ColorHex(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, goterm.ErrInvalidColor) {
+					t.Errorf("ColorHex(%q) error = %v, want ErrInvalidColor", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ColorHex(%q) error = %v", tt.input, err)
+			}
+			if c.Mode() != goterm.ColorModeTrueColor {
+				t.Errorf("ColorHex(%q).Mode() = %v, want truecolor", tt.input, c.Mode())
+			}
+			if r, g, b := c.RGB(); r != tt.r || g != tt.g || b != tt.b {
+				t.Errorf("ColorHex(%q).RGB() = (%d, %d, %d), want (%d, %d, %d)", tt.input, r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
+func TestMustColorHex(t *testing.T) {
+	if got := goterm.MustColorHex("#102030"); got != goterm.ColorRGB(16, 32, 48) {
+		t.Errorf("MustColorHex() = %v, want RGB(16, 32, 48)", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustColorHex() with invalid input did not panic")
+		}
+	}()
+	goterm.MustColorHex("nope")
+}
+
+func TestColorHSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		h, s, v float64
+		r, g, b uint8
+	}{
+		{"red", 0, 1, 1, 255, 0, 0},
+		{"yellow", 60, 1, 1, 255, 255, 0},
+		{"green", 120, 1, 1, 0, 255, 0},
+		{"cyan", 180, 1, 1, 0, 255, 255},
+		{"blue", 240, 1, 1, 0, 0, 255},
+		{"magenta", 300, 1, 1, 255, 0, 255},
+		{"wraps_360", 360, 1, 1, 255, 0, 0},
+		{"wraps_negative", -120, 1, 1, 0, 0, 255},
+		{"gray", 90, 0, 0.5, 128, 128, 128},
+		{"dark_orange", 30, 1, 0.5, 128, 64, 0},
+		{"clamped", 0, 2, -1, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := goterm.ColorHSV(tt.h, tt.s, tt.v)
+			if r, g, b := c.RGB(); r != tt.r || g != tt.g || b != tt.b {
+				t.Errorf("ColorHSV(%v, %v, %v) = (%d, %d, %d), want (%d, %d, %d)", tt.h, tt.s, tt.v, r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
+func TestColorHSL(t *testing.T) {
+	tests := []struct {
+		name    string
+		h, s, l float64
+		r, g, b uint8
+	}{
+		{"red", 0, 1, 0.5, 255, 0, 0},
+		{"black", 0, 1, 0, 0, 0, 0},
+		{"white", 0, 1, 1, 255, 255, 255},
+		{"light_blue", 240, 1, 0.75, 128, 128, 255},
+		{"dark_green", 120, 1, 0.25, 0, 128, 0},
+		{"gray", 0, 0, 0.5, 128, 128, 128},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := goterm.ColorHSL(tt.h, tt.s, tt.l)
+			if r, g, b := c.RGB(); r != tt.r || g != tt.g || b != tt.b {
+				t.Errorf("ColorHSL(%v, %v, %v) = (%d, %d, %d), want (%d, %d, %d)", tt.h, tt.s, tt.l, r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
+func TestColorByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b uint8
+		wantErr bool
+	}{
+		{"orchid", 218, 112, 214, false},
+		{"RebeccaPurple", 102, 51, 153, false},
+		{"dark slate gray", 47, 79, 79, false},
+		{"light_sea-green", 32, 178, 170, false},
+		{"grey", 128, 128, 128, false},
+		{"notacolor", 0, 0, 0, true},
+		{"", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := goterm.ColorByName(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, goterm.ErrInvalidColor) {
+					t.Errorf("ColorByName(%q) error = %v, want ErrInvalidColor", tt.name, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ColorByName(%q) error = %v", tt.name, err)
+			}
+			if r, g, b := c.RGB(); r != tt.r || g != tt.g || b != tt.b {
+				t.Errorf("ColorByName(%q) = (%d, %d, %d), want (%d, %d, %d)", tt.name, r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
 func TestColorIndex(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -97,6 +239,33 @@ func TestColorTo256(t *testing.T) {
 	}
 }
 
+func TestColorTo256Nearest(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b uint8
+		want    uint8
+	}{
+		{"black", 0, 0, 0, 16},
+		{"white", 255, 255, 255, 231},
+		{"red", 255, 0, 0, 196},
+		{"cube_level", 95, 135, 175, 67},
+		{"near_cube_level", 100, 130, 170, 67},
+		{"mid_gray", 128, 128, 128, 244},
+		{"dark_gray", 30, 30, 30, 234},
+		{"warm_gray", 120, 118, 115, 243},
+		{"cube_gray", 135, 135, 135, 102},
+		{"light_gray", 238, 238, 238, 255},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goterm.ColorRGB(tt.r, tt.g, tt.b).To256().Index(); got != tt.want {
+				t.Errorf("RGB(%d, %d, %d).To256().Index() = %d, want %d", tt.r, tt.g, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestColorTo16(t *testing.T) {
 	// Test conversion to 16-color
 	tests := []struct {
@@ -121,6 +290,63 @@ func TestColorTo16(t *testing.T) {
 	}
 }
 
+func TestColorTo16Nearest(t *testing.T) {
+	tests := []struct {
+		name  string
+		color goterm.Color
+		want  uint8
+	}{
+		{"black", goterm.ColorRGB(0, 0, 0), 0},
+		{"bright_white", goterm.ColorRGB(255, 255, 255), 15},
+		{"dark_red", goterm.ColorRGB(190, 10, 10), 1},
+		{"bright_red", goterm.ColorRGB(250, 20, 20), 9},
+		{"orange", goterm.ColorRGB(255, 165, 0), 3},
+		{"mid_gray", goterm.ColorRGB(128, 128, 128), 8},
+		{"light_gray", goterm.ColorRGB(220, 220, 220), 7},
+		{"navy", goterm.ColorRGB(0, 0, 200), 4},
+		{"cube_orange", goterm.ColorIndex(214), 3},
+		{"cube_bright_green", goterm.ColorIndex(46), 10},
+		{"gray_ramp_dark", goterm.ColorIndex(233), 0},
+		{"gray_ramp_light", goterm.ColorIndex(254), 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.To16().Index(); got != tt.want {
+				t.Errorf("To16().Index() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := goterm.ColorDefault().To16(); got != goterm.ColorDefault() {
+		t.Errorf("ColorDefault().To16() = %v, want default", got)
+	}
+}
+
+func TestColorString(t *testing.T) {
+	tests := []struct {
+		color goterm.Color
+		want  string
+	}{
+		{goterm.ColorDefault(), "default"},
+		{goterm.ColorRed, "ansi(1)"},
+		{goterm.ColorIndex(12), "ansi(12)"},
+		{goterm.ColorIndex(208), "ansi256(208)"},
+		{goterm.ColorRGB(255, 128, 0), "rgb(255,128,0)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.color.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := fmt.Sprint(tt.color); got != tt.want {
+				t.Errorf("fmt.Sprint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNamedColors(t *testing.T) {
 	// Test that named colors are correctly defined
 	colors := map[string]goterm.Color{
@@ -145,3 +371,25 @@ func TestNamedColors(t *testing.T) {
 		})
 	}
 }
+
+func TestColorToRGB(t *testing.T) {
+	tests := []struct {
+		name  string
+		color goterm.Color
+		want  goterm.Color
+	}{
+		{"ansi", goterm.ColorRed, goterm.ColorRGB(205, 0, 0)},
+		{"cube", goterm.ColorIndex(208), goterm.ColorRGB(255, 135, 0)},
+		{"gray", goterm.ColorIndex(232), goterm.ColorRGB(8, 8, 8)},
+		{"rgb", goterm.ColorRGB(1, 2, 3), goterm.ColorRGB(1, 2, 3)},
+		{"default", goterm.ColorDefault(), goterm.ColorDefault()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.ToRGB(); got != tt.want {
+				t.Errorf("ToRGB() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestColorPickerPalette(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   widgets.ColorPickerMode
+		start  goterm.Color
+		events []goterm.Event
+		want   goterm.Color
+	}{
+		{"right", widgets.ColorPicker16, goterm.ColorRed, []goterm.Event{key(goterm.KeyRight)}, goterm.ColorGreen},
+		{"down", widgets.ColorPicker16, goterm.ColorRed, []goterm.Event{key(goterm.KeyDown)}, goterm.ColorIndex(9)},
+		{"left_stops", widgets.ColorPicker16, goterm.ColorBlack, []goterm.Event{key(goterm.KeyLeft)}, goterm.ColorBlack},
+		{"end", widgets.ColorPicker16, goterm.ColorRed, []goterm.Event{key(goterm.KeyEnd)}, goterm.ColorIndex(15)},
+		{"cube_down", widgets.ColorPicker256, goterm.ColorIndex(3), []goterm.Event{key(goterm.KeyDown)}, goterm.ColorIndex(19)},
+		{"cube_row", widgets.ColorPicker256, goterm.ColorIndex(20), []goterm.Event{key(goterm.KeyDown)}, goterm.ColorIndex(56)},
+		{"gray_clamps", widgets.ColorPicker256, goterm.ColorIndex(231), []goterm.Event{key(goterm.KeyDown)}, goterm.ColorIndex(255)},
+		{"click_16", widgets.ColorPicker16, goterm.ColorBlack, []goterm.Event{click(7, 3)}, goterm.ColorIndex(10)},
+		{"click_256", widgets.ColorPicker256, goterm.ColorBlack, []goterm.Event{click(5, 4)}, goterm.ColorIndex(57)},
+		{"converts", widgets.ColorPicker16, goterm.ColorRGB(250, 0, 0), nil, goterm.ColorIndex(9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := widgets.ColorPicker{Mode: tt.mode}
+			p.SetColor(tt.start)
+			p.Draw(goterm.NewBuffer(40, 12))
+			for _, ev := range tt.events {
+				p.HandleEvent(ev)
+			}
+			if got := p.Color(); got != tt.want {
+				t.Errorf("Color() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorPickerSliders(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   widgets.ColorPickerMode
+		start  goterm.Color
+		events []goterm.Event
+		want   goterm.Color
+	}{
+		{"red_up", widgets.ColorPickerRGB, goterm.ColorRGB(10, 20, 30), []goterm.Event{key(goterm.KeyRight)}, goterm.ColorRGB(11, 20, 30)},
+		{"green_shift", widgets.ColorPickerRGB, goterm.ColorRGB(10, 20, 30), []goterm.Event{key(goterm.KeyDown), shiftKey(goterm.KeyRight)}, goterm.ColorRGB(10, 30, 30)},
+		{"blue_max", widgets.ColorPickerRGB, goterm.ColorRGB(10, 20, 30), []goterm.Event{key(goterm.KeyDown), key(goterm.KeyDown), key(goterm.KeyEnd)}, goterm.ColorRGB(10, 20, 255)},
+		{"clamps", widgets.ColorPickerRGB, goterm.ColorRGB(250, 0, 0), []goterm.Event{shiftKey(goterm.KeyRight)}, goterm.ColorRGB(255, 0, 0)},
+		{"palette_start", widgets.ColorPickerRGB, goterm.ColorRed, []goterm.Event{key(goterm.KeyHome)}, goterm.ColorRGB(0, 0, 0)},
+		{"hue", widgets.ColorPickerHSV, goterm.ColorRGB(255, 0, 0), []goterm.Event{key(goterm.KeyEnd), key(goterm.KeyHome)}, goterm.ColorRGB(255, 0, 0)},
+		{"saturation", widgets.ColorPickerHSV, goterm.ColorRGB(255, 0, 0), []goterm.Event{key(goterm.KeyDown), key(goterm.KeyHome)}, goterm.ColorRGB(255, 255, 255)},
+		{"click_value", widgets.ColorPickerHSV, goterm.ColorRGB(255, 0, 0), []goterm.Event{click(2, 4)}, goterm.ColorRGB(0, 0, 0)},
+		{"click_red", widgets.ColorPickerRGB, goterm.ColorRGB(0, 0, 0), []goterm.Event{click(35, 2)}, goterm.ColorRGB(255, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := widgets.ColorPicker{Mode: tt.mode}
+			p.SetColor(tt.start)
+			p.Draw(goterm.NewBuffer(40, 7))
+			for _, ev := range tt.events {
+				p.HandleEvent(ev)
+			}
+			if got := p.Color(); got != tt.want {
+				t.Errorf("Color() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorPickerHueSurvivesGray(t *testing.T) {
+	p := widgets.ColorPicker{Mode: widgets.ColorPickerHSV}
+	p.SetColor(goterm.ColorRGB(0, 0, 255))
+	p.HandleEvent(key(goterm.KeyDown))
+	p.HandleEvent(key(goterm.KeyHome)) // Saturation 0: white
+	p.HandleEvent(key(goterm.KeyEnd))  // Saturation back to 100
+	if got, want := p.Color(), goterm.ColorRGB(0, 0, 255); got != want {
+		t.Errorf("Color() = %v, want %v", got, want)
+	}
+}
+
+func TestColorPickerModes(t *testing.T) {
+	var changes []goterm.Color
+	p := widgets.ColorPicker{OnChange: func(c goterm.Color) { changes = append(changes, c) }}
+	p.SetColor(goterm.ColorIndex(9))
+	buf := goterm.NewBuffer(40, 7)
+	p.Draw(buf)
+	if got, want := rowText(buf, 0, 0, 18), " 16   256   RGB   "; got != want {
+		t.Errorf("tabs = %q, want %q", got, want)
+	}
+	if !buf.GetCell(1, 0).Style.Has(goterm.StyleBold) {
+		t.Error("current tab not highlighted")
+	}
+	if got := buf.GetCell(4, 3).Ch; got != '◆' {
+		t.Errorf("cursor swatch = %q, want '◆'", got)
+	}
+	if got, want := rowText(buf, 7, 5, 7), "ansi(9)"; got != want {
+		t.Errorf("preview label = %q, want %q", got, want)
+	}
+
+	p.HandleEvent(runeKey(']'))
+	p.HandleEvent(runeKey(']'))
+	if p.Mode != widgets.ColorPickerRGB {
+		t.Fatalf("Mode = %v, want RGB", p.Mode)
+	}
+	if got, want := p.Color(), goterm.ColorRGB(255, 0, 0); got != want {
+		t.Errorf("Color() = %v, want %v", got, want)
+	}
+	p.Draw(buf)
+	if got, want := rowText(buf, 36, 2, 4), " 255"; got != want {
+		t.Errorf("red value = %q, want %q", got, want)
+	}
+	if got, want := rowText(buf, 7, 6, 7), "#ff0000"; got != want {
+		t.Errorf("preview label = %q, want %q", got, want)
+	}
+
+	p.HandleEvent(click(1, 0))
+	if p.Mode != widgets.ColorPicker16 || p.Color() != goterm.ColorIndex(9) {
+		t.Errorf("after tab click Mode = %v, Color() = %v", p.Mode, p.Color())
+	}
+	if len(changes) != 2 || changes[0] != goterm.ColorRGB(255, 0, 0) || changes[1] != goterm.ColorIndex(9) {
+		t.Errorf("OnChange calls = %v", changes)
+	}
+
+	var selected goterm.Color
+	p.OnSelect = func(c goterm.Color) { selected = c }
+	p.HandleEvent(key(goterm.KeyEnter))
+	if selected != goterm.ColorIndex(9) {
+		t.Errorf("OnSelect got %v", selected)
+	}
+}
@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestNextPrevCell(t *testing.T) {
+	// "a" (1 byte), "e\u0301" (3 bytes), "世" (3 bytes, wide), "b" (1 byte)
+	s := "ae\u0301世b"
+	offsets := []int{0, 1, 4, 7, 8}
+	widths := []int{1, 1, 2, 1}
+
+	for i := 0; i < len(widths); i++ {
+		next, w := goterm.NextCell(s, offsets[i])
+		if next != offsets[i+1] || w != widths[i] {
+			t.Errorf("NextCell(s, %d) = (%d, %d), want (%d, %d)", offsets[i], next, w, offsets[i+1], widths[i])
+		}
+
+		prev, w := goterm.PrevCell(s, offsets[i+1])
+		if prev != offsets[i] || w != widths[i] {
+			t.Errorf("PrevCell(s, %d) = (%d, %d), want (%d, %d)", offsets[i+1], prev, w, offsets[i], widths[i])
+		}
+	}
+
+	if next, w := goterm.NextCell(s, len(s)); next != len(s) || w != 0 {
+		t.Errorf("NextCell(s, end) = (%d, %d), want (%d, 0)", next, w, len(s))
+	}
+	if prev, w := goterm.PrevCell(s, 0); prev != 0 || w != 0 {
+		t.Errorf("PrevCell(s, 0) = (%d, %d), want (0, 0)", prev, w)
+	}
+}
+
+func TestColumnConversions(t *testing.T) {
+	s := "ae\u0301世b"
+
+	columns := []struct {
+		offset, col int
+	}{
+		{0, 0}, {1, 1}, {2, 1}, {4, 2}, {7, 4}, {8, 5},
+	}
+	for _, tt := range columns {
+		if got := goterm.ByteToColumn(s, tt.offset); got != tt.col {
+			t.Errorf("ByteToColumn(s, %d) = %d, want %d", tt.offset, got, tt.col)
+		}
+	}
+
+	offsets := []struct {
+		col, offset int
+	}{
+		{0, 0}, {1, 1}, {2, 4}, {3, 4}, {4, 7}, {5, 8}, {10, 8},
+	}
+	for _, tt := range offsets {
+		if got := goterm.ColumnToByte(s, tt.col); got != tt.offset {
+			t.Errorf("ColumnToByte(s, %d) = %d, want %d", tt.col, got, tt.offset)
+		}
+	}
+}
+
+func TestRuneConversions(t *testing.T) {
+	s := "a世b"
+
+	tests := []struct {
+		runeIdx, offset int
+	}{
+		{0, 0}, {1, 1}, {2, 4}, {3, 5},
+	}
+	for _, tt := range tests {
+		if got := goterm.RuneToByte(s, tt.runeIdx); got != tt.offset {
+			t.Errorf("RuneToByte(s, %d) = %d, want %d", tt.runeIdx, got, tt.offset)
+		}
+		if got := goterm.ByteToRune(s, tt.offset); got != tt.runeIdx {
+			t.Errorf("ByteToRune(s, %d) = %d, want %d", tt.offset, got, tt.runeIdx)
+		}
+	}
+
+	offsets := []struct {
+		name            string
+		s               string
+		offset, runeIdx int
+	}{
+		{"negative", s, -3, 0},
+		{"mid_rune", s, 2, 1},
+		{"last_byte_of_rune", s, 3, 1},
+		{"past_end", s, 9, 3},
+		{"accent_mid_rune", "é", 1, 0},
+	}
+	for _, tt := range offsets {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goterm.ByteToRune(tt.s, tt.offset); got != tt.runeIdx {
+				t.Errorf("ByteToRune(%q, %d) = %d, want %d", tt.s, tt.offset, got, tt.runeIdx)
+			}
+		})
+	}
+}
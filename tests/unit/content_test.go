@@ -0,0 +1,56 @@
+package unit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestSetContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		x         int
+		primary   rune
+		combining []rune
+		wantComb  string
+		wantWidth int
+	}{
+		{"plain", 0, 'a', nil, "", 1},
+		{"decomposed_accent", 1, 'e', []rune{'\u0301'}, "\u0301", 1},
+		{"stacked_marks", 2, 'o', []rune{'\u0308', '\u0304'}, "\u0308\u0304", 1},
+		{"wide", 3, '世', nil, "", 2},
+		{"emoji_sequence", 5, '👩', []rune{'\u200d', '💻'}, "\u200d💻", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(10, 1)
+			screen.SetContent(tt.x, 0, tt.primary, tt.combining, goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold)
+
+			cell := screen.GetCell(tt.x, 0)
+			if cell.Ch != tt.primary || cell.Comb != tt.wantComb || cell.Fg != goterm.ColorRed || cell.Style != goterm.StyleBold {
+				t.Errorf("GetCell = %+v, want %q + %q", cell, tt.primary, tt.wantComb)
+			}
+
+			primary, combining, fg, _, style, width := screen.GetContent(tt.x, 0)
+			if primary != tt.primary || !slices.Equal(combining, tt.combining) || fg != goterm.ColorRed || style != goterm.StyleBold || width != tt.wantWidth {
+				t.Errorf("GetContent = (%q, %q, %v, %v, %d), want (%q, %q, red, bold, %d)",
+					primary, combining, fg, style, width, tt.primary, tt.combining, tt.wantWidth)
+			}
+		})
+	}
+}
+
+func TestSetContentWideAtEdge(t *testing.T) {
+	screen := goterm.NewScreen(4, 1)
+	screen.SetContent(1, 0, 'x', nil, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.SetContent(0, 0, '世', nil, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.SetContent(3, 0, '界', nil, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	// The continuation column is blanked, and a wide character that does
+	// not fit is replaced by a blank
+	if got := rowText(screen, 0, 0, 4); got != "世   " {
+		t.Errorf("row = %q, want %q", got, "世   ")
+	}
+}
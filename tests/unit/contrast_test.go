@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestColorLuminance(t *testing.T) {
+	tests := []struct {
+		name  string
+		color goterm.Color
+		want  float64
+	}{
+		{"black", goterm.ColorRGB(0, 0, 0), 0},
+		{"white", goterm.ColorRGB(255, 255, 255), 1},
+		{"red", goterm.ColorRGB(255, 0, 0), 0.2126},
+		{"gray", goterm.ColorRGB(128, 128, 128), 0.2159},
+		{"palette_green", goterm.ColorIndex(46), 0.7152},
+		{"default", goterm.ColorDefault(), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.Luminance(); math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("Luminance() = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	black := goterm.ColorRGB(0, 0, 0)
+	white := goterm.ColorRGB(255, 255, 255)
+
+	tests := []struct {
+		name string
+		a, b goterm.Color
+		want float64
+	}{
+		{"black_white", black, white, 21},
+		{"white_black", white, black, 21},
+		{"same", goterm.ColorRed, goterm.ColorRed, 1},
+		{"gray_white", goterm.ColorRGB(118, 118, 118), white, 4.54},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goterm.ContrastRatio(tt.a, tt.b); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestTextColor(t *testing.T) {
+	black := goterm.ColorRGB(0, 0, 0)
+	white := goterm.ColorRGB(255, 255, 255)
+
+	tests := []struct {
+		name string
+		bg   goterm.Color
+		want goterm.Color
+	}{
+		{"navy", goterm.ColorRGB(0, 0, 128), white},
+		{"yellow", goterm.ColorRGB(255, 255, 0), black},
+		{"dark_red", goterm.ColorRGB(139, 0, 0), white},
+		{"light_gray", goterm.ColorRGB(211, 211, 211), black},
+		{"ansi_blue", goterm.ColorBlue, white},
+		{"ansi_white", goterm.ColorWhite, black},
+		{"default", goterm.ColorDefault(), goterm.ColorDefault()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goterm.BestTextColor(tt.bg); got != tt.want {
+				t.Errorf("BestTextColor(%v) = %v, want %v", tt.bg, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestDialogLayout(t *testing.T) {
+	screen := goterm.NewScreen(30, 11)
+	screen.Fill(0, 0, 30, 11, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+	before := screen.Snapshot()
+
+	d := widgets.Confirm(screen, "Quit", "Discard changes?", nil)
+	if got, want := d.Rect(), (goterm.Rect{X: 4, Y: 2, W: 22, H: 6}); got != want {
+		t.Fatalf("Rect() = %+v, want %+v", got, want)
+	}
+
+	// The dialog is drawn on a layer, so it outlives the screen being
+	// cleared and redrawn underneath
+	screen.Clear()
+	screen.Fill(0, 0, 30, 11, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+	shown := screen.Composite()
+	want := []string{
+		"┌─────── Quit ───────┐",
+		"│                    │",
+		"│ Discard changes?   │",
+		"│                    │",
+		"│ [ OK ]  [ Cancel ] │",
+		"└────────────────────┘",
+	}
+	for y, row := range want {
+		if got := rowText(shown, 4, 2+y, 22); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+	if !shown.GetCell(6, 6).Style.Has(goterm.StyleReverse) {
+		t.Error("focused button not highlighted")
+	}
+
+	if got := shown.GetCell(26, 3); got == before.GetCell(26, 3) {
+		t.Error("no shadow drawn beside the dialog")
+	}
+
+	// Closing reveals the screen as it is now, not as it was at Open
+	screen.SetCell(5, 3, goterm.NewCell('x', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+	d.Close()
+	shown = screen.Composite()
+	for y := 0; y < 11; y++ {
+		want := rowText(before, 0, y, 30)
+		if y == 3 {
+			want = ".....x" + want[6:]
+		}
+		if got := rowText(shown, 0, y, 30); got != want {
+			t.Errorf("row %d after Close = %q, want %q", y, got, want)
+		}
+	}
+	if got := shown.GetCell(26, 3); got != before.GetCell(26, 3) {
+		t.Errorf("shadow left after Close: %+v", got)
+	}
+}
+
+func TestDialogInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []goterm.Event
+		want   int
+	}{
+		{"enter", []goterm.Event{key(goterm.KeyEnter)}, 0},
+		{"right_enter", []goterm.Event{key(goterm.KeyRight), key(goterm.KeyEnter)}, 1},
+		{"tab_wraps", []goterm.Event{key(goterm.KeyTab), key(goterm.KeyTab), key(goterm.KeyEnter)}, 0},
+		{"escape", []goterm.Event{key(goterm.KeyEscape)}, -1},
+		{"click_cancel", []goterm.Event{click(0, 0), click(16, 6)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(30, 11)
+			pressed := -2
+			d := &widgets.Dialog{Message: "Discard changes?", Buttons: []string{"OK", "Cancel"}}
+			d.OnClose = func(button int) { pressed = button }
+			d.Open(screen)
+
+			for _, ev := range tt.events {
+				if !d.HandleEvent(ev) {
+					t.Errorf("HandleEvent(%v) not used by open dialog", ev)
+				}
+			}
+			if pressed != tt.want {
+				t.Errorf("OnClose called with %d, want %d", pressed, tt.want)
+			}
+			if d.IsOpen() {
+				t.Error("dialog still open")
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestFloodFill(t *testing.T) {
+	wall := goterm.NewCell('#', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	paint := goterm.NewCell('~', goterm.ColorBlue, goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name string
+		x, y int
+		clip *goterm.Rect
+		want []string
+	}{
+		{"enclosed_room", 2, 1, nil, []string{
+			"#####...",
+			"#~~~#...",
+			"#~###...",
+			"#~#.....",
+			"###.....",
+		}},
+		{"outside_wraps_around", 6, 0, nil, []string{
+			"#####~~~",
+			"#   #~~~",
+			"# ###~~~",
+			"# #~~~~~",
+			"###~~~~~",
+		}},
+		{"wall_only", 0, 0, nil, []string{
+			"~~~~~...",
+			"~   ~...",
+			"~ ~~~...",
+			"~ ~.....",
+			"~~~.....",
+		}},
+		{"clipped", 6, 0, &goterm.Rect{X: 5, Y: 0, W: 3, H: 2}, []string{
+			"#####~~~",
+			"#   #~~~",
+			"# ###...",
+			"# #.....",
+			"###.....",
+		}},
+		{"out_of_bounds", 9, 9, nil, []string{
+			"#####...",
+			"#   #...",
+			"# ###...",
+			"# #.....",
+			"###.....",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(8, 5)
+			layout := []string{"#####...", "#   #...", "# ###...", "# #.....", "###....."}
+			for y, row := range layout {
+				for x, ch := range row {
+					cell := goterm.NewCell(ch, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+					if ch == '#' {
+						cell = wall
+					}
+					screen.SetCell(x, y, cell)
+				}
+			}
+
+			if tt.clip != nil {
+				screen.PushClip(*tt.clip)
+			}
+			screen.FloodFill(tt.x, tt.y, paint)
+
+			for y, want := range tt.want {
+				if got := rowText(screen, 0, y, 8); got != want {
+					t.Errorf("row %d = %q, want %q", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFloodFillSameCell(t *testing.T) {
+	screen := goterm.NewScreen(3, 1)
+	blank := goterm.NewCell(' ', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	screen.FloodFill(1, 0, blank)
+
+	if got := rowText(screen, 0, 0, 3); got != "   " {
+		t.Errorf("row = %q, want unchanged", got)
+	}
+}
@@ -0,0 +1,157 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestFocusManager(t *testing.T) {
+	a := &widgets.Checkbox{Label: "a"}
+	b := &widgets.Checkbox{Label: "b"}
+	c := &widgets.Checkbox{Label: "c"}
+	m := widgets.NewFocusManager(a, b, c)
+
+	var changes []string
+	name := func(f widgets.Focusable) string {
+		if f == nil {
+			return "-"
+		}
+		return f.(*widgets.Checkbox).Label
+	}
+	m.OnFocusChange = func(prev, next widgets.Focusable) {
+		changes = append(changes, name(prev)+">"+name(next))
+	}
+	focused := func(want *widgets.Checkbox) {
+		t.Helper()
+		if m.Focused() != want {
+			t.Fatalf("Focused() = %s, want %s", name(m.Focused()), want.Label)
+		}
+		for _, box := range []*widgets.Checkbox{a, b, c} {
+			if box.Focused() != (box == want) {
+				t.Errorf("%s.Focused() = %v", box.Label, box.Focused())
+			}
+		}
+	}
+
+	focused(a)
+	if !m.HandleEvent(key(goterm.KeyTab)) {
+		t.Error("Tab not handled")
+	}
+	focused(b)
+	m.HandleEvent(runeKey(' '))
+	if !b.Checked || a.Checked {
+		t.Errorf("Space checked a=%v b=%v, want only b", a.Checked, b.Checked)
+	}
+	m.HandleEvent(key(goterm.KeyTab))
+	m.HandleEvent(key(goterm.KeyTab))
+	focused(a)
+	m.HandleEvent(shiftKey(goterm.KeyTab))
+	focused(c)
+
+	if m.HandleEvent(runeKey('x')) {
+		t.Error("key the focused widget does not use reported as handled")
+	}
+	if m.HandleEvent(click(0, 0)) {
+		t.Error("mouse event handled")
+	}
+	if m.HandleEvent(goterm.KeyEvent{Key: goterm.KeyTab, Modifiers: goterm.ModCtrl}) {
+		t.Error("Ctrl+Tab handled")
+	}
+
+	m.Remove(c)
+	focused(a)
+	if !m.Focus(b) || m.Focus(c) {
+		t.Error("Focus() reported wrong membership")
+	}
+	focused(b)
+	m.Remove(a)
+	focused(b)
+	m.Remove(b)
+	if m.Focused() != nil {
+		t.Errorf("Focused() = %s after removing every widget", name(m.Focused()))
+	}
+
+	want := []string{"a>b", "b>c", "c>a", "a>c", "c>a", "a>b", "b>-"}
+	if len(changes) != len(want) {
+		t.Fatalf("OnFocusChange calls = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("OnFocusChange calls = %v, want %v", changes, want)
+			break
+		}
+	}
+}
+
+func TestFocusManagerTextAreaAndList(t *testing.T) {
+	area := &widgets.TextArea{}
+	list := &widgets.List{Items: []string{"one", "two"}}
+	m := widgets.NewFocusManager(area, list)
+	areaBuf, listBuf := goterm.NewBuffer(5, 1), goterm.NewBuffer(5, 2)
+	draw := func() {
+		area.Draw(areaBuf)
+		list.Draw(listBuf)
+	}
+	// The cursor follows the typed "x"; the list cursor starts on the first
+	// row. Both are always drawn, dimmed while the other widget has the focus.
+	shown := func(c goterm.Cell) bool {
+		return c.Style.Has(goterm.StyleReverse) && !c.Style.Has(goterm.StyleDim)
+	}
+	cursorShown := func() bool { return shown(areaBuf.GetCell(1, 0)) }
+	currentShown := func() bool { return shown(listBuf.GetCell(0, list.Cursor())) }
+	currentDimmed := func() bool {
+		style := listBuf.GetCell(0, list.Cursor()).Style
+		return style.Has(goterm.StyleReverse) && style.Has(goterm.StyleDim)
+	}
+
+	m.HandleEvent(runeKey('x'))
+	draw()
+	if area.Text() != "x" || !area.Focused() || list.Focused() {
+		t.Fatalf("text area got %q, focused %v; list focused %v", area.Text(), area.Focused(), list.Focused())
+	}
+	if !cursorShown() || currentShown() || !currentDimmed() {
+		t.Error("want the text area cursor drawn and the list highlight dimmed")
+	}
+
+	m.HandleEvent(key(goterm.KeyTab))
+	m.HandleEvent(key(goterm.KeyDown))
+	draw()
+	if m.Focused() != widgets.Focusable(list) || list.Cursor() != 1 || area.Text() != "x" {
+		t.Fatalf("after Tab: list cursor %d, text %q", list.Cursor(), area.Text())
+	}
+	if cursorShown() || !currentShown() {
+		t.Error("want the list highlight drawn and the text area cursor dimmed")
+	}
+
+	m.HandleEvent(key(goterm.KeyTab))
+	draw()
+	if !area.Focused() || list.Focused() || !cursorShown() || currentShown() {
+		t.Errorf("Tab did not wrap around to the text area")
+	}
+}
+
+func TestUnmanagedWidgetsShowCursor(t *testing.T) {
+	// Without a FocusManager the cursors are drawn in full, as keys move them
+	area := &widgets.TextArea{}
+	list := &widgets.List{Items: []string{"one", "two"}}
+	area.HandleEvent(runeKey('x'))
+	list.HandleEvent(key(goterm.KeyDown))
+	areaBuf, listBuf := goterm.NewBuffer(5, 1), goterm.NewBuffer(5, 2)
+	area.Draw(areaBuf)
+	list.Draw(listBuf)
+	for name, c := range map[string]goterm.Cell{"text area": areaBuf.GetCell(1, 0), "list": listBuf.GetCell(0, 1)} {
+		if !c.Style.Has(goterm.StyleReverse) || c.Style.Has(goterm.StyleDim) {
+			t.Errorf("%s cursor style = %v, want reverse video", name, c.Style)
+		}
+	}
+
+	// Once removed from a FocusManager, they are drawn in full again
+	m := widgets.NewFocusManager(area, list)
+	m.Remove(list)
+	list.Draw(listBuf)
+	if c := listBuf.GetCell(0, 1); c.Style.Has(goterm.StyleDim) {
+		t.Errorf("list cursor dimmed after Remove: %v", c.Style)
+	}
+}
@@ -0,0 +1,39 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestScreenForEach(t *testing.T) {
+	screen := goterm.NewScreen(5, 3)
+	screen.DrawText(0, 1, "abcde", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	var visited [][2]int
+	screen.ForEach(goterm.Rect{X: 3, Y: 1, W: 4, H: 4}, func(x, y int, c goterm.Cell) goterm.Cell {
+		visited = append(visited, [2]int{x, y})
+		c.Style = c.Style.Set(goterm.StyleDim)
+		return c
+	})
+
+	want := [][2]int{{3, 1}, {4, 1}, {3, 2}, {4, 2}}
+	if len(visited) != len(want) {
+		t.Fatalf("visited %v, want %v", visited, want)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Errorf("visit %d = %v, want %v", i, visited[i], want[i])
+		}
+	}
+
+	for x := 0; x < 5; x++ {
+		cell := screen.GetCell(x, 1)
+		if dim := cell.Style.Has(goterm.StyleDim); dim != (x >= 3) {
+			t.Errorf("cell %d dimmed = %v, want %v", x, dim, x >= 3)
+		}
+		if cell.Ch != rune('a'+x) {
+			t.Errorf("cell %d = %q, want content kept", x, cell.Ch)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestGradientAt(t *testing.T) {
+	red := goterm.ColorRGB(255, 0, 0)
+	green := goterm.ColorRGB(0, 255, 0)
+	blue := goterm.ColorRGB(0, 0, 255)
+	even := goterm.NewGradient(red, green, blue)
+	stops := goterm.NewGradientStops(
+		goterm.ColorStop{Pos: 0.75, Color: blue},
+		goterm.ColorStop{Pos: 0.25, Color: red},
+	)
+
+	tests := []struct {
+		name string
+		g    goterm.Gradient
+		t    float64
+		want goterm.Color
+	}{
+		{"start", even, 0, red},
+		{"middle_stop", even, 0.5, green},
+		{"end", even, 1, blue},
+		{"between", even, 0.25, goterm.ColorRGB(128, 128, 0)},
+		{"before_first", even, -1, red},
+		{"after_last", even, 2, blue},
+		{"stops_sorted", stops, 0.5, goterm.ColorRGB(128, 0, 128)},
+		{"before_first_stop", stops, 0.1, red},
+		{"after_last_stop", stops, 0.9, blue},
+		{"single", goterm.NewGradient(green), 0.7, green},
+		{"empty", goterm.Gradient{}, 0.5, goterm.ColorDefault()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g.At(tt.t); got != tt.want {
+				t.Errorf("At(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGradientColors(t *testing.T) {
+	g := goterm.NewGradient(goterm.ColorRGB(0, 0, 0), goterm.ColorRGB(200, 200, 200))
+	colors := g.Colors(5)
+
+	want := []uint8{0, 50, 100, 150, 200}
+	if len(colors) != len(want) {
+		t.Fatalf("Colors(5) returned %d colors", len(colors))
+	}
+	for i, v := range want {
+		if r, _, _ := colors[i].RGB(); r != v {
+			t.Errorf("color %d red = %d, want %d", i, r, v)
+		}
+	}
+	if got := g.Colors(0); len(got) != 0 {
+		t.Errorf("Colors(0) = %v, want empty", got)
+	}
+}
+
+func TestFillGradient(t *testing.T) {
+	g := goterm.NewGradient(goterm.ColorRGB(0, 0, 0), goterm.ColorRGB(255, 255, 255))
+
+	tests := []struct {
+		name        string
+		orientation goterm.Orientation
+		rect        goterm.Rect
+		step        func(x, y int) int
+	}{
+		{"horizontal", goterm.Horizontal, goterm.Rect{X: 1, Y: 0, W: 3, H: 2}, func(x, _ int) int { return x - 1 }},
+		{"vertical", goterm.Vertical, goterm.Rect{X: 0, Y: 0, W: 2, H: 3}, func(_, y int) int { return y }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(4, 3)
+			screen.DrawText(0, 0, "abcd", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold)
+			screen.FillGradient(tt.rect, g, tt.orientation)
+
+			levels := []uint8{0, 128, 255}
+			for y := 0; y < 3; y++ {
+				for x := 0; x < 4; x++ {
+					cell := screen.GetCell(x, y)
+					if !tt.rect.Contains(x, y) {
+						if cell.Bg != goterm.ColorDefault() {
+							t.Errorf("cell (%d, %d) colored outside rect", x, y)
+						}
+						continue
+					}
+					if r, _, _ := cell.Bg.RGB(); r != levels[tt.step(x, y)] {
+						t.Errorf("cell (%d, %d) bg = %d, want %d", x, y, r, levels[tt.step(x, y)])
+					}
+					if y == 0 && (cell.Ch != rune('a'+x) || cell.Fg != goterm.ColorRed) {
+						t.Errorf("cell (%d, %d) = %+v, want content kept", x, y, cell)
+					}
+				}
+			}
+		})
+	}
+}
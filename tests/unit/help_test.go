@@ -0,0 +1,167 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+// helpKeymap returns a keymap with three groups of bindings
+func helpKeymap(t *testing.T) *goterm.Keymap {
+	t.Helper()
+	m := &goterm.Keymap{}
+	for _, b := range []struct {
+		group, help string
+		keys        []string
+	}{
+		{"Move", "Up", []string{"k", "up"}},
+		{"Move", "Down", []string{"j"}},
+		{"File", "Save", []string{"ctrl+s"}},
+		{"File", "", []string{"ctrl+z"}},
+		{"App", "Quit", []string{"q"}},
+	} {
+		if err := m.Bind(b.group, b.help, nil, b.keys...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestHelpOverlayLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		w, h int
+		want []string
+	}{
+		{"one_column", 30, 16, []string{
+			"┌──── Help ────┐",
+			"│              │",
+			"│ Move         │",
+			"│ k, Up  Up    │",
+			"│ j      Down  │",
+			"│              │",
+			"│ File         │",
+			"│ Ctrl+S  Save │",
+			"│              │",
+			"│ App          │",
+			"│ q  Quit      │",
+			"│              │",
+			"└──────────────┘",
+		}},
+		{"two_columns", 40, 11, []string{
+			"┌─────────── Help ───────────┐",
+			"│                            │",
+			"│ Move          File         │",
+			"│ k, Up  Up     Ctrl+S  Save │",
+			"│ j      Down                │",
+			"│               App          │",
+			"│               q  Quit      │",
+			"│                            │",
+			"└────────────────────────────┘",
+		}},
+		{"too_narrow", 40, 9, []string{
+			"┌────────────── Help ──────────────┐",
+			"│                                  │",
+			"│ Move          File               │",
+			"│ k, Up  Up     Ctrl+S  Save       │",
+			"│ j      Down                      │",
+			"│                                  │",
+			"└──────────────────────────────────┘",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(tt.w, tt.h)
+			o := &widgets.HelpOverlay{Keymap: helpKeymap(t)}
+			o.Open(screen)
+			screen.Clear()
+			r := o.Rect()
+			if r.H != len(tt.want) {
+				t.Fatalf("Rect() = %+v, want height %d", r, len(tt.want))
+			}
+			shown := screen.Composite()
+			for y, row := range tt.want {
+				if got := rowText(shown, r.X, r.Y+y, r.W); got != row {
+					t.Errorf("row %d = %q, want %q", y, got, row)
+				}
+			}
+		})
+	}
+}
+
+func TestHelpOverlayClose(t *testing.T) {
+	tests := []struct {
+		name  string
+		ev    goterm.Event
+		close bool
+	}{
+		{"escape", key(goterm.KeyEscape), true},
+		{"question", runeKey('?'), true},
+		{"q", runeKey('q'), true},
+		{"click", click(0, 0), true},
+		{"other_key", runeKey('x'), false},
+		{"wheel", goterm.MouseEvent{Button: goterm.MouseWheelDown, Action: goterm.MouseScroll}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(30, 14)
+			screen.Fill(0, 0, 30, 14, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			closed := false
+			o := &widgets.HelpOverlay{Keymap: helpKeymap(t), Shadow: true, OnClose: func() { closed = true }}
+			o.Open(screen)
+			if !o.HandleEvent(tt.ev) {
+				t.Error("HandleEvent() = false while open")
+			}
+			if closed != tt.close || o.IsOpen() == tt.close {
+				t.Errorf("closed = %v, IsOpen() = %v, want closed %v", closed, o.IsOpen(), tt.close)
+			}
+			shown := screen.Composite()
+			if tt.close && rowText(shown, 0, 7, 30) != strings.Repeat(".", 30) {
+				t.Errorf("screen not revealed: %q", rowText(shown, 0, 7, 30))
+			}
+			if !tt.close && rowText(shown, 0, 7, 30) == strings.Repeat(".", 30) {
+				t.Error("overlay not shown")
+			}
+		})
+	}
+}
+
+func TestKeymapHelpText(t *testing.T) {
+	want := "Move\n  k, Up  Up\n  j      Down\n\nFile\n  Ctrl+S  Save\n\nApp\n  q  Quit\n"
+	if got := helpKeymap(t).HelpText(); got != want {
+		t.Errorf("HelpText() = %q, want %q", got, want)
+	}
+}
+
+func TestKeyHints(t *testing.T) {
+	m := helpKeymap(t)
+	hints := widgets.KeyHints{Keymap: m}
+	tests := []struct {
+		name  string
+		short bool
+		w     int
+		want  string
+	}{
+		{"help_all", false, 40, "k Up  j Down  Ctrl+S Save  q Quit"},
+		{"help_cut", false, 20, "k Up  j Down"},
+		{"short_only", true, 40, "Ctrl+S save  q quit"},
+		{"none_fit", true, 5, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.short {
+				m.Bindings[2].Short, m.Bindings[4].Short = "save", "quit"
+			}
+			buf := goterm.NewBuffer(tt.w, 1)
+			hints.Draw(buf)
+			if got := strings.TrimRight(rowText(buf, 0, 0, tt.w), " "); got != tt.want {
+				t.Errorf("Draw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if w, h := hints.PreferredSize(80, 5); w != len("Ctrl+S save  q quit") || h != 1 {
+		t.Errorf("PreferredSize() = %d×%d", w, h)
+	}
+}
@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestImageCells(t *testing.T) {
+	// Red over blue on the left, green over transparent on the right
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			switch {
+			case x < 2 && y < 2:
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			case x < 2:
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			case y < 2:
+				img.Set(x, y, color.RGBA{G: 255, A: 255})
+			}
+		}
+	}
+	red, green, blue := goterm.ColorRGB(255, 0, 0), goterm.ColorRGB(0, 255, 0), goterm.ColorRGB(0, 0, 255)
+
+	screen := goterm.NewScreen(4, 2)
+	screen.DrawText(0, 0, "abcd", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	im := screen.AddImage(img, goterm.Rect{X: 1, Y: 0, W: 2, H: 1})
+	b := screen.Composite()
+	if c := b.GetCell(1, 0); c.Ch != '▀' || c.Fg != red || c.Bg != blue {
+		t.Errorf("left image cell = %q fg %v bg %v, want red over blue", c.Ch, c.Fg, c.Bg)
+	}
+	if c := b.GetCell(2, 0); c.Ch != '▀' || c.Fg != green {
+		t.Errorf("right image cell = %q fg %v, want green on top", c.Ch, c.Fg)
+	}
+	if got := rowText(b, 0, 0, 4); got != "a▀▀d" {
+		t.Errorf("row = %q, want the image between a and d", got)
+	}
+
+	layer := screen.AddLayer(1)
+	layer.DrawText(1, 0, "X", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	if got := rowText(screen.Composite(), 0, 0, 4); got != "aX▀d" {
+		t.Errorf("row under a layer = %q, want the layer over the image", got)
+	}
+
+	im.SetVisible(false)
+	if got := rowText(screen.Composite(), 0, 0, 4); got != "aXcd" {
+		t.Errorf("hidden image row = %q", got)
+	}
+	im.SetVisible(true)
+	im.SetRect(goterm.Rect{X: 3, Y: 1, W: 2, H: 1})
+	if got := rowText(screen.Composite(), 3, 1, 1); got != "▀" {
+		t.Errorf("image moved partly off screen = %q", got)
+	}
+	screen.RemoveImage(im)
+	if got := rowText(screen.Composite(), 3, 1, 1); got != " " {
+		t.Errorf("removed image still drawn: %q", got)
+	}
+}
+
+func TestDetectGraphicsProtocol(t *testing.T) {
+	tests := []struct {
+		name                          string
+		term, termProgram, lcTerminal string
+		tmux                          string
+		want                          goterm.GraphicsProtocol
+	}{
+		{"kitty", "xterm-kitty", "", "", "", goterm.GraphicsKitty},
+		{"ghostty", "xterm-ghostty", "ghostty", "", "", goterm.GraphicsKitty},
+		{"wezterm", "xterm-256color", "WezTerm", "", "", goterm.GraphicsKitty},
+		{"iterm2", "xterm-256color", "iTerm.app", "iTerm2", "", goterm.GraphicsITerm2},
+		{"iterm2_ssh", "xterm-256color", "", "iTerm2", "", goterm.GraphicsITerm2},
+		{"tmux", "screen-256color", "iTerm.app", "iTerm2", "/tmp/tmux-0/default", goterm.GraphicsCells},
+		{"xterm", "xterm-256color", "", "", "", goterm.GraphicsCells},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			t.Setenv("TERM_PROGRAM", tt.termProgram)
+			t.Setenv("LC_TERMINAL", tt.lcTerminal)
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("KITTY_WINDOW_ID", "")
+			if got := goterm.DetectGraphicsProtocol(); got != tt.want {
+				t.Errorf("DetectGraphicsProtocol() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// mods returns k with modifiers m
+func mods(k goterm.KeyEvent, m goterm.Modifier) goterm.KeyEvent {
+	k.Modifiers = m
+	return k
+}
+
+func TestInputDecoder(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []goterm.Event
+	}{
+		{"text", "hé世", []goterm.Event{runeKey('h'), runeKey('é'), runeKey('世')}},
+		{"controls", "\r\t\x7f\x03\x00", []goterm.Event{
+			key(goterm.KeyEnter), key(goterm.KeyTab), key(goterm.KeyBackspace),
+			ctrlKey('c'), mods(runeKey(' '), goterm.ModCtrl),
+		}},
+		{"arrows", "\x1b[A\x1bOB\x1b[1;5C\x1b[1;2D", []goterm.Event{
+			key(goterm.KeyUp), key(goterm.KeyDown),
+			mods(key(goterm.KeyRight), goterm.ModCtrl), shiftKey(goterm.KeyLeft),
+		}},
+		{"editing", "\x1b[H\x1b[4~\x1b[5~\x1b[6;3~\x1b[3~\x1b[2~", []goterm.Event{
+			key(goterm.KeyHome), key(goterm.KeyEnd), key(goterm.KeyPageUp),
+			mods(key(goterm.KeyPageDown), goterm.ModAlt), key(goterm.KeyDelete), key(goterm.KeyInsert),
+		}},
+		{"function keys", "\x1bOP\x1b[15~\x1b[24;5~", []goterm.Event{
+			key(goterm.KeyF1), key(goterm.KeyF5), mods(key(goterm.KeyF12), goterm.ModCtrl),
+		}},
+		{"shift tab", "\x1b[Z", []goterm.Event{shiftKey(goterm.KeyTab)}},
+		{"alt", "\x1bx\x1b\x1b[A", []goterm.Event{
+			mods(runeKey('x'), goterm.ModAlt), mods(key(goterm.KeyUp), goterm.ModAlt),
+		}},
+		{"sgr mouse", "\x1b[<0;5;3M\x1b[<0;5;3m\x1b[<32;6;3M\x1b[<65;1;1M\x1b[<18;2;2M", []goterm.Event{
+			goterm.MouseEvent{X: 4, Y: 2, Button: goterm.MouseLeft, Action: goterm.MousePress},
+			goterm.MouseEvent{X: 4, Y: 2, Button: goterm.MouseLeft, Action: goterm.MouseRelease},
+			goterm.MouseEvent{X: 5, Y: 2, Button: goterm.MouseLeft, Action: goterm.MouseMotion},
+			goterm.MouseEvent{X: 0, Y: 0, Button: goterm.MouseWheelDown, Action: goterm.MouseScroll},
+			goterm.MouseEvent{X: 1, Y: 1, Button: goterm.MouseRight, Modifiers: goterm.ModCtrl, Action: goterm.MousePress},
+		}},
+		{"x10 mouse", "\x1b[M !\"", []goterm.Event{
+			goterm.MouseEvent{X: 0, Y: 1, Button: goterm.MouseLeft, Action: goterm.MousePress},
+		}},
+		{"paste", "a\x1b[200~x\r\ny\x1b[Az\x1b[201~b", []goterm.Event{
+			runeKey('a'), goterm.PasteEvent{Text: "x\ny\x1b[Az"}, runeKey('b'),
+		}},
+		{"unknown sequence", "\x1b[99xq", []goterm.Event{runeKey('q')}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d goterm.InputDecoder
+			got := d.Feed([]byte(tt.input))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Feed(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			if d.Pending() {
+				t.Errorf("Pending() = true after complete input")
+			}
+
+			// Input split anywhere decodes the same
+			var split goterm.InputDecoder
+			var events []goterm.Event
+			for i := range len(tt.input) {
+				events = append(events, split.Feed([]byte{tt.input[i]})...)
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("Feed byte by byte = %+v, want %+v", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputDecoderFlush(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []goterm.Event
+	}{
+		{"escape", "\x1b", []goterm.Event{key(goterm.KeyEscape)}},
+		{"double escape", "\x1b\x1b", []goterm.Event{key(goterm.KeyEscape), key(goterm.KeyEscape)}},
+		{"alt bracket", "\x1b[", []goterm.Event{mods(runeKey('['), goterm.ModAlt)}},
+		{"unfinished paste", "\x1b[200~abc", []goterm.Event{goterm.PasteEvent{Text: "abc"}}},
+		{"partial rune", "\xe4\xb8", []goterm.Event{runeKey('�'), runeKey('�')}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d goterm.InputDecoder
+			if got := d.Feed([]byte(tt.input)); len(got) != 0 {
+				t.Errorf("Feed(%q) = %+v, want no events before Flush", tt.input, got)
+			}
+			if !d.Pending() {
+				t.Errorf("Pending() = false, want true")
+			}
+			if got := d.Flush(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flush() = %+v, want %+v", got, tt.want)
+			}
+			if d.Pending() {
+				t.Errorf("Pending() = true after Flush")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want goterm.KeyEvent
+		str  string
+	}{
+		{"q", goterm.KeyEvent{Key: goterm.KeyRune, Rune: 'q'}, "q"},
+		{"G", goterm.KeyEvent{Key: goterm.KeyRune, Rune: 'G'}, "G"},
+		{"ctrl+c", goterm.KeyEvent{Key: goterm.KeyRune, Rune: 'c', Modifiers: goterm.ModCtrl}, "Ctrl+C"},
+		{"Ctrl-X", goterm.KeyEvent{Key: goterm.KeyRune, Rune: 'x', Modifiers: goterm.ModCtrl}, "Ctrl+X"},
+		{"shift+tab", goterm.KeyEvent{Key: goterm.KeyTab, Modifiers: goterm.ModShift}, "Shift+Tab"},
+		{"alt+enter", goterm.KeyEvent{Key: goterm.KeyEnter, Modifiers: goterm.ModAlt}, "Alt+Enter"},
+		{"pagedown", goterm.KeyEvent{Key: goterm.KeyPageDown}, "PgDn"},
+		{"escape", goterm.KeyEvent{Key: goterm.KeyEscape}, "Esc"},
+		{"F12", goterm.KeyEvent{Key: goterm.KeyF12}, "F12"},
+		{"space", goterm.KeyEvent{Key: goterm.KeyRune, Rune: ' '}, "Space"},
+		{"+", goterm.KeyEvent{Key: goterm.KeyRune, Rune: '+'}, "+"},
+		{"ctrl++", goterm.KeyEvent{Key: goterm.KeyRune, Rune: '+', Modifiers: goterm.ModCtrl}, "Ctrl++"},
+		{"-", goterm.KeyEvent{Key: goterm.KeyRune, Rune: '-'}, "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goterm.ParseKey(tt.name)
+			if err != nil {
+				t.Fatalf("ParseKey(%q) error: %v", tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseKey(%q) = %+v, want %+v", tt.name, got, tt.want)
+			}
+			if s := got.String(); s != tt.str {
+				t.Errorf("String() = %q, want %q", s, tt.str)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "hyper+x", "nokey", "ctrl+"} {
+		if _, err := goterm.ParseKey(bad); !errors.Is(err, goterm.ErrInvalidKey) {
+			t.Errorf("ParseKey(%q) error = %v, want ErrInvalidKey", bad, err)
+		}
+	}
+}
+
+func TestKeymap(t *testing.T) {
+	var m goterm.Keymap
+	var ran []string
+	bind := func(group, help string, keys ...string) {
+		t.Helper()
+		if err := m.Bind(group, help, func() { ran = append(ran, help) }, keys...); err != nil {
+			t.Fatalf("Bind(%v) error: %v", keys, err)
+		}
+	}
+	bind("Navigation", "Down", "j", "down")
+	bind("General", "Quit", "q", "ctrl+c")
+	bind("Navigation", "Help", "?")
+	m.Bindings = append(m.Bindings, goterm.KeyBinding{Keys: []goterm.KeyEvent{{Key: goterm.KeyF5}}, Group: "Hidden"})
+
+	if err := m.Bind("", "bad", nil, "x", "nokey"); !errors.Is(err, goterm.ErrInvalidKey) || len(m.Bindings) != 4 {
+		t.Errorf("Bind with a bad key: error %v, %d bindings", err, len(m.Bindings))
+	}
+
+	events := []goterm.Event{
+		key(goterm.KeyDown),
+		ctrlKey('c'),
+		goterm.KeyEvent{Key: goterm.KeyRune, Rune: '?', Modifiers: goterm.ModShift},
+	}
+	for _, ev := range events {
+		if !m.HandleEvent(ev) {
+			t.Errorf("HandleEvent(%+v) = false", ev)
+		}
+	}
+	for _, ev := range []goterm.Event{runeKey('x'), key(goterm.KeyF5), goterm.KeyEvent{Key: goterm.KeyRune, Rune: 'q', Modifiers: goterm.ModAlt}} {
+		if m.HandleEvent(ev) {
+			t.Errorf("HandleEvent(%+v) = true", ev)
+		}
+	}
+	if want := []string{"Down", "Quit", "Help"}; len(ran) != 3 || ran[0] != want[0] || ran[1] != want[1] || ran[2] != want[2] {
+		t.Errorf("actions run = %v, want %v", ran, want)
+	}
+
+	if got := m.Groups(); len(got) != 2 || got[0] != "Navigation" || got[1] != "General" {
+		t.Errorf("Groups() = %v", got)
+	}
+	if got := m.Lookup(goterm.KeyEvent{Key: goterm.KeyRune, Rune: 'j'}).KeysString(); got != "j, Down" {
+		t.Errorf("KeysString() = %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestColorDistanceTo(t *testing.T) {
+	black := goterm.ColorRGB(0, 0, 0)
+	white := goterm.ColorRGB(255, 255, 255)
+	red := goterm.ColorRGB(255, 0, 0)
+
+	tests := []struct {
+		name string
+		a, b goterm.Color
+		want float64
+	}{
+		{"identical", red, red, 0},
+		{"black_white", black, white, 100},
+		{"palette_equals_rgb", goterm.ColorIndex(196), red, 0},
+		{"red_blue", red, goterm.ColorRGB(0, 0, 255), 52.88},
+		{"default_self", goterm.ColorDefault(), goterm.ColorDefault(), 0},
+		{"default_other", goterm.ColorDefault(), red, math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.a.DistanceTo(tt.b)
+			if math.IsInf(tt.want, 1) {
+				if !math.IsInf(got, 1) {
+					t.Errorf("DistanceTo() = %v, want +Inf", got)
+				}
+				return
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("DistanceTo() = %.3f, want %.2f", got, tt.want)
+			}
+			if back := tt.b.DistanceTo(tt.a); math.Abs(back-got) > 1e-9 {
+				t.Errorf("DistanceTo() not symmetric: %v vs %v", got, back)
+			}
+		})
+	}
+
+	// Orange is perceptually closer to red than to blue
+	orange := goterm.ColorRGB(255, 128, 0)
+	if orange.DistanceTo(red) >= orange.DistanceTo(goterm.ColorRGB(0, 0, 255)) {
+		t.Error("orange should be closer to red than to blue")
+	}
+}
@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestLayerCompositing(t *testing.T) {
+	screen := goterm.NewScreen(6, 2)
+	screen.DrawText(0, 0, "abcdef", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	popup := screen.AddLayer(10)
+	popup.DrawText(1, 0, "XY", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone)
+	ui := screen.AddLayer(5)
+	ui.DrawText(2, 0, "123", goterm.ColorBlue, goterm.ColorDefault(), goterm.StyleNone)
+
+	// The higher layer wins where layers overlap; undrawn cells show through
+	if got := rowText(screen.Composite(), 0, 0, 6); got != "aXY23f" {
+		t.Errorf("composite = %q, want %q", got, "aXY23f")
+	}
+	if got := rowText(screen, 0, 0, 6); got != "abcdef" {
+		t.Errorf("screen content = %q, want it unchanged", got)
+	}
+	if got := screen.Composite().GetCell(1, 0).Fg; got != goterm.ColorRed {
+		t.Errorf("composite fg = %v, want popup color", got)
+	}
+
+	popup.SetVisible(false)
+	if got := rowText(screen.Composite(), 0, 0, 6); got != "ab123f" {
+		t.Errorf("hidden popup composite = %q, want %q", got, "ab123f")
+	}
+
+	popup.SetVisible(true)
+	popup.SetZ(1)
+	if popup.Z() != 1 || !popup.Visible() {
+		t.Errorf("Z() = %d, Visible() = %v, want 1, true", popup.Z(), popup.Visible())
+	}
+	if got := rowText(screen.Composite(), 0, 0, 6); got != "aX123f" {
+		t.Errorf("reordered composite = %q, want %q", got, "aX123f")
+	}
+
+	ui.Clear()
+	screen.RemoveLayer(popup)
+	if got := rowText(screen.Composite(), 0, 0, 6); got != "abcdef" {
+		t.Errorf("composite after clear/remove = %q, want %q", got, "abcdef")
+	}
+}
+
+func TestLayerWideCharacters(t *testing.T) {
+	screen := goterm.NewScreen(6, 1)
+	screen.DrawText(0, 0, "世界", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	layer := screen.AddLayer(1)
+	layer.SetCell(1, 0, goterm.NewCell('x', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+	layer.DrawText(4, 0, "界", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	// The half-covered wide character below is blanked
+	if got := rowText(screen.Composite(), 0, 0, 6); got != " x界 界 " {
+		t.Errorf("composite = %q, want %q", got, " x界 界 ")
+	}
+}
+
+func TestLayerResize(t *testing.T) {
+	screen := goterm.NewScreen(4, 2)
+	layer := screen.AddLayer(0)
+	screen.Resize(8, 3)
+
+	if w, h := layer.Size(); w != 8 || h != 3 {
+		t.Errorf("layer Size() = (%d, %d), want (8, 3)", w, h)
+	}
+	layer.SetCell(7, 2, goterm.NewCell('z', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+	if got := screen.Composite().GetCell(7, 2).Ch; got != 'z' {
+		t.Errorf("composite (7, 2) = %q, want 'z'", got)
+	}
+}
@@ -0,0 +1,161 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name        string
+		length      int
+		constraints []goterm.Constraint
+		want        []int
+	}{
+		{"fixed_fill", 80, []goterm.Constraint{goterm.Fixed(20), goterm.Fill(1)}, []int{20, 60}},
+		{"percent_rounding", 11, []goterm.Constraint{goterm.Percent(50), goterm.Percent(50)}, []int{5, 6}},
+		{"thirds", 10, []goterm.Constraint{goterm.Percent(33), goterm.Percent(33), goterm.Percent(34)}, []int{3, 3, 4}},
+		{"weights", 10, []goterm.Constraint{goterm.Fill(1), goterm.Fill(2)}, []int{3, 7}},
+		{"equal_fill", 10, []goterm.Constraint{goterm.Fill(1), goterm.Fill(1), goterm.Fill(1)}, []int{3, 3, 4}},
+		{"mixed", 100, []goterm.Constraint{goterm.Fixed(10), goterm.Percent(30), goterm.Fill(1), goterm.Fixed(5)}, []int{10, 30, 55, 5}},
+		{"overflow", 25, []goterm.Constraint{goterm.Fixed(20), goterm.Fixed(10), goterm.Fill(1)}, []int{20, 5, 0}},
+		{"no_fill", 50, []goterm.Constraint{goterm.Fixed(10), goterm.Percent(20)}, []int{10, 10}},
+		{"zero_weight", 10, []goterm.Constraint{goterm.Fill(0), goterm.Fill(1)}, []int{0, 10}},
+		{"negative", 10, []goterm.Constraint{goterm.Fixed(-3), goterm.Fill(1)}, []int{0, 10}},
+		{"empty", 0, []goterm.Constraint{goterm.Percent(50), goterm.Fill(1)}, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols := goterm.SplitH(goterm.Rect{X: 3, Y: 1, W: tt.length, H: 4}, tt.constraints...)
+			rows := goterm.SplitV(goterm.Rect{X: 1, Y: 3, W: 4, H: tt.length}, tt.constraints...)
+			if len(cols) != len(tt.want) || len(rows) != len(tt.want) {
+				t.Fatalf("got %d columns and %d rows, want %d", len(cols), len(rows), len(tt.want))
+			}
+			x, y := 3, 3
+			for i, want := range tt.want {
+				if c := cols[i]; c != (goterm.Rect{X: x, Y: 1, W: want, H: 4}) {
+					t.Errorf("column %d = %+v, want X %d W %d", i, c, x, want)
+				}
+				if r := rows[i]; r != (goterm.Rect{X: 1, Y: y, W: 4, H: want}) {
+					t.Errorf("row %d = %+v, want Y %d H %d", i, r, y, want)
+				}
+				x += want
+				y += want
+			}
+		})
+	}
+}
+
+// sizeRecorder fills its surface with a character and remembers the size it
+// was drawn at
+type sizeRecorder struct {
+	ch   rune
+	w, h int
+}
+
+func (r *sizeRecorder) Draw(s goterm.Surface) {
+	r.w, r.h = s.Size()
+	for y := 0; y < r.h; y++ {
+		for x := 0; x < r.w; x++ {
+			s.SetCell(x, y, goterm.NewCell(r.ch, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+		}
+	}
+}
+
+func TestLayoutResize(t *testing.T) {
+	screen := goterm.NewScreen(10, 4)
+	side, main := &sizeRecorder{ch: 's'}, &sizeRecorder{ch: 'm'}
+	status := &widgets.StatusBar{Left: goterm.StyledText{}.Add("ok", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)}
+	l := screen.AddLayout(func(area goterm.Rect) []goterm.Placement {
+		rows := goterm.SplitV(area, goterm.Fill(1), goterm.Fixed(1))
+		cols := goterm.SplitH(rows[0], goterm.Percent(30), goterm.Fill(1))
+		return []goterm.Placement{{Rect: cols[0], Widget: side}, {Rect: cols[1], Widget: main}, {Rect: rows[1], Widget: status}}
+	})
+	screen.Relayout()
+	if side.w != 3 || side.h != 3 || main.w != 7 {
+		t.Errorf("drawn at %dx%d and %dx%d, want 3x3 and 7x3", side.w, side.h, main.w, main.h)
+	}
+
+	screen.HandleResize(goterm.ResizeEvent{Width: 20, Height: 6})
+	if side.w != 6 || side.h != 5 || main.w != 14 || main.h != 5 {
+		t.Errorf("after resize drawn at %dx%d and %dx%d, want 6x5 and 14x5", side.w, side.h, main.w, main.h)
+	}
+	if got := rowText(screen, 0, 4, 20); got != "ssssssmmmmmmmmmmmmmm" {
+		t.Errorf("row 4 = %q", got)
+	}
+	if got := rowText(screen, 0, 5, 4); got != "ok  " {
+		t.Errorf("status row = %q", got)
+	}
+	if r, ok := l.Rect(main); !ok || r != (goterm.Rect{X: 6, Y: 0, W: 14, H: 5}) {
+		t.Errorf("Rect(main) = %+v, %v", r, ok)
+	}
+	if _, ok := l.Rect(&sizeRecorder{}); ok {
+		t.Error("Rect() found a widget that was not placed")
+	}
+
+	screen.RemoveLayout(l)
+	screen.HandleResize(goterm.ResizeEvent{Width: 8, Height: 3})
+	if side.w != 6 || len(screen.Layouts()) != 0 {
+		t.Errorf("removed layout was laid out again: side %dx%d", side.w, side.h)
+	}
+}
+
+func TestDockLayout(t *testing.T) {
+	menu, status, left, right, center := &sizeRecorder{}, &sizeRecorder{}, &sizeRecorder{}, &sizeRecorder{}, &sizeRecorder{}
+	tests := []struct {
+		name   string
+		area   goterm.Rect
+		panels []goterm.DockPanel
+		want   []goterm.Rect
+	}{
+		{"ide", goterm.Rect{W: 80, H: 24}, []goterm.DockPanel{
+			{Edge: goterm.DockTop, Size: 1, Widget: menu},
+			{Edge: goterm.DockBottom, Size: 1, Widget: status},
+			{Edge: goterm.DockLeft, Size: 20, Widget: left},
+			{Edge: goterm.DockRight, Size: 10, Widget: right},
+		}, []goterm.Rect{
+			{X: 0, Y: 0, W: 80, H: 1},
+			{X: 0, Y: 23, W: 80, H: 1},
+			{X: 0, Y: 1, W: 20, H: 22},
+			{X: 70, Y: 1, W: 10, H: 22},
+			{X: 20, Y: 1, W: 50, H: 22},
+		}},
+		{"sidebar_first", goterm.Rect{X: 2, Y: 1, W: 30, H: 10}, []goterm.DockPanel{
+			{Edge: goterm.DockLeft, Size: 8, Widget: left},
+			{Edge: goterm.DockTop, Size: 2, Widget: menu},
+		}, []goterm.Rect{
+			{X: 2, Y: 1, W: 8, H: 10},
+			{X: 10, Y: 1, W: 22, H: 2},
+			{X: 10, Y: 3, W: 22, H: 8},
+		}},
+		{"overflow", goterm.Rect{W: 10, H: 5}, []goterm.DockPanel{
+			{Edge: goterm.DockBottom, Size: 3, Widget: status},
+			{Edge: goterm.DockTop, Size: 4, Widget: menu},
+			{Edge: goterm.DockRight, Size: 0, Widget: right},
+		}, []goterm.Rect{
+			{X: 0, Y: 2, W: 10, H: 3},
+			{X: 0, Y: 0, W: 10, H: 2},
+			{X: 10, Y: 2, W: 0, H: 0},
+			{X: 0, Y: 2, W: 10, H: 0},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dock := &goterm.DockLayout{Panels: tt.panels, Center: center}
+			got := dock.Layout(tt.area)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Layout() = %d placements, want %d", len(got), len(tt.want))
+			}
+			for i, p := range got {
+				if p.Rect != tt.want[i] {
+					t.Errorf("placement %d = %+v, want %+v", i, p.Rect, tt.want[i])
+				}
+			}
+			if got[len(got)-1].Widget != goterm.Drawable(center) {
+				t.Error("center not placed last")
+			}
+		})
+	}
+}
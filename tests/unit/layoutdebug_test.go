@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestLayoutDebug(t *testing.T) {
+	screen := goterm.NewScreen(20, 5)
+	side, main := &sizeRecorder{ch: 's'}, &sizeRecorder{ch: 'm'}
+	status := textWidget("ok")
+	screen.AddLayout(func(area goterm.Rect) []goterm.Placement {
+		rows := goterm.SplitV(area, goterm.Fill(1), goterm.Fixed(1))
+		cols := goterm.SplitH(rows[0], goterm.Fixed(8), goterm.Fill(1))
+		return []goterm.Placement{{Rect: cols[0], Widget: side}, {Rect: cols[1], Widget: main}, {Rect: rows[1], Widget: status}}
+	})
+	screen.Relayout()
+	plain := screen.Composite()
+
+	screen.ToggleLayoutDebug()
+	if !screen.LayoutDebug() {
+		t.Fatal("LayoutDebug() = false after toggling on")
+	}
+	want := []string{
+		"┌0 uni…┐┌1 unit.si…┐",
+		"│ssssss││mmmmmmmmmm│",
+		"│ssssss││mmmmmmmmmm│",
+		"└──────┘└──────────┘",
+		"2 unit.textWidget 2…",
+	}
+	b := screen.Composite()
+	for y, row := range want {
+		if got := rowText(b, 0, y, 20); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+	if got := b.GetCell(0, 0).Fg; got != goterm.ColorRed {
+		t.Errorf("first outline fg = %v, want red", got)
+	}
+	if got := b.GetCell(8, 0).Fg; got != goterm.ColorGreen {
+		t.Errorf("second outline fg = %v, want green", got)
+	}
+	if got := b.GetCell(1, 0); got.Fg != goterm.ColorBlack || got.Bg != goterm.ColorRed {
+		t.Errorf("label cell = %+v, want black on red", got)
+	}
+
+	screen.HandleResize(goterm.ResizeEvent{Width: 24, Height: 5})
+	if got := rowText(screen.Composite(), 8, 3, 16); got != "└──────────────┘" {
+		t.Errorf("outline after resize = %q", got)
+	}
+
+	screen.SetLayoutDebug(false)
+	screen.HandleResize(goterm.ResizeEvent{Width: 20, Height: 5})
+	b = screen.Composite()
+	for y := 0; y < 5; y++ {
+		if got, want := rowText(b, 0, y, 20), rowText(plain, 0, y, 20); got != want {
+			t.Errorf("row %d with debugging off = %q, want %q", y, got, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestDrawHLineVLine(t *testing.T) {
+	screen := goterm.NewScreen(10, 5)
+	screen.DrawHLine(1, 1, 5, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+	screen.DrawVLine(8, 0, 4, goterm.BorderDouble, goterm.ColorWhite, goterm.ColorDefault())
+
+	for x := 1; x <= 5; x++ {
+		if got := screen.GetCell(x, 1).Ch; got != '─' {
+			t.Errorf("DrawHLine() cell %d = %q, want '─'", x, got)
+		}
+	}
+	for y := 0; y < 4; y++ {
+		if got := screen.GetCell(8, y).Ch; got != '║' {
+			t.Errorf("DrawVLine() cell %d = %q, want '║'", y, got)
+		}
+	}
+}
+
+func TestLineJunctionMerging(t *testing.T) {
+	// A box split into a 2×2 grid by a divider in each direction
+	screen := goterm.NewScreen(9, 7)
+	screen.DrawBox(0, 0, 9, 7, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+	screen.DrawHLine(0, 3, 9, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+	screen.DrawVLine(4, 0, 7, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+
+	want := map[[2]int]rune{
+		{0, 3}: '├',
+		{8, 3}: '┤',
+		{4, 0}: '┬',
+		{4, 6}: '┴',
+		{4, 3}: '┼',
+		{2, 3}: '─',
+		{4, 1}: '│',
+		{0, 0}: '┌',
+	}
+	for pos, ch := range want {
+		if got := screen.GetCell(pos[0], pos[1]).Ch; got != ch {
+			t.Errorf("cell (%d, %d) = %q, want %q", pos[0], pos[1], got, ch)
+		}
+	}
+}
+
+func TestLineJunctionMixedWeights(t *testing.T) {
+	tests := []struct {
+		name  string
+		box   goterm.BorderStyle
+		line  goterm.BorderStyle
+		left  rune
+		right rune
+	}{
+		{"double_box_single_line", goterm.BorderDouble, goterm.BorderSingle, '╟', '╢'},
+		{"thick_box_thick_line", goterm.BorderThick, goterm.BorderThick, '┣', '┫'},
+		{"single_box_double_line", goterm.BorderSingle, goterm.BorderDouble, '╞', '╡'},
+		{"rounded_box", goterm.BorderRounded, goterm.BorderSingle, '├', '┤'},
+		{"ascii", goterm.BorderASCII, goterm.BorderASCII, '+', '+'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(6, 5)
+			screen.DrawBox(0, 0, 6, 5, tt.box, goterm.ColorWhite, goterm.ColorDefault())
+			screen.DrawHLine(0, 2, 6, tt.line, goterm.ColorWhite, goterm.ColorDefault())
+
+			if got := screen.GetCell(0, 2).Ch; got != tt.left {
+				t.Errorf("left junction = %q, want %q", got, tt.left)
+			}
+			if got := screen.GetCell(5, 2).Ch; got != tt.right {
+				t.Errorf("right junction = %q, want %q", got, tt.right)
+			}
+		})
+	}
+}
+
+func TestLineOverText(t *testing.T) {
+	screen := goterm.NewScreen(5, 1)
+	screen.DrawText(0, 0, "abc", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawHLine(0, 0, 5, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+
+	for x := 0; x < 5; x++ {
+		if got := screen.GetCell(x, 0).Ch; got != '─' {
+			t.Errorf("cell %d = %q, want '─' over non-box content", x, got)
+		}
+	}
+}
+
+func TestLineJunctionMixedBorders(t *testing.T) {
+	tests := []struct {
+		name   string
+		border goterm.BorderStyle
+		want   map[[2]int]rune
+	}{
+		// Double horizontal edges with single vertical edges
+		{"double_horizontal", goterm.BorderDoubleHorizontal, map[[2]int]rune{
+			{0, 2}: '╞', {6, 2}: '╡', {3, 0}: '╤', {3, 4}: '╧', {3, 2}: '╪',
+		}},
+		// Single horizontal edges with double vertical edges
+		{"double_vertical", goterm.BorderDoubleVertical, map[[2]int]rune{
+			{0, 2}: '╟', {6, 2}: '╢', {3, 0}: '╥', {3, 4}: '╨', {3, 2}: '╫',
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(7, 5)
+			screen.DrawBox(0, 0, 7, 5, tt.border, goterm.ColorWhite, goterm.ColorDefault())
+			screen.DrawHLine(0, 2, 7, tt.border, goterm.ColorWhite, goterm.ColorDefault())
+			screen.DrawVLine(3, 0, 5, tt.border, goterm.ColorWhite, goterm.ColorDefault())
+
+			for p, want := range tt.want {
+				if got := screen.GetCell(p[0], p[1]).Ch; got != want {
+					t.Errorf("cell (%d, %d) = %q, want %q", p[0], p[1], got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestLinks(t *testing.T) {
+	screen := goterm.NewScreen(12, 2)
+	screen.DrawText(0, 0, "docs | home", goterm.ColorBlue, goterm.ColorDefault(), goterm.StyleUnderline)
+	screen.SetLink(goterm.Rect{X: 0, Y: 0, W: 4, H: 1}, "https://example.com/docs")
+	screen.SetLink(goterm.Rect{X: 7, Y: 0, W: 4, H: 1}, "https://example.com")
+
+	// A translucent shade keeps the link beneath it
+	shade := screen.AddLayer(1)
+	shade.Fill(0, 0, 2, 1, goterm.NewCell(' ', goterm.ColorDefault(), goterm.ColorRGBA(0, 0, 0, 128), goterm.StyleNone))
+
+	// A view places links in its own coordinates
+	screen.SubView(0, 1, 12, 1).SetLink(goterm.Rect{X: 3, Y: 0, W: 2, H: 1}, "mailto:me@example.com")
+
+	tests := []struct {
+		name string
+		x, y int
+		want string
+	}{
+		{"first", 3, 0, "https://example.com/docs"},
+		{"shaded", 0, 0, "https://example.com/docs"},
+		{"second", 10, 0, "https://example.com"},
+		{"gap", 5, 0, ""},
+		{"view", 4, 1, "mailto:me@example.com"},
+		{"out_of_bounds", 12, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := screen.LinkAt(tt.x, tt.y); got != tt.want {
+				t.Errorf("LinkAt(%d, %d) = %q, want %q", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+
+	screen.DrawText(8, 0, "x", goterm.ColorBlue, goterm.ColorDefault(), goterm.StyleNone)
+	if got := screen.LinkAt(8, 0); got != "" {
+		t.Errorf("LinkAt after redraw = %q, want none", got)
+	}
+	if got := screen.GetCell(1, 0).Ch; got != 'o' {
+		t.Errorf("SetLink changed content: got %q, want 'o'", got)
+	}
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func key(k goterm.Key) goterm.KeyEvent {
+	return goterm.KeyEvent{Key: k}
+}
+
+func runeKey(r rune) goterm.KeyEvent {
+	return goterm.KeyEvent{Key: goterm.KeyRune, Rune: r}
+}
+
+func click(x, y int) goterm.MouseEvent {
+	return goterm.MouseEvent{X: x, Y: y, Button: goterm.MouseLeft, Action: goterm.MousePress}
+}
+
+func TestListNavigation(t *testing.T) {
+	items := []string{"apple", "banana", "cherry", "date", "elderberry", "fig"}
+
+	tests := []struct {
+		name   string
+		list   widgets.List
+		events []goterm.Event
+		cursor int
+		top    string
+	}{
+		{"initial", widgets.List{}, nil, 0, "apple"},
+		{"down", widgets.List{}, []goterm.Event{key(goterm.KeyDown), runeKey('j')}, 2, "apple"},
+		{"stops_at_top", widgets.List{}, []goterm.Event{key(goterm.KeyUp)}, 0, "apple"},
+		{"end_scrolls", widgets.List{}, []goterm.Event{key(goterm.KeyEnd)}, 5, "date"},
+		{"page_down", widgets.List{}, []goterm.Event{key(goterm.KeyPageDown)}, 2, "apple"},
+		{"click", widgets.List{}, []goterm.Event{click(0, 1)}, 1, "apple"},
+		{"wheel", widgets.List{}, []goterm.Event{goterm.MouseEvent{Button: goterm.MouseWheelDown}}, 3, "banana"},
+		{"filter", widgets.List{Filter: "ERR"}, []goterm.Event{key(goterm.KeyDown)}, 4, "cherry"},
+		{"type_to_filter", widgets.List{TypeToFilter: true}, []goterm.Event{runeKey('f'), runeKey('x'), key(goterm.KeyBackspace)}, 5, "fig"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := tt.list
+			list.Items = items
+			buf := goterm.NewBuffer(12, 3)
+			list.Draw(buf)
+			for _, ev := range tt.events {
+				list.HandleEvent(ev)
+				list.Draw(buf)
+			}
+
+			if got := list.Cursor(); got != tt.cursor {
+				t.Errorf("Cursor() = %d, want %d", got, tt.cursor)
+			}
+			if got := rowText(buf, 0, 0, len(tt.top)); got != tt.top {
+				t.Errorf("top row = %q, want %q", got, tt.top)
+			}
+		})
+	}
+}
+
+func TestListSelection(t *testing.T) {
+	var selected []int
+	list := widgets.List{
+		Items:       []string{"one", "two", "three"},
+		MultiSelect: true,
+		OnSelect:    func(i int) { selected = append(selected, i) },
+	}
+	buf := goterm.NewBuffer(10, 3)
+	list.Draw(buf)
+
+	list.HandleEvent(runeKey(' '))
+	list.HandleEvent(click(0, 2))
+	list.HandleEvent(key(goterm.KeyEnter))
+	list.Draw(buf)
+
+	if got := list.Marked(); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("Marked() = %v, want [0 2]", got)
+	}
+	if !slices.Equal(selected, []int{2}) {
+		t.Errorf("OnSelect called with %v, want [2]", selected)
+	}
+	if got := rowText(buf, 0, 0, 7); got != "[x] one" {
+		t.Errorf("row 0 = %q, want %q", got, "[x] one")
+	}
+	if got := buf.GetCell(9, 2).Style; !got.Has(goterm.StyleReverse) {
+		t.Errorf("current row style = %v, want reverse", got)
+	}
+	if handled := list.HandleEvent(runeKey('q')); handled {
+		t.Error("HandleEvent() used an unbound key")
+	}
+}
+
+func TestListRender(t *testing.T) {
+	list := widgets.List{
+		Items: []string{"a", "b"},
+		Render: func(i int, item string, state widgets.ListItemState) goterm.StyledText {
+			prefix := "  "
+			if state.Current {
+				prefix = "> "
+			}
+			return goterm.StyledText{}.Add(prefix+item, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+		},
+	}
+	buf := goterm.NewBuffer(4, 2)
+	list.Draw(buf)
+
+	if got := rowText(buf, 0, 0, 3) + rowText(buf, 0, 1, 3); got != "> a  b" {
+		t.Errorf("Draw() = %q, want %q", got, "> a  b")
+	}
+}
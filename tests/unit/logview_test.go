@@ -0,0 +1,184 @@
+package unit
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want widgets.LogLevel
+	}{
+		{"2024/01/02 15:04:05 ERROR disk full", widgets.LogError},
+		{"[warn] slow request", widgets.LogWarn},
+		{"time=now level=info msg=started", widgets.LogInfo},
+		{"DEBUG cache miss", widgets.LogDebug},
+		{"panic: runtime error", widgets.LogError},
+		{"plain text", widgets.LogNone},
+		{"a b c d e f error", widgets.LogNone},
+	}
+	for _, tt := range tests {
+		if got := widgets.ParseLogLevel(tt.line); got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestLogViewFollow(t *testing.T) {
+	var l widgets.LogView
+	buf := goterm.NewBuffer(10, 3)
+	for i := 1; i <= 5; i++ {
+		l.Append(fmt.Sprintf("line %d", i))
+	}
+	l.Draw(buf)
+	if got := bufferText(buf); fmt.Sprint(got) != "[line 3 line 4 line 5]" {
+		t.Errorf("following = %q", got)
+	}
+
+	l.HandleEvent(key(goterm.KeyUp))
+	l.Append("line 6")
+	l.Draw(buf)
+	if l.Following() || l.Top() != 1 {
+		t.Errorf("after scrolling up: Following() = %v, Top() = %d, want false, 1", l.Following(), l.Top())
+	}
+
+	l.HandleEvent(runeKey('j'))
+	l.HandleEvent(runeKey('j'))
+	l.Append("line 7")
+	l.Draw(buf)
+	if !l.Following() || l.Top() != 4 {
+		t.Errorf("after scrolling to end: Following() = %v, Top() = %d, want true, 4", l.Following(), l.Top())
+	}
+
+	l.HandleEvent(runeKey('g'))
+	l.HandleEvent(runeKey('G'))
+	if !l.Following() {
+		t.Error("G did not resume following")
+	}
+}
+
+func TestLogViewRing(t *testing.T) {
+	l := widgets.LogView{MaxLines: 4}
+	buf := goterm.NewBuffer(10, 2)
+	for i := 1; i <= 3; i++ {
+		l.Append(fmt.Sprintf("line %d", i))
+	}
+	l.Draw(buf)
+	l.ScrollTo(0)
+
+	for i := 4; i <= 6; i++ {
+		l.Append(fmt.Sprintf("line %d", i))
+	}
+	if l.Len() != 4 || l.Line(0) != "line 3" || l.Line(3) != "line 6" {
+		t.Errorf("Len() = %d, lines %q..%q, want 4, line 3..line 6", l.Len(), l.Line(0), l.Line(3))
+	}
+	l.Draw(buf)
+	if got := bufferText(buf); fmt.Sprint(got) != "[line 3 line 4]" {
+		t.Errorf("paused view = %q, want the oldest lines kept", got)
+	}
+
+	l.MaxLines = 2
+	l.Append("line 7")
+	if l.Len() != 2 || l.Line(0) != "line 6" {
+		t.Errorf("after lowering MaxLines: Len() = %d, Line(0) = %q", l.Len(), l.Line(0))
+	}
+}
+
+func TestLogViewWrite(t *testing.T) {
+	var l widgets.LogView
+	appended := 0
+	l.OnAppend = func() { appended++ }
+	fmt.Fprint(&l, "one\r\ntw")
+	fmt.Fprint(&l, "o\nthree")
+	if l.Len() != 2 || l.Line(0) != "one" || l.Line(1) != "two" {
+		t.Errorf("Len() = %d, lines %q, %q", l.Len(), l.Line(0), l.Line(1))
+	}
+	if appended != 2 {
+		t.Errorf("OnAppend called %d times, want 2", appended)
+	}
+
+	logger := log.New(&l, "", 0)
+	logger.Print("WARN last")
+	if l.Line(2) != "threeWARN last" {
+		t.Errorf("Line(2) = %q", l.Line(2))
+	}
+}
+
+func TestLogViewColorsAndSearch(t *testing.T) {
+	var l widgets.LogView
+	l.Append("INFO start", "ERROR failed", "DEBUG retry", "WARN slow", "INFO done")
+	l.AppendLevel(widgets.LogError, "exit")
+	buf := goterm.NewBuffer(12, 6)
+	l.Draw(buf)
+
+	def := goterm.ColorDefault()
+	tests := []struct {
+		y    int
+		want goterm.Color
+	}{
+		{0, def},
+		{1, goterm.ColorRed},
+		{2, goterm.ColorIndex(8)},
+		{3, goterm.ColorYellow},
+		{5, goterm.ColorRed},
+	}
+	for _, tt := range tests {
+		if got := buf.GetCell(0, tt.y).Fg; got != tt.want {
+			t.Errorf("row %d fg = %v, want %v", tt.y, got, tt.want)
+		}
+	}
+
+	buf = goterm.NewBuffer(12, 2)
+	l.Draw(buf)
+	if !l.Search("info", true) || l.Top() != 0 {
+		t.Fatalf("Search backward: Top() = %d, want 0", l.Top())
+	}
+	if l.Following() {
+		t.Error("Search did not stop following")
+	}
+	l.Draw(buf)
+	if c := buf.GetCell(0, 0); !c.Style.Has(goterm.StyleReverse) || buf.GetCell(4, 0).Style.Has(goterm.StyleReverse) {
+		t.Errorf("match not highlighted: %+v", c)
+	}
+	l.HandleEvent(runeKey('N'))
+	if l.Top() != 4 {
+		t.Errorf("N: Top() = %d, want 4", l.Top())
+	}
+	if l.Search("missing", false) {
+		t.Error("Search found a missing line")
+	}
+	l.HandleEvent(key(goterm.KeyEscape))
+	l.Draw(buf)
+	if buf.GetCell(0, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("Escape did not clear the highlighting")
+	}
+}
+
+func TestLogViewConcurrentAppend(t *testing.T) {
+	l := widgets.LogView{MaxLines: 100}
+	buf := goterm.NewBuffer(20, 5)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				l.Append(fmt.Sprintf("goroutine %d line %d", g, i))
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		l.Draw(buf)
+		l.HandleEvent(key(goterm.KeyUp))
+	}
+	wg.Wait()
+	if l.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", l.Len())
+	}
+}
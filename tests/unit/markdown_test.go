@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+// bufferText returns the rows of b with trailing spaces removed
+func bufferText(b *goterm.Buffer) []string {
+	w, h := b.Size()
+	rows := make([]string, h)
+	for y := range rows {
+		rows[y] = strings.TrimRight(rowText(b, 0, y, w), " ")
+	}
+	return rows
+}
+
+func TestRenderMarkdownBlocks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"heading", "# Title #\n\nSome text", []string{"Title", "", "Some text"}},
+		{"heading_hash", "### C#", []string{"C#"}},
+		{"wrap", "one two three\nfour five", []string{"one two three", "four five"}},
+		{"long_word", "abcdefghijklmnopqrstuvwxyz", []string{"abcdefghijklmnop", "qrstuvwxyz"}},
+		{"bullets", "- one\n- two\n  more\n* three", []string{"• one", "• two more", "• three"}},
+		{"numbered", "3. three\n4. four", []string{"3. three", "4. four"}},
+		{"nested", "- outer\n  - inner\n- next", []string{"• outer", "  • inner", "• next"}},
+		{"code", "```go\nx := 1\n```\nafter", []string{"  x := 1", "", "after"}},
+		{"quote", "> quoted\n> text", []string{"│ quoted text"}},
+		{"rule", "a\n\n---\n\nb", []string{"a", "", strings.Repeat("─", 16), "", "b"}},
+		{"inline", "**bold** and `code` and [link](http://x)", []string{"bold and code", "and link"}},
+		{"escapes", `\*not em\* a_b_c`, []string{"*not em* a_b_c"}},
+		{"image", "![logo](logo.png)", []string{"[logo]"}},
+		{"autolink", "see <https://go.dev>", []string{"see", "https://go.dev"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bufferText(widgets.RenderMarkdown(tt.src, 16, goterm.DefaultTheme()))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("RenderMarkdown(%q) =\n%s\nwant\n%s", tt.src, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownStyles(t *testing.T) {
+	theme := goterm.DefaultTheme()
+	b := widgets.RenderMarkdown("# Head\n\n*it* **bo** ~~st~~ `co` [a link](http://x/y)", 40, theme)
+
+	tests := []struct {
+		name  string
+		x, y  int
+		fg    goterm.Color
+		style goterm.Style
+		link  string
+	}{
+		{"h1", 0, 0, theme.Primary, goterm.StyleBold | goterm.StyleUnderline, ""},
+		{"italic", 0, 2, theme.Text, goterm.StyleItalic, ""},
+		{"space", 2, 2, theme.Text, goterm.StyleNone, ""},
+		{"bold", 3, 2, theme.Text, goterm.StyleBold, ""},
+		{"strike", 6, 2, theme.Text, goterm.StyleStrikethrough, ""},
+		{"code", 9, 2, theme.Accent, goterm.StyleNone, ""},
+		{"link", 12, 2, theme.Primary, goterm.StyleUnderline, "http://x/y"},
+		{"link_space", 13, 2, theme.Primary, goterm.StyleUnderline, "http://x/y"},
+		{"link_end", 17, 2, theme.Primary, goterm.StyleUnderline, "http://x/y"},
+		{"after_link", 18, 2, theme.Text, goterm.StyleNone, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := b.GetCell(tt.x, tt.y)
+			if c.Fg != tt.fg || c.Style != tt.style || c.Link != tt.link {
+				t.Errorf("cell %q = fg %v style %v link %q, want fg %v style %v link %q", c.Ch, c.Fg, c.Style, c.Link, tt.fg, tt.style, tt.link)
+			}
+		})
+	}
+}
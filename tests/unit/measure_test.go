@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+var (
+	_ goterm.Measurable = (*widgets.List)(nil)
+	_ goterm.Measurable = (*widgets.StatusBar)(nil)
+	_ goterm.Measurable = (*widgets.Spinner)(nil)
+	_ goterm.Measurable = (*widgets.Checkbox)(nil)
+	_ goterm.Measurable = (*widgets.RadioGroup)(nil)
+	_ goterm.Measurable = (*widgets.Select)(nil)
+	_ goterm.Measurable = (*widgets.ProgressBar)(nil)
+	_ goterm.Measurable = (*widgets.BigText)(nil)
+	_ goterm.Measurable = (*widgets.CodeView)(nil)
+	_ goterm.Measurable = (*widgets.Viewport)(nil)
+	_ goterm.Measurable = (*widgets.TextArea)(nil)
+	_ goterm.Measurable = (*widgets.Dialog)(nil)
+	_ goterm.Measurable = (*widgets.HelpOverlay)(nil)
+	_ goterm.Measurable = (*widgets.LineChart)(nil)
+	_ goterm.Measurable = (*widgets.ScatterPlot)(nil)
+	_ goterm.Measurable = (*widgets.Canvas)(nil)
+	_ goterm.Measurable = (*widgets.Pager)(nil)
+	_ goterm.Measurable = (*widgets.LogView)(nil)
+	_ goterm.Measurable = (*widgets.ColorPicker)(nil)
+)
+
+func TestPreferredSize(t *testing.T) {
+	def := goterm.ColorDefault()
+	code := &widgets.CodeView{LineNumbers: true}
+	code.SetText("package main\n\nfunc main() {}\n")
+	area := &widgets.TextArea{}
+	area.SetText("one\nthree")
+	pager := &widgets.Pager{}
+	pager.SetText("one\nthree")
+	logs := &widgets.LogView{}
+	logs.Append("started", "ok")
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	sliders := &widgets.ColorPicker{}
+	sliders.SetMode(widgets.ColorPickerRGB)
+
+	tests := []struct {
+		name       string
+		widget     goterm.Measurable
+		maxW, maxH int
+		w, h       int
+	}{
+		{"list", &widgets.List{Items: []string{"a", "longest", "mid"}}, 80, 24, 7, 3},
+		{"list_multi", &widgets.List{Items: []string{"abc"}, MultiSelect: true}, 80, 24, 7, 1},
+		{"list_filtered", &widgets.List{Items: []string{"apple", "banana", "cherry"}, Filter: "an"}, 80, 24, 6, 1},
+		{"list_limited", &widgets.List{Items: []string{"a", "longest", "mid"}}, 4, 2, 4, 2},
+		{"status", &widgets.StatusBar{Left: goterm.StyledText{}.Add("NORMAL", def, def, 0), Right: goterm.StyledText{}.Add("1:1", def, def, 0)}, 80, 24, 10, 1},
+		{"spinner", &widgets.Spinner{Label: "Loading"}, 80, 24, 9, 1},
+		{"checkbox", &widgets.Checkbox{Label: "Wrap"}, 80, 24, 8, 1},
+		{"radio", &widgets.RadioGroup{Options: []string{"Small", "Medium"}}, 80, 24, 10, 2},
+		{"select", &widgets.Select{Options: []string{"Red", "Green"}, Placeholder: "Pick"}, 80, 24, 7, 1},
+		{"progress", &widgets.ProgressBar{}, 40, 24, 40, 1},
+		{"bigtext", &widgets.BigText{Text: "12"}, 80, 24, 9, 5},
+		{"code", code, 80, 24, 16, 4},
+		{"viewport", &widgets.Viewport{Content: goterm.NewBuffer(30, 10), Scrollbars: true}, 20, 24, 20, 11},
+		{"textarea", area, 80, 24, 6, 2},
+		{"linechart", &widgets.LineChart{Series: []widgets.Series{{Values: values}}}, 80, 24, 5, 24},
+		{"linechart_legend", &widgets.LineChart{Series: []widgets.Series{{Name: "requests", Values: values}}, Legend: true}, 80, 24, 10, 24},
+		{"linechart_limited", &widgets.LineChart{Series: []widgets.Series{{Values: values}}}, 3, 2, 3, 2},
+		{"scatter", &widgets.ScatterPlot{}, 30, 10, 30, 10},
+		{"canvas", widgets.NewCanvas(10, 4), 80, 24, 10, 4},
+		{"canvas_limited", widgets.NewCanvas(10, 4), 6, 2, 6, 2},
+		{"pager", pager, 80, 24, 5, 3},
+		{"logview", logs, 80, 24, 7, 2},
+		{"colorpicker", &widgets.ColorPicker{}, 80, 24, 24, 6},
+		{"colorpicker_sliders", sliders, 80, 24, 38, 7},
+		{"zero_limits", &widgets.List{Items: []string{"a"}}, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w, h := tt.widget.PreferredSize(tt.maxW, tt.maxH); w != tt.w || h != tt.h {
+				t.Errorf("PreferredSize(%d, %d) = %d, %d, want %d, %d", tt.maxW, tt.maxH, w, h, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestSplitFit(t *testing.T) {
+	list := &widgets.List{Items: []string{"one", "two", "three"}}
+	status := &widgets.StatusBar{Left: goterm.StyledText{}.Add("ready", goterm.ColorDefault(), goterm.ColorDefault(), 0)}
+
+	cols := goterm.SplitH(goterm.Rect{W: 40, H: 10}, goterm.Fit(list), goterm.Fixed(1), goterm.Fill(1))
+	if cols[0].W != 5 || cols[1].X != 5 || cols[2].W != 34 {
+		t.Errorf("SplitH with Fit = %+v", cols)
+	}
+	rows := goterm.SplitV(goterm.Rect{W: 40, H: 10}, goterm.Fill(1), goterm.Fit(list), goterm.Fit(status))
+	if rows[0].H != 6 || rows[1].H != 3 || rows[2] != (goterm.Rect{Y: 9, W: 40, H: 1}) {
+		t.Errorf("SplitV with Fit = %+v", rows)
+	}
+	small := goterm.SplitV(goterm.Rect{W: 40, H: 2}, goterm.Fit(list), goterm.Fit(status))
+	if small[0].H != 2 || small[1].H != 0 {
+		t.Errorf("SplitV with Fit in a small area = %+v", small)
+	}
+}
@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// mouseRecorder is a widget that records the mouse events it gets
+type mouseRecorder struct {
+	events []goterm.MouseEvent
+}
+
+func (r *mouseRecorder) Draw(goterm.Surface) {}
+
+func (r *mouseRecorder) HandleEvent(ev goterm.Event) bool {
+	if mev, ok := ev.(goterm.MouseEvent); ok {
+		r.events = append(r.events, mev)
+		return true
+	}
+	return false
+}
+
+// last returns the position of the last event r got
+func (r *mouseRecorder) last() (x, y int, ok bool) {
+	if len(r.events) == 0 {
+		return 0, 0, false
+	}
+	ev := r.events[len(r.events)-1]
+	return ev.X, ev.Y, true
+}
+
+func TestMouseRouter(t *testing.T) {
+	screen := goterm.NewScreen(20, 10)
+	left, right, popup, tagged := &mouseRecorder{}, &mouseRecorder{}, &mouseRecorder{}, &mouseRecorder{}
+	layout := screen.AddLayout(func(area goterm.Rect) []goterm.Placement {
+		cols := goterm.SplitH(area, goterm.Fill(1), goterm.Fill(1))
+		return []goterm.Placement{{Rect: cols[0], Widget: left}, {Rect: cols[1], Widget: right}}
+	})
+	router := goterm.NewMouseRouter(screen)
+	router.AddLayout(layout)
+	router.Add(goterm.Rect{X: 8, Y: 2, W: 4, H: 2}, popup)
+	screen.SetTag(goterm.Rect{X: 15, Y: 8, W: 3, H: 1}, 7)
+	router.AddTag(7, tagged)
+
+	tests := []struct {
+		name   string
+		x, y   int
+		target *mouseRecorder
+		wantX  int
+		wantY  int
+	}{
+		{"left column", 3, 4, left, 3, 4},
+		{"right column", 12, 5, right, 2, 5},
+		{"popup on top", 10, 3, popup, 2, 1},
+		{"tagged cells", 16, 8, tagged, 1, 0},
+		{"below tag", 16, 9, right, 6, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := goterm.MouseEvent{X: tt.x, Y: tt.y, Button: goterm.MouseWheelUp, Action: goterm.MouseScroll}
+			if !router.HandleEvent(ev) {
+				t.Fatal("HandleEvent() = false")
+			}
+			x, y, ok := tt.target.last()
+			if !ok || x != tt.wantX || y != tt.wantY {
+				t.Errorf("target got (%d, %d, %v), want (%d, %d)", x, y, ok, tt.wantX, tt.wantY)
+			}
+			tt.target.events = nil
+			if h, _, _ := router.HitTest(tt.x, tt.y); h != goterm.EventHandler(tt.target) {
+				t.Errorf("HitTest(%d, %d) = %v, want the target", tt.x, tt.y, h)
+			}
+		})
+	}
+
+	if router.HandleEvent(goterm.KeyEvent{Key: goterm.KeyEnter}) {
+		t.Error("key event handled")
+	}
+	router.Remove(popup)
+	router.HandleEvent(click(9, 3))
+	if len(popup.events) != 0 || len(left.events) != 1 {
+		t.Errorf("click after Remove went to popup=%d left=%d", len(popup.events), len(left.events))
+	}
+	router.RemoveLayout(layout)
+	if router.HandleEvent(goterm.MouseEvent{X: 3, Y: 3, Button: goterm.MouseWheelDown, Action: goterm.MouseScroll}) {
+		t.Error("event handled after RemoveLayout")
+	}
+}
+
+func TestMouseRouterCapture(t *testing.T) {
+	screen := goterm.NewScreen(20, 10)
+	a, b := &mouseRecorder{}, &mouseRecorder{}
+	router := goterm.NewMouseRouter(screen)
+	router.Add(goterm.Rect{X: 0, Y: 0, W: 10, H: 10}, a)
+	router.Add(goterm.Rect{X: 10, Y: 0, W: 10, H: 10}, b)
+
+	events := []goterm.MouseEvent{
+		{X: 5, Y: 5, Button: goterm.MouseLeft, Action: goterm.MousePress},
+		{X: 12, Y: 5, Button: goterm.MouseLeft, Action: goterm.MouseMotion},
+		{X: 15, Y: 6, Button: goterm.MouseLeft, Action: goterm.MouseRelease},
+		{X: 15, Y: 6, Button: goterm.MouseNone, Action: goterm.MouseMotion},
+	}
+	for _, ev := range events {
+		router.HandleEvent(ev)
+	}
+	if len(a.events) != 3 {
+		t.Fatalf("capturing handler got %d events, want 3", len(a.events))
+	}
+	if x, y, _ := a.last(); x != 15 || y != 6 {
+		t.Errorf("release outside the region at (%d, %d), want (15, 6)", x, y)
+	}
+	if x, y, ok := b.last(); !ok || x != 5 || y != 6 || len(b.events) != 1 {
+		t.Errorf("handler under the pointer got %v after the release, want one motion at (5, 6)", b.events)
+	}
+}
@@ -0,0 +1,141 @@
+package unit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+// numberedText returns n lines "line 1" to "line n"
+func numberedText(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestPagerNavigation(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []goterm.Event
+		want   int
+	}{
+		{"down", []goterm.Event{runeKey('j'), key(goterm.KeyDown), key(goterm.KeyEnter)}, 3},
+		{"up_stops", []goterm.Event{runeKey('j'), runeKey('k'), runeKey('k')}, 0},
+		{"page", []goterm.Event{runeKey(' '), runeKey('f')}, 8},
+		{"page_back", []goterm.Event{key(goterm.KeyPageDown), ctrlKey('f'), runeKey('b')}, 4},
+		{"half_page", []goterm.Event{runeKey('d'), ctrlKey('d'), runeKey('u')}, 2},
+		{"bottom", []goterm.Event{runeKey('G')}, 16},
+		{"top", []goterm.Event{key(goterm.KeyEnd), runeKey('g')}, 0},
+		{"wheel", []goterm.Event{goterm.MouseEvent{Button: goterm.MouseWheelDown}}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p widgets.Pager
+			p.SetText(numberedText(20))
+			buf := goterm.NewBuffer(10, 5)
+			p.Draw(buf)
+			for _, ev := range tt.events {
+				p.HandleEvent(ev)
+			}
+			if got := p.Top(); got != tt.want {
+				t.Errorf("Top() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagerStatus(t *testing.T) {
+	var p widgets.Pager
+	p.SetText(numberedText(20))
+	buf := goterm.NewBuffer(16, 5)
+
+	p.Draw(buf)
+	if got, want := rowText(buf, 0, 4, 16), ":     1-4/20 20%"; got != want {
+		t.Errorf("status = %q, want %q", got, want)
+	}
+	if !buf.GetCell(0, 4).Style.Has(goterm.StyleReverse) {
+		t.Error("status line not highlighted")
+	}
+
+	p.HandleEvent(key(goterm.KeyEnd))
+	p.Draw(buf)
+	if got, want := rowText(buf, 0, 4, 16), "(END) 17-20/20 1"; got != want {
+		t.Errorf("status at end = %q, want %q", got, want)
+	}
+	if got := p.Percent(); got != 100 {
+		t.Errorf("Percent() = %d, want 100", got)
+	}
+}
+
+func TestPagerSearch(t *testing.T) {
+	var p widgets.Pager
+	p.SetText("alpha\nbeta\ngamma\nBeta two\ndelta\nepsilon\nbeta three\nzeta\neta\ntheta")
+	buf := goterm.NewBuffer(12, 4)
+	p.Draw(buf)
+
+	for _, ev := range []goterm.Event{runeKey('/'), runeKey('b'), runeKey('x'), key(goterm.KeyBackspace), runeKey('e')} {
+		p.HandleEvent(ev)
+	}
+	p.Draw(buf)
+	if got, want := rowText(buf, 0, 3, 12), "/be         "; got != want {
+		t.Errorf("prompt = %q, want %q", got, want)
+	}
+
+	p.HandleEvent(key(goterm.KeyEnter))
+	if got := p.Top(); got != 1 {
+		t.Fatalf("Top() after search = %d, want 1", got)
+	}
+	p.Draw(buf)
+	if !buf.GetCell(0, 0).Style.Has(goterm.StyleReverse) || !buf.GetCell(1, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("match not highlighted")
+	}
+	if buf.GetCell(2, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("highlight runs past the match")
+	}
+
+	steps := []struct {
+		ev   goterm.Event
+		want int
+	}{
+		{runeKey('n'), 3},
+		{runeKey('n'), 6},
+		{runeKey('N'), 3},
+	}
+	for _, step := range steps {
+		p.HandleEvent(step.ev)
+		if got := p.Top(); got != step.want {
+			t.Errorf("Top() after %+v = %d, want %d", step.ev, got, step.want)
+		}
+	}
+
+	if p.Search("omega", false) {
+		t.Error("Search found a missing pattern")
+	}
+	p.Draw(buf)
+	if got := rowText(buf, 0, 3, 12); !strings.HasPrefix(got, "Pattern not") {
+		t.Errorf("status = %q, want not found message", got)
+	}
+}
+
+func TestPagerANSI(t *testing.T) {
+	var p widgets.Pager
+	p.Fg = goterm.ColorWhite
+	p.SetLines(goterm.ParseANSI("ok \x1b[31mfail\x1b[0m"))
+	buf := goterm.NewBuffer(10, 2)
+	p.Draw(buf)
+
+	if got := rowText(buf, 0, 0, 7); got != "ok fail" {
+		t.Errorf("text = %q", got)
+	}
+	if got := buf.GetCell(0, 0).Fg; got != goterm.ColorWhite {
+		t.Errorf("default text fg = %v, want Fg", got)
+	}
+	if got := buf.GetCell(3, 0).Fg; got != goterm.ColorRed {
+		t.Errorf("colored text fg = %v, want red", got)
+	}
+}
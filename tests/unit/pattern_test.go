@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestShadeRune(t *testing.T) {
+	tests := []struct {
+		shade goterm.Shade
+		want  rune
+	}{
+		{goterm.ShadeNone, ' '},
+		{goterm.ShadeLight, '░'},
+		{goterm.ShadeMedium, '▒'},
+		{goterm.ShadeDark, '▓'},
+		{goterm.ShadeFull, '█'},
+		{goterm.Shade(-1), ' '},
+		{goterm.Shade(9), '█'},
+	}
+	for _, tt := range tests {
+		if got := tt.shade.Rune(); got != tt.want {
+			t.Errorf("Shade(%d).Rune() = %q, want %q", tt.shade, got, tt.want)
+		}
+	}
+}
+
+func TestFillShade(t *testing.T) {
+	screen := goterm.NewScreen(4, 2)
+	screen.FillShade(goterm.Rect{X: 1, W: 2, H: 1}, goterm.ShadeMedium, goterm.ColorBlue, goterm.ColorBlack)
+
+	if got := rowText(screen, 0, 0, 4); got != " ▒▒ " {
+		t.Errorf("row = %q, want %q", got, " ▒▒ ")
+	}
+	if cell := screen.GetCell(1, 0); cell.Fg != goterm.ColorBlue || cell.Bg != goterm.ColorBlack {
+		t.Errorf("shade colors = %v/%v, want blue on black", cell.Fg, cell.Bg)
+	}
+}
+
+func TestFillChecker(t *testing.T) {
+	x := goterm.NewCell('x', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	o := goterm.NewCell('o', goterm.ColorBlack, goterm.ColorDefault(), goterm.StyleNone)
+
+	screen := goterm.NewScreen(7, 3)
+	screen.FillChecker(goterm.Rect{X: 1, W: 6, H: 3}, 2, 1, x, o)
+
+	want := []string{" xxooxx", " ooxxoo", " xxooxx"}
+	for y, row := range want {
+		if got := rowText(screen, 0, y, 7); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+}
+
+func TestFillDithered(t *testing.T) {
+	screen := goterm.NewScreen(9, 5)
+	screen.FillDithered(goterm.Rect{W: 9, H: 1}, goterm.ColorBlue, goterm.ColorRed, goterm.Horizontal)
+	screen.FillDithered(goterm.Rect{Y: 1, W: 2, H: 4}, goterm.ColorBlue, goterm.ColorRed, goterm.Vertical)
+
+	if got := rowText(screen, 0, 0, 9); got != " ░░▒▒▓▓██" {
+		t.Errorf("horizontal = %q, want %q", got, " ░░▒▒▓▓██")
+	}
+	if cell := screen.GetCell(4, 0); cell.Fg != goterm.ColorRed || cell.Bg != goterm.ColorBlue {
+		t.Errorf("dither colors = %v over %v, want red over blue", cell.Fg, cell.Bg)
+	}
+
+	var vertical []rune
+	for y := 1; y < 5; y++ {
+		vertical = append(vertical, screen.GetCell(1, y).Ch)
+	}
+	if got := string(vertical); got != " ░▓█" {
+		t.Errorf("vertical = %q, want %q", got, " ░▓█")
+	}
+}
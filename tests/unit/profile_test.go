@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestDetectColorProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		colorterm string
+		term      string
+		noColor   bool
+		want      goterm.ColorMode
+	}{
+		{"truecolor", "truecolor", "xterm-256color", false, goterm.ColorModeTrueColor},
+		{"24bit", "24bit", "xterm", false, goterm.ColorModeTrueColor},
+		{"256", "", "xterm-256color", false, goterm.ColorMode256},
+		{"16", "", "xterm", false, goterm.ColorMode16},
+		{"unset", "", "", false, goterm.ColorMode16},
+		{"dumb", "", "dumb", false, goterm.ColorModeDefault},
+		{"no_color", "truecolor", "xterm-256color", true, goterm.ColorModeDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLORTERM", tt.colorterm)
+			t.Setenv("TERM", tt.term)
+			t.Setenv("NO_COLOR", "")
+			if tt.noColor {
+				t.Setenv("NO_COLOR", "1")
+			}
+			if got := goterm.DetectColorProfile(); got != tt.want {
+				t.Errorf("DetectColorProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenColorProfile(t *testing.T) {
+	screen := goterm.NewScreen(2, 2)
+	if got := screen.ColorProfile(); got != goterm.ColorModeTrueColor {
+		t.Errorf("default ColorProfile() = %v, want truecolor", got)
+	}
+	screen.SetColorProfile(goterm.ColorMode256)
+	if got := screen.ColorProfile(); got != goterm.ColorMode256 {
+		t.Errorf("ColorProfile() = %v, want 256", got)
+	}
+}
+
+func TestDetectSupportedStyles(t *testing.T) {
+	tests := []struct {
+		term   string
+		italic bool
+		none   bool
+	}{
+		{"xterm-256color", true, false},
+		{"tmux-256color", true, false},
+		{"screen-256color", false, false},
+		{"linux", false, false},
+		{"vt100", false, false},
+		{"dumb", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			got := goterm.DetectSupportedStyles()
+			if got.Has(goterm.StyleItalic) != tt.italic {
+				t.Errorf("DetectSupportedStyles() = %v, italic support want %v", got, tt.italic)
+			}
+			if (got == goterm.StyleNone) != tt.none {
+				t.Errorf("DetectSupportedStyles() = %v", got)
+			}
+		})
+	}
+}
+
+func TestScreenSupportedStyles(t *testing.T) {
+	screen := goterm.NewScreen(2, 2)
+	if got := screen.SupportedStyles(); got != goterm.StyleAll {
+		t.Errorf("default SupportedStyles() = %v, want StyleAll", got)
+	}
+	screen.SetSupportedStyles(goterm.StyleBold)
+	if got := screen.SupportedStyles(); got != goterm.StyleBold {
+		t.Errorf("SupportedStyles() = %v, want bold", got)
+	}
+}
@@ -0,0 +1,56 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		name  string
+		bar   widgets.ProgressBar
+		ticks int
+		want  string
+	}{
+		{"empty", widgets.ProgressBar{}, 0, "░░░░░░░░░░"},
+		{"half", widgets.ProgressBar{Value: 0.5}, 0, "█████░░░░░"},
+		{"overflow", widgets.ProgressBar{Value: 1.5}, 0, "██████████"},
+		{"percent", widgets.ProgressBar{Value: 0.25, ShowPercent: true}, 0, "█░░░░  25%"},
+		{"label", widgets.ProgressBar{Value: 1, Label: "CPU", Runes: widgets.BarASCII}, 0, "CPU ######"},
+		{"partial", widgets.ProgressBar{Value: 0.33, Runes: widgets.BarSmooth}, 0, "███▎      "},
+		{"indeterminate", widgets.ProgressBar{Indeterminate: true, ShowPercent: true}, 0, "██░░░░░░░░"},
+		{"indeterminate_tick", widgets.ProgressBar{Indeterminate: true}, 3, "░░░██░░░░░"},
+		{"indeterminate_bounce", widgets.ProgressBar{Indeterminate: true}, 10, "░░░░░░██░░"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := goterm.NewBuffer(10, 1)
+			for range tt.ticks {
+				tt.bar.Tick()
+			}
+			tt.bar.Draw(buf)
+			if got := rowText(buf, 0, 0, 10); got != tt.want {
+				t.Errorf("Draw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressBarGradient(t *testing.T) {
+	gradient := goterm.NewGradient(goterm.ColorRGB(255, 0, 0), goterm.ColorRGB(0, 0, 255))
+	bar := widgets.ProgressBar{Value: 1, Gradient: &gradient}
+	buf := goterm.NewBuffer(5, 2)
+	bar.Draw(buf)
+
+	for y := 0; y < 2; y++ {
+		if got := buf.GetCell(0, y).Fg; got != goterm.ColorRGB(255, 0, 0) {
+			t.Errorf("row %d first cell = %v, want start color", y, got)
+		}
+		if got := buf.GetCell(4, y).Fg; got != goterm.ColorRGB(0, 0, 255) {
+			t.Errorf("row %d last cell = %v, want end color", y, got)
+		}
+	}
+}
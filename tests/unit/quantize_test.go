@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestQuantizeImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{128, 128, 128, 255})
+	img.Set(2, 0, color.RGBA{255, 165, 0, 255})
+
+	tests := []struct {
+		name string
+		mode goterm.ColorMode
+		want []uint8
+	}{
+		{"256", goterm.ColorMode256, []uint8{196, 244, 214}},
+		{"16", goterm.ColorMode16, []uint8{9, 8, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := goterm.QuantizeImage(img, tt.mode, false)
+			if out.Bounds() != img.Bounds() {
+				t.Fatalf("Bounds() = %v, want %v", out.Bounds(), img.Bounds())
+			}
+			for x, want := range tt.want {
+				if got := out.ColorIndexAt(x, 0); got != want {
+					t.Errorf("pixel %d index = %d, want %d", x, got, want)
+				}
+			}
+			if tt.mode == goterm.ColorMode16 && len(out.Palette) != 16 {
+				t.Errorf("palette has %d colors, want 16", len(out.Palette))
+			}
+		})
+	}
+}
+
+func TestQuantizeImageDither(t *testing.T) {
+	// A flat color between two palette entries
+	img := image.NewRGBA(image.Rect(10, 10, 30, 30))
+	for y := 10; y < 30; y++ {
+		for x := 10; x < 30; x++ {
+			img.Set(x, y, color.RGBA{64, 64, 64, 255})
+		}
+	}
+
+	plain := goterm.QuantizeImage(img, goterm.ColorMode16, false)
+	dithered := goterm.QuantizeImage(img, goterm.ColorMode16, true)
+
+	plainIndexes := map[uint8]bool{}
+	var sum float64
+	for y := 10; y < 30; y++ {
+		for x := 10; x < 30; x++ {
+			plainIndexes[plain.ColorIndexAt(x, y)] = true
+			r, _, _, _ := dithered.At(x, y).RGBA()
+			sum += float64(r >> 8)
+		}
+	}
+
+	if len(plainIndexes) != 1 {
+		t.Errorf("undithered flat area uses %d colors, want 1", len(plainIndexes))
+	}
+	if avg := sum / 400; avg < 54 || avg > 74 {
+		t.Errorf("dithered average = %.1f, want about 64", avg)
+	}
+}
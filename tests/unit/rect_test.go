@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestRectContains(t *testing.T) {
+	r := goterm.Rect{X: 2, Y: 3, W: 4, H: 2}
+
+	tests := []struct {
+		x, y int
+		want bool
+	}{
+		{2, 3, true},
+		{5, 4, true},
+		{6, 4, false},
+		{5, 5, false},
+		{1, 3, false},
+	}
+	for _, tt := range tests {
+		if got := r.Contains(tt.x, tt.y); got != tt.want {
+			t.Errorf("%+v.Contains(%d, %d) = %v, want %v", r, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestRectIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b goterm.Rect
+		want goterm.Rect
+	}{
+		{"overlap", goterm.Rect{X: 0, Y: 0, W: 5, H: 5}, goterm.Rect{X: 3, Y: 2, W: 5, H: 5}, goterm.Rect{X: 3, Y: 2, W: 2, H: 3}},
+		{"contained", goterm.Rect{X: 0, Y: 0, W: 10, H: 10}, goterm.Rect{X: 2, Y: 2, W: 3, H: 3}, goterm.Rect{X: 2, Y: 2, W: 3, H: 3}},
+		{"disjoint", goterm.Rect{X: 0, Y: 0, W: 2, H: 2}, goterm.Rect{X: 5, Y: 5, W: 2, H: 2}, goterm.Rect{}},
+		{"touching", goterm.Rect{X: 0, Y: 0, W: 2, H: 2}, goterm.Rect{X: 2, Y: 0, W: 2, H: 2}, goterm.Rect{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.a.Intersect(tt.b)
+			if got != tt.want {
+				t.Errorf("Intersect() = %+v, want %+v", got, tt.want)
+			}
+			if tt.want.Empty() != got.Empty() {
+				t.Errorf("Intersect().Empty() = %v, want %v", got.Empty(), tt.want.Empty())
+			}
+		})
+	}
+}
+
+func TestScreenFill(t *testing.T) {
+	screen := goterm.NewScreen(10, 5)
+	fill := goterm.NewCell('#', goterm.ColorRed, goterm.ColorBlue, goterm.StyleBold)
+
+	// Region extends past the right and bottom edges and must be clipped
+	screen.Fill(7, 3, 5, 5, fill)
+
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 10; x++ {
+			inside := x >= 7 && y >= 3
+			if got := screen.GetCell(x, y).Equal(fill); got != inside {
+				t.Errorf("cell (%d, %d) filled = %v, want %v", x, y, got, inside)
+			}
+		}
+	}
+
+	// Negative origins are clipped as well
+	screen.Fill(-2, -2, 3, 3, fill)
+	if !screen.GetCell(0, 0).Equal(fill) {
+		t.Error("Fill() with negative origin did not fill (0, 0)")
+	}
+	if screen.GetCell(1, 1).Equal(fill) {
+		t.Error("Fill() with negative origin filled past its region")
+	}
+}
+
+func TestScreenFillStyle(t *testing.T) {
+	screen := goterm.NewScreen(10, 3)
+	screen.DrawText(0, 1, "abcdef", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	screen.FillStyle(goterm.Rect{X: 1, Y: 1, W: 3, H: 1}, goterm.ColorBlack, goterm.ColorYellow, goterm.StyleReverse)
+
+	for x, ch := range "abcdef" {
+		cell := screen.GetCell(x, 1)
+		if cell.Ch != ch {
+			t.Errorf("FillStyle() changed character at %d: got %q, want %q", x, cell.Ch, ch)
+		}
+		styled := x >= 1 && x <= 3
+		if got := cell.Bg == goterm.ColorYellow && cell.Style == goterm.StyleReverse; got != styled {
+			t.Errorf("cell %d styled = %v, want %v", x, got, styled)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestRegionTransforms(t *testing.T) {
+	region := goterm.Rect{X: 1, Y: 0, W: 2, H: 1}
+
+	tests := []struct {
+		name  string
+		apply func(s *goterm.Screen)
+		check func(c goterm.Cell) bool
+	}{
+		{
+			name:  "dim",
+			apply: func(s *goterm.Screen) { s.DimRegion(region) },
+			check: func(c goterm.Cell) bool { return c.Style == goterm.StyleBold|goterm.StyleDim },
+		},
+		{
+			name:  "invert",
+			apply: func(s *goterm.Screen) { s.InvertRegion(region) },
+			check: func(c goterm.Cell) bool { return c.Style == goterm.StyleBold|goterm.StyleReverse },
+		},
+		{
+			name:  "invert_twice",
+			apply: func(s *goterm.Screen) { s.InvertRegion(region); s.InvertRegion(region) },
+			check: func(c goterm.Cell) bool { return c.Style == goterm.StyleBold },
+		},
+		{
+			name:  "tint",
+			apply: func(s *goterm.Screen) { s.TintRegion(region, goterm.ColorRed) },
+			check: func(c goterm.Cell) bool { return c.Fg == goterm.ColorRed && c.Bg == goterm.ColorBlue },
+		},
+		{
+			name: "apply_style",
+			apply: func(s *goterm.Screen) {
+				s.ApplyStyle(region, func(c goterm.Cell) goterm.Cell {
+					c.Style = goterm.StyleUnderline
+					return c
+				})
+			},
+			check: func(c goterm.Cell) bool { return c.Style == goterm.StyleUnderline },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(4, 1)
+			screen.DrawText(0, 0, "abcd", goterm.ColorWhite, goterm.ColorBlue, goterm.StyleBold)
+			tt.apply(screen)
+
+			for x := 0; x < 4; x++ {
+				cell := screen.GetCell(x, 0)
+				if cell.Ch != rune('a'+x) {
+					t.Errorf("cell %d = %q, want content kept", x, cell.Ch)
+				}
+				inside := region.Contains(x, 0)
+				if inside && !tt.check(cell) {
+					t.Errorf("cell %d = %+v, not transformed", x, cell)
+				}
+				if !inside && (cell.Style != goterm.StyleBold || cell.Fg != goterm.ColorWhite) {
+					t.Errorf("cell %d = %+v, changed outside region", x, cell)
+				}
+			}
+		})
+	}
+}
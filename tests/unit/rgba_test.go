@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestColorRGBA(t *testing.T) {
+	c := goterm.ColorRGBA(10, 20, 30, 64)
+	if c.Alpha() != 64 {
+		t.Errorf("Alpha() = %d, want 64", c.Alpha())
+	}
+	if r, g, b := c.RGB(); r != 10 || g != 20 || b != 30 {
+		t.Errorf("RGB() = (%d, %d, %d), want (10, 20, 30)", r, g, b)
+	}
+	if got := c.String(); got != "rgba(10,20,30,64)" {
+		t.Errorf("String() = %q", got)
+	}
+	if goterm.ColorRGBA(10, 20, 30, 255) != goterm.ColorRGB(10, 20, 30) {
+		t.Error("opaque ColorRGBA should equal ColorRGB")
+	}
+	if goterm.ColorRed.Alpha() != 255 || goterm.ColorDefault().Alpha() != 255 {
+		t.Error("non-RGBA colors should be opaque")
+	}
+}
+
+func TestTranslucentLayer(t *testing.T) {
+	white := goterm.ColorRGB(200, 200, 200)
+	blue := goterm.ColorRGB(0, 0, 200)
+
+	screen := goterm.NewScreen(3, 1)
+	screen.DrawText(0, 0, "abc", white, blue, goterm.StyleNone)
+
+	layer := screen.AddLayer(1)
+	scrim := goterm.ColorRGBA(0, 0, 0, 128)
+	layer.SetCell(0, 0, goterm.NewCell(' ', goterm.ColorDefault(), scrim, goterm.StyleNone))
+	layer.SetCell(1, 0, goterm.NewCell('X', goterm.ColorRGBA(255, 255, 255, 128), scrim, goterm.StyleNone))
+
+	out := screen.Composite()
+
+	// A translucent blank tints the content beneath
+	tinted := out.GetCell(0, 0)
+	if tinted.Ch != 'a' {
+		t.Errorf("tinted cell = %q, want 'a' kept", tinted.Ch)
+	}
+	if tinted.Fg != goterm.ColorRGB(100, 100, 100) || tinted.Bg != goterm.ColorRGB(0, 0, 100) {
+		t.Errorf("tinted colors = %v on %v, want rgb(100,100,100) on rgb(0,0,100)", tinted.Fg, tinted.Bg)
+	}
+
+	// Translucent cells with content replace the character and blend colors
+	covered := out.GetCell(1, 0)
+	if covered.Ch != 'X' {
+		t.Errorf("covered cell = %q, want 'X'", covered.Ch)
+	}
+	if covered.Bg != goterm.ColorRGB(0, 0, 100) || covered.Fg != goterm.ColorRGB(128, 128, 178) {
+		t.Errorf("covered colors = %v on %v, want rgb(128,128,178) on rgb(0,0,100)", covered.Fg, covered.Bg)
+	}
+
+	if got := out.GetCell(2, 0); got.Bg != blue {
+		t.Errorf("uncovered cell bg = %v, want %v", got.Bg, blue)
+	}
+}
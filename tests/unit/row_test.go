@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestScreenGetRow(t *testing.T) {
+	screen := goterm.NewScreen(4, 2)
+	screen.DrawText(0, 1, "wxyz", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone)
+
+	row := screen.GetRow(1)
+	if len(row) != 4 {
+		t.Fatalf("len(GetRow(1)) = %d, want 4", len(row))
+	}
+	for x, ch := range "wxyz" {
+		if row[x].Ch != ch || row[x].Fg != goterm.ColorRed {
+			t.Errorf("row[%d] = %+v, want red %q", x, row[x], ch)
+		}
+	}
+
+	// The row is a copy
+	row[0].Ch = '!'
+	if got := screen.GetCell(0, 1).Ch; got != 'w' {
+		t.Errorf("modifying GetRow result changed the screen: %q", got)
+	}
+
+	if screen.GetRow(-1) != nil || screen.GetRow(2) != nil {
+		t.Error("GetRow() out of bounds should return nil")
+	}
+}
+
+func TestScreenCopyInto(t *testing.T) {
+	screen := goterm.NewScreen(4, 3)
+	for y, line := range []string{"abcd", "efgh", "ijkl"} {
+		screen.DrawText(0, y, line, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	}
+
+	tests := []struct {
+		name string
+		rect goterm.Rect
+		size int
+		want string
+		n    int
+	}{
+		{"inner", goterm.Rect{X: 1, Y: 1, W: 2, H: 2}, 4, "fgjk", 4},
+		{"partly_outside", goterm.Rect{X: 3, Y: 2, W: 2, H: 2}, 4, "l   ", 4},
+		{"short_dst", goterm.Rect{W: 4, H: 3}, 5, "abcde", 5},
+		{"empty_rect", goterm.Rect{W: 0, H: 3}, 4, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := make([]goterm.Cell, tt.size)
+			n := screen.CopyInto(dst, tt.rect)
+			if n != tt.n {
+				t.Fatalf("CopyInto() = %d, want %d", n, tt.n)
+			}
+
+			var got []rune
+			for _, c := range dst[:n] {
+				got = append(got, c.Ch)
+			}
+			if string(got) != tt.want {
+				t.Errorf("copied %q, want %q", string(got), tt.want)
+			}
+		})
+	}
+}
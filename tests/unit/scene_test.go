@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dshills/goterm"
+)
+
+// fillScene is a scene that fills the screen with one character and counts
+// its updates and events
+type fillScene struct {
+	ch      rune
+	updates int
+	events  int
+}
+
+func (f *fillScene) Update(time.Duration) { f.updates++ }
+
+func (f *fillScene) Draw(s *goterm.Screen) {
+	w, h := s.Size()
+	s.Fill(0, 0, w, h, goterm.NewCell(f.ch, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+}
+
+func (f *fillScene) HandleEvent(goterm.Event) bool {
+	f.events++
+	return true
+}
+
+func TestSceneManagerStack(t *testing.T) {
+	var m goterm.SceneManager
+	menu, game, pause := &fillScene{ch: 'm'}, &fillScene{ch: 'g'}, &fillScene{ch: 'p'}
+	screen := goterm.NewScreen(3, 1)
+	frame := func() string {
+		screen.Clear()
+		m.Update(time.Millisecond)
+		m.Draw(screen)
+		return rowText(screen, 0, 0, 3)
+	}
+
+	if m.HandleEvent(runeKey('x')) || m.Top() != nil || m.Pop() != nil {
+		t.Error("empty manager has a scene")
+	}
+	m.Push(menu)
+	if got := frame(); got != "mmm" {
+		t.Errorf("menu frame = %q", got)
+	}
+	if old := m.Replace(game); old != menu {
+		t.Errorf("Replace() returned %v, want the menu", old)
+	}
+	m.Push(pause)
+	m.HandleEvent(runeKey('x'))
+	if got := frame(); got != "ppp" || m.Len() != 2 {
+		t.Errorf("pause frame = %q with %d scenes", got, m.Len())
+	}
+	if pause.events != 1 || game.events != 0 || game.updates != 0 {
+		t.Errorf("scene below the top got events=%d updates=%d", game.events, game.updates)
+	}
+	if m.Pop() != pause || m.Top() != game {
+		t.Error("Pop() did not uncover the game")
+	}
+	if got := frame(); got != "ggg" {
+		t.Errorf("game frame = %q", got)
+	}
+}
+
+func TestSceneManagerTransition(t *testing.T) {
+	m := goterm.SceneManager{Transition: goterm.Wipe, Duration: 100 * time.Millisecond}
+	screen := goterm.NewScreen(10, 1)
+	frame := func(dt time.Duration) string {
+		screen.Clear()
+		m.Update(dt)
+		m.Draw(screen)
+		return rowText(screen, 0, 0, 10)
+	}
+
+	m.Push(&fillScene{ch: 'a'})
+	if got := frame(0); got != strings.Repeat("a", 10) || m.Transitioning() {
+		t.Errorf("first scene = %q, transitioning %v; want no transition", got, m.Transitioning())
+	}
+	m.Replace(&fillScene{ch: 'b'})
+	if got := frame(0); got != strings.Repeat("a", 10) {
+		t.Errorf("transition start = %q", got)
+	}
+	if got := frame(50 * time.Millisecond); got != "bbbbbaaaaa" {
+		t.Errorf("transition halfway = %q", got)
+	}
+	if got := frame(60 * time.Millisecond); got != strings.Repeat("b", 10) || m.Transitioning() {
+		t.Errorf("transition end = %q, transitioning %v", got, m.Transitioning())
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	from, to := goterm.NewBuffer(6, 1), goterm.NewBuffer(6, 1)
+	from.DrawText(0, 0, "abcdef", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	to.DrawText(0, 0, "ABCDEF", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name       string
+		transition goterm.Transition
+		t          float64
+		want       string
+	}{
+		{"wipe start", goterm.Wipe, 0, "abcdef"},
+		{"wipe third", goterm.Wipe, 1.0 / 3, "ABcdef"},
+		{"wipe end", goterm.Wipe, 1, "ABCDEF"},
+		{"slide half", goterm.Slide, 0.5, "defABC"},
+		{"slide end", goterm.Slide, 1, "ABCDEF"},
+		{"dissolve start", goterm.Dissolve, 0, "abcdef"},
+		{"dissolve end", goterm.Dissolve, 1, "ABCDEF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := goterm.NewBuffer(6, 1)
+			tt.transition(dst, from, to, tt.t)
+			if got := rowText(dst, 0, 0, 6); got != tt.want {
+				t.Errorf("frame at %.2f = %q, want %q", tt.t, got, tt.want)
+			}
+		})
+	}
+}
@@ -294,15 +294,16 @@ func TestScreenDrawText(t *testing.T) {
 			screen.Clear()
 			screen.DrawText(tt.x, tt.y, tt.text, tt.fg, tt.bg, tt.style)
 
-			// Verify each character was placed correctly
-			runes := []rune(tt.text)
-			for i, ch := range runes {
-				cell := screen.GetCell(tt.x+i, tt.y)
+			// Verify each character was placed correctly, advancing by display width
+			col := tt.x
+			for i, ch := range []rune(tt.text) {
+				cell := screen.GetCell(col, tt.y)
 				expected := goterm.NewCell(ch, tt.fg, tt.bg, tt.style)
 				if !cell.Equal(expected) {
 					t.Errorf("DrawText() char %d at (%d, %d) = %+v, want %+v",
-						i, tt.x+i, tt.y, cell, expected)
+						i, col, tt.y, cell, expected)
 				}
+				col += goterm.RuneWidth(ch)
 			}
 		})
 	}
@@ -364,3 +365,18 @@ func TestScreenResize(t *testing.T) {
 		t.Error("Resize() didn't properly handle out-of-bounds access")
 	}
 }
+
+func TestScreenDrawTextf(t *testing.T) {
+	screen := goterm.NewScreen(20, 1)
+	screen.DrawTextf(1, 0, goterm.ColorYellow, goterm.ColorDefault(), goterm.StyleBold, "Score: %04d %s", 42, "世")
+
+	want := "Score: 0042 世"
+	x := 1
+	for _, ch := range want {
+		cell := screen.GetCell(x, 0)
+		if cell.Ch != ch || cell.Fg != goterm.ColorYellow || cell.Style != goterm.StyleBold {
+			t.Errorf("GetCell(%d, 0) = %+v, want %q in yellow bold", x, cell, ch)
+		}
+		x += goterm.RuneWidth(ch)
+	}
+}
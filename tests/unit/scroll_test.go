@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// fillRows writes the row number as a digit into the first column of each row
+func fillRows(screen *goterm.Screen) {
+	_, h := screen.Size()
+	for y := 0; y < h; y++ {
+		screen.DrawText(0, y, string(rune('0'+y))+"xxx", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	}
+}
+
+func TestScreenScroll(t *testing.T) {
+	fill := goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name string
+		dy   int
+		want string
+	}{
+		{"up_one", 1, "1234."},
+		{"up_two", 2, "234.."},
+		{"down_one", -1, ".0123"},
+		{"down_three", -3, "...01"},
+		{"past_height", 10, "....."},
+		{"zero", 0, "01234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(4, 5)
+			fillRows(screen)
+			screen.Scroll(tt.dy, fill)
+
+			for y, ch := range tt.want {
+				if got := screen.GetCell(0, y).Ch; got != ch {
+					t.Errorf("row %d = %q, want %q", y, got, ch)
+				}
+			}
+		})
+	}
+}
+
+func TestScreenScrollRegion(t *testing.T) {
+	fill := goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	screen := goterm.NewScreen(4, 5)
+	fillRows(screen)
+
+	// Scroll rows 1-3, columns 0-1 up by one
+	screen.ScrollRegion(goterm.Rect{X: 0, Y: 1, W: 2, H: 3}, 1, fill)
+
+	wantCol0 := "023.4"
+	for y, ch := range wantCol0 {
+		if got := screen.GetCell(0, y).Ch; got != ch {
+			t.Errorf("row %d column 0 = %q, want %q", y, got, ch)
+		}
+	}
+
+	// Columns outside the region are untouched
+	if got := screen.GetCell(2, 3).Ch; got != 'x' {
+		t.Errorf("cell outside region = %q, want 'x'", got)
+	}
+	if got := screen.GetCell(1, 3).Ch; got != '.' {
+		t.Errorf("vacated cell in region = %q, want '.'", got)
+	}
+}
+
+func TestScreenInsertDeleteLines(t *testing.T) {
+	fill := goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name string
+		op   func(s *goterm.Screen)
+		want string
+	}{
+		{"insert_one", func(s *goterm.Screen) { s.InsertLines(1, 1, fill) }, "0.123"},
+		{"insert_two_at_top", func(s *goterm.Screen) { s.InsertLines(0, 2, fill) }, "..012"},
+		{"insert_past_bottom", func(s *goterm.Screen) { s.InsertLines(3, 9, fill) }, "012.."},
+		{"delete_one", func(s *goterm.Screen) { s.DeleteLines(1, 1, fill) }, "0234."},
+		{"delete_two_at_top", func(s *goterm.Screen) { s.DeleteLines(0, 2, fill) }, "234.."},
+		{"delete_last", func(s *goterm.Screen) { s.DeleteLines(4, 1, fill) }, "0123."},
+		{"zero_count", func(s *goterm.Screen) { s.InsertLines(1, 0, fill); s.DeleteLines(1, -1, fill) }, "01234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(4, 5)
+			fillRows(screen)
+			tt.op(screen)
+
+			for y, ch := range tt.want {
+				if got := screen.GetCell(0, y).Ch; got != ch {
+					t.Errorf("row %d = %q, want %q", y, got, ch)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func newSelect(screen *goterm.Screen) *widgets.Select {
+	return &widgets.Select{
+		Screen:      screen,
+		Options:     []string{"Low", "Medium", "High"},
+		Selected:    -1,
+		Placeholder: "Pick one",
+	}
+}
+
+func TestSelectDraw(t *testing.T) {
+	screen := goterm.NewScreen(20, 8)
+	sel := newSelect(screen)
+	field := screen.SubView(2, 1, 12, 1)
+
+	sel.Draw(field)
+	if got, want := rowText(screen, 2, 1, 12), "Pick one   ▾"; got != want {
+		t.Errorf("placeholder = %q, want %q", got, want)
+	}
+
+	sel.HandleEvent(key(goterm.KeyEnter))
+	if !sel.IsOpen() {
+		t.Fatal("Enter did not open the list")
+	}
+	sel.Draw(field)
+	out := screen.Composite()
+	want := []string{"Pick one   ▴", "Low         ", "Medium      ", "High        "}
+	for i, row := range want {
+		if got := rowText(out, 2, 1+i, 12); got != row {
+			t.Errorf("row %d = %q, want %q", i, got, row)
+		}
+	}
+
+	sel.HandleEvent(key(goterm.KeyEscape))
+	if sel.IsOpen() {
+		t.Fatal("Escape did not close the list")
+	}
+	if got := rowText(screen.Composite(), 2, 2, 12); got != "            " {
+		t.Errorf("list left behind: %q", got)
+	}
+}
+
+func TestSelectOpensAbove(t *testing.T) {
+	screen := goterm.NewScreen(20, 6)
+	sel := newSelect(screen)
+	sel.Selected = 2
+	sel.Draw(screen.SubView(0, 4, 10, 1))
+	sel.Open()
+
+	out := screen.Composite()
+	for i, row := range []string{"Low       ", "Medium    ", "High      "} {
+		if got := rowText(out, 0, 1+i, 10); got != row {
+			t.Errorf("row %d = %q, want %q", i, got, row)
+		}
+	}
+	if !out.GetCell(0, 3).Style.Has(goterm.StyleReverse) {
+		t.Error("selected option not current")
+	}
+}
+
+func TestSelectInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []goterm.Event
+		want    int
+		changed bool
+	}{
+		{"enter_first", []goterm.Event{key(goterm.KeyEnter), key(goterm.KeyEnter)}, 0, true},
+		{"down_down_enter", []goterm.Event{key(goterm.KeyDown), key(goterm.KeyDown), key(goterm.KeyEnter)}, 1, true},
+		{"escape", []goterm.Event{key(goterm.KeyDown), key(goterm.KeyDown), key(goterm.KeyEscape)}, -1, false},
+		{"type_ahead", []goterm.Event{runeKey('h'), runeKey('i'), key(goterm.KeyEnter)}, 2, true},
+		{"space_opens", []goterm.Event{runeKey(' '), key(goterm.KeyDown), key(goterm.KeyEnter)}, 1, true},
+		{"click_option", []goterm.Event{click(3, 0), click(3, 3)}, 2, true},
+		{"click_outside", []goterm.Event{click(3, 0), click(15, 5)}, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(20, 8)
+			sel := newSelect(screen)
+			changed := false
+			sel.OnChange = func(int) { changed = true }
+			sel.Draw(screen.SubView(0, 0, 10, 1))
+
+			for _, ev := range tt.events {
+				sel.HandleEvent(ev)
+			}
+			if sel.Selected != tt.want {
+				t.Errorf("Selected = %d, want %d", sel.Selected, tt.want)
+			}
+			if changed != tt.changed {
+				t.Errorf("OnChange called = %v, want %v", changed, tt.changed)
+			}
+			if sel.IsOpen() {
+				t.Error("list still open")
+			}
+		})
+	}
+}
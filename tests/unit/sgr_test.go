@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestParseSGR(t *testing.T) {
+	def := goterm.ColorDefault()
+
+	tests := []struct {
+		name  string
+		seq   string
+		fg    goterm.Color
+		bg    goterm.Color
+		style goterm.Style
+	}{
+		{"reset", "\x1b[0m", def, def, goterm.StyleNone},
+		{"empty_params", "\x1b[m", def, def, goterm.StyleNone},
+		{"basic", "\x1b[31;44m", goterm.ColorRed, goterm.ColorBlue, goterm.StyleNone},
+		{"bright", "\x1b[91;102m", goterm.ColorIndex(9), goterm.ColorIndex(10), goterm.StyleNone},
+		{"styles", "\x1b[1;3;4;9m", def, def, goterm.StyleBold | goterm.StyleItalic | goterm.StyleUnderline | goterm.StyleStrikethrough},
+		{"style_off", "\x1b[1;2;7;22m", def, def, goterm.StyleReverse},
+		{"256", "\x1b[38;5;208;48;5;17m", goterm.ColorIndex(208), goterm.ColorIndex(17), goterm.StyleNone},
+		{"rgb", "\x1b[38;2;255;128;0;1m", goterm.ColorRGB(255, 128, 0), def, goterm.StyleBold},
+		{"colon_256", "\x1b[38:5:208m", goterm.ColorIndex(208), def, goterm.StyleNone},
+		{"colon_rgb", "\x1b[48:2::10:20:30m", def, goterm.ColorRGB(10, 20, 30), goterm.StyleNone},
+		{"colon_rgb_no_space", "\x1b[48:2:10:20:30m", def, goterm.ColorRGB(10, 20, 30), goterm.StyleNone},
+		{"underline_curly", "\x1b[4:3m", def, def, goterm.StyleUnderline},
+		{"underline_off", "\x1b[4m\x1b[4:0m", def, def, goterm.StyleNone},
+		{"defaults", "\x1b[31;41m\x1b[39;49m", def, def, goterm.StyleNone},
+		{"cumulative", "\x1b[1m\x1b[32m", goterm.ColorGreen, def, goterm.StyleBold},
+		{"reset_midway", "\x1b[1;31m\x1b[0;34m", goterm.ColorBlue, def, goterm.StyleNone},
+		{"overline", "\x1b[53m", def, def, goterm.StyleOverline},
+		{"overline_off", "\x1b[53;1m\x1b[55m", def, def, goterm.StyleBold},
+		{"unknown_ignored", "\x1b[73;35m", goterm.ColorMagenta, def, goterm.StyleNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fg, bg, style, err := goterm.ParseSGR([]byte(tt.seq))
+			if err != nil {
+				t.Fatalf("ParseSGR(%q) error = %v", tt.seq, err)
+			}
+			if fg != tt.fg || bg != tt.bg || style != tt.style {
+				t.Errorf("ParseSGR(%q) = (%v, %v, %v), want (%v, %v, %v)", tt.seq, fg, bg, style, tt.fg, tt.bg, tt.style)
+			}
+		})
+	}
+}
+
+func TestParseSGRInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"31m",
+		"\x1b[31",
+		"\x1b[31mtext",
+		"\x1b[x1m",
+		"\x1b[38;5m",
+		"\x1b[38;2;1;2m",
+		"\x1b[38;5;300m",
+		"\x1b[38;7;1m",
+	}
+
+	for _, seq := range tests {
+		t.Run(seq, func(t *testing.T) {
+			if _, _, _, err := goterm.ParseSGR([]byte(seq)); !errors.Is(err, goterm.ErrInvalidSGR) {
+				t.Errorf("ParseSGR(%q) error = %v, want ErrInvalidSGR", seq, err)
+			}
+		})
+	}
+}
+
+func TestParseANSI(t *testing.T) {
+	def := goterm.ColorDefault()
+	span := func(text string, fg goterm.Color, style goterm.Style) goterm.Span {
+		return goterm.Span{Text: text, Fg: fg, Bg: def, Style: style}
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []goterm.StyledText
+	}{
+		{"plain", "a\r\nb", []goterm.StyledText{{span("a", def, 0)}, {span("b", def, 0)}}},
+		{"colored", "x\x1b[31mred\x1b[0m y", []goterm.StyledText{
+			{span("x", def, 0), span("red", goterm.ColorRed, 0), span(" y", def, 0)},
+		}},
+		{"carries_over", "\x1b[1mon\nstill\x1b[m", []goterm.StyledText{
+			{span("on", def, goterm.StyleBold)}, {span("still", def, goterm.StyleBold)},
+		}},
+		{"empty_line", "a\n\nb", []goterm.StyledText{{span("a", def, 0)}, nil, {span("b", def, 0)}}},
+		{"other_csi", "a\x1b[2Kb", []goterm.StyledText{{span("ab", def, 0)}}},
+		{"osc", "\x1b]8;;http://x\x1b\\link\x1b]8;;\a", []goterm.StyledText{{span("link", def, 0)}}},
+		{"bad_sgr", "\x1b[38;5m\x1b[32mok", []goterm.StyledText{{span("ok", goterm.ColorGreen, 0)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := goterm.ParseANSI(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseANSI(%q) = %d lines %v, want %d", tt.text, len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if len(got[i]) != len(tt.want[i]) {
+					t.Fatalf("line %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+				for j := range got[i] {
+					if got[i][j] != tt.want[i][j] {
+						t.Errorf("line %d span %d = %+v, want %+v", i, j, got[i][j], tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
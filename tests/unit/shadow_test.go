@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestDrawShadowRegion(t *testing.T) {
+	screen := goterm.NewScreen(8, 5)
+	screen.Fill(0, 0, 8, 5, goterm.NewCell('.', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+	screen.DrawShadow(goterm.Rect{X: 1, Y: 1, W: 4, H: 2})
+
+	// s marks the cells expected to be shaded
+	want := []string{
+		"........",
+		"........",
+		".....ss.",
+		"...ssss.",
+		"........",
+	}
+	for y, row := range want {
+		for x, ch := range row {
+			cell := screen.GetCell(x, y)
+			if shaded := cell.Style.Has(goterm.StyleDim); shaded != (ch == 's') {
+				t.Errorf("cell (%d, %d) dimmed = %v, want %v", x, y, shaded, ch == 's')
+			}
+			if cell.Ch != '.' {
+				t.Errorf("cell (%d, %d) character changed to %q", x, y, cell.Ch)
+			}
+		}
+	}
+}
+
+func TestDrawShadowRGB(t *testing.T) {
+	screen := goterm.NewScreen(4, 3)
+	screen.Fill(0, 0, 4, 3, goterm.NewCell('x', goterm.ColorRGB(200, 100, 50), goterm.ColorRGB(100, 100, 100), goterm.StyleBold))
+	screen.DrawShadow(goterm.Rect{W: 2, H: 2})
+
+	cell := screen.GetCell(2, 1)
+	if r, g, b := cell.Fg.RGB(); r != 80 || g != 40 || b != 20 {
+		t.Errorf("shadow fg = (%d, %d, %d), want (80, 40, 20)", r, g, b)
+	}
+	if r, g, b := cell.Bg.RGB(); r != 40 || g != 40 || b != 40 {
+		t.Errorf("shadow bg = (%d, %d, %d), want (40, 40, 40)", r, g, b)
+	}
+	if cell.Style != goterm.StyleBold {
+		t.Errorf("shadow style = %v, want unchanged bold", cell.Style)
+	}
+
+	// Shadows are clipped at the screen edge
+	screen.DrawShadow(goterm.Rect{X: 2, Y: 1, W: 2, H: 2})
+}
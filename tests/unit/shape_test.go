@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// drawnRows returns the rows of the screen as strings, showing blanks as '.'
+func drawnRows(screen *goterm.Screen) []string {
+	w, h := screen.Size()
+	rows := make([]string, h)
+	for y := range rows {
+		var row []rune
+		for x := 0; x < w; x++ {
+			ch := screen.GetCell(x, y).Ch
+			if ch == ' ' {
+				ch = '.'
+			}
+			row = append(row, ch)
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
+
+func TestDrawLine(t *testing.T) {
+	star := goterm.NewCell('*', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		want           []string
+	}{
+		{"horizontal", 1, 1, 4, 1, []string{"......", ".****.", "......", "......"}},
+		{"vertical", 2, 0, 2, 3, []string{"..*...", "..*...", "..*...", "..*..."}},
+		{"diagonal", 0, 0, 3, 3, []string{"*.....", ".*....", "..*...", "...*.."}},
+		{"shallow", 0, 0, 5, 2, []string{"**....", "..**..", "....**", "......"}},
+		{"reversed", 5, 2, 0, 0, []string{"**....", "..**..", "....**", "......"}},
+		{"single_point", 3, 3, 3, 3, []string{"......", "......", "......", "...*.."}},
+		{"clipped", -2, 1, 8, 1, []string{"......", "******", "......", "......"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(6, 4)
+			screen.DrawLine(tt.x1, tt.y1, tt.x2, tt.y2, star)
+
+			for y, row := range drawnRows(screen) {
+				if row != tt.want[y] {
+					t.Errorf("row %d = %q, want %q", y, row, tt.want[y])
+				}
+			}
+		})
+	}
+}
+
+func TestDrawSlopedLine(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		want           []string
+	}{
+		{"falling", 0, 0, 3, 3, []string{`\.....`, `.\....`, `..\...`, `...\..`}},
+		{"rising", 0, 3, 3, 0, []string{`.../..`, `../...`, `./....`, `/.....`}},
+		{"horizontal", 1, 2, 4, 2, []string{`......`, `......`, `.----.`, `......`}},
+		{"vertical", 5, 0, 5, 2, []string{`.....|`, `.....|`, `.....|`, `......`}},
+		{"shallow", 0, 0, 5, 2, []string{`-\....`, `..-\..`, `....--`, `......`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(6, 4)
+			screen.DrawSlopedLine(tt.x1, tt.y1, tt.x2, tt.y2, goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+			for y, row := range drawnRows(screen) {
+				if row != tt.want[y] {
+					t.Errorf("row %d = %q, want %q", y, row, tt.want[y])
+				}
+			}
+		})
+	}
+}
+
+func TestDrawEllipse(t *testing.T) {
+	o := goterm.NewCell('o', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name string
+		draw func(s *goterm.Screen)
+		want []string
+	}{
+		{"circle_outline", func(s *goterm.Screen) { s.DrawCircle(5, 2, 2, o) }, []string{
+			"...ooooo...",
+			".oo.....oo.",
+			".o.......o.",
+			".oo.....oo.",
+			"...ooooo...",
+		}},
+		{"circle_filled", func(s *goterm.Screen) { s.FillCircle(5, 2, 2, o) }, []string{
+			"...ooooo...",
+			".ooooooooo.",
+			".ooooooooo.",
+			".ooooooooo.",
+			"...ooooo...",
+		}},
+		{"ellipse_outline", func(s *goterm.Screen) { s.DrawEllipse(5, 2, 3, 1, o) }, []string{
+			"...........",
+			"...ooooo...",
+			"..o.....o..",
+			"...ooooo...",
+			"...........",
+		}},
+		{"zero_radius", func(s *goterm.Screen) { s.DrawEllipse(1, 1, 0, 0, o) }, []string{
+			"...........",
+			".o.........",
+			"...........",
+		}},
+		{"negative_radius", func(s *goterm.Screen) { s.FillEllipse(1, 1, -1, 2, o) }, []string{
+			"...........",
+			"...........",
+			"...........",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(11, 5)
+			tt.draw(screen)
+
+			rows := drawnRows(screen)
+			for y, want := range tt.want {
+				if rows[y] != want {
+					t.Errorf("row %d = %q, want %q", y, rows[y], want)
+				}
+			}
+		})
+	}
+}
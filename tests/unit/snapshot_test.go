@@ -0,0 +1,48 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	screen := goterm.NewScreen(8, 3)
+	screen.DrawText(0, 0, "menu", goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleBold)
+	screen.DrawText(0, 1, "世界", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	snap := screen.Snapshot()
+	if w, h := snap.Size(); w != 8 || h != 3 {
+		t.Fatalf("snapshot Size() = (%d, %d), want (8, 3)", w, h)
+	}
+
+	// Draw a modal dialog over the content, then dismiss it
+	screen.DrawBox(1, 0, 6, 3, goterm.BorderDouble, goterm.ColorRed, goterm.ColorDefault())
+	if got := rowText(snap, 0, 0, 4); got != "menu" {
+		t.Errorf("snapshot changed with screen: %q", got)
+	}
+	screen.Restore(snap)
+
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 8; x++ {
+			if got, want := screen.GetCell(x, y), snap.GetCell(x, y); !got.Equal(want) {
+				t.Errorf("cell (%d, %d) = %+v, want %+v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestRestoreAfterResize(t *testing.T) {
+	screen := goterm.NewScreen(4, 2)
+	screen.DrawText(0, 0, "abcd", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawText(0, 1, "efgh", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	snap := screen.Snapshot()
+
+	screen.Resize(6, 1)
+	screen.DrawText(0, 0, "xxxxxx", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.Restore(snap)
+
+	if got := rowText(screen, 0, 0, 6); got != "abcd  " {
+		t.Errorf("row 0 = %q, want %q", got, "abcd  ")
+	}
+}
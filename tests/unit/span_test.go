@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestStyledText(t *testing.T) {
+	text := goterm.StyledText{}.
+		Add("HP: ", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone).
+		Add("世界", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleBold)
+
+	if len(text) != 2 {
+		t.Fatalf("len = %d, want 2", len(text))
+	}
+	if got := text.String(); got != "HP: 世界" {
+		t.Errorf("String() = %q, want %q", got, "HP: 世界")
+	}
+	if got := text.Width(); got != 8 {
+		t.Errorf("Width() = %d, want 8", got)
+	}
+}
+
+func TestDrawSpans(t *testing.T) {
+	screen := goterm.NewScreen(12, 2)
+	spans := goterm.StyledText{}.
+		Add("ab", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone).
+		Add("世", goterm.ColorRed, goterm.ColorBlue, goterm.StyleBold).
+		Add("c\td", goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleNone)
+
+	end := screen.DrawSpans(1, 0, spans)
+	if end != 10 {
+		t.Errorf("DrawSpans returned %d, want 10", end)
+	}
+	if got := rowText(screen, 1, 0, 9); got != "ab世 c   d" {
+		t.Errorf("row = %q, want %q", got, "ab世 c   d")
+	}
+
+	tests := []struct {
+		x     int
+		fg    goterm.Color
+		style goterm.Style
+	}{
+		{1, goterm.ColorWhite, goterm.StyleNone},
+		{3, goterm.ColorRed, goterm.StyleBold},
+		{5, goterm.ColorGreen, goterm.StyleNone},
+	}
+	for _, tt := range tests {
+		cell := screen.GetCell(tt.x, 0)
+		if cell.Fg != tt.fg || cell.Style != tt.style {
+			t.Errorf("cell %d = fg %v style %v, want fg %v style %v", tt.x, cell.Fg, cell.Style, tt.fg, tt.style)
+		}
+	}
+}
+
+func TestDrawSpansNewline(t *testing.T) {
+	screen := goterm.NewScreen(8, 2)
+	spans := []goterm.Span{
+		{Text: "ab\nc", Fg: goterm.ColorWhite, Bg: goterm.ColorDefault()},
+		{Text: "d", Fg: goterm.ColorRed, Bg: goterm.ColorDefault()},
+	}
+
+	if end := screen.DrawSpans(2, 0, spans); end != 4 {
+		t.Errorf("DrawSpans returned %d, want 4", end)
+	}
+	if got := rowText(screen, 2, 1, 2); got != "cd" {
+		t.Errorf("row 1 = %q, want %q", got, "cd")
+	}
+}
@@ -0,0 +1,36 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestSpinner(t *testing.T) {
+	tests := []struct {
+		name    string
+		spinner widgets.Spinner
+		ticks   int
+		want    string
+	}{
+		{"default", widgets.Spinner{}, 0, "|     "},
+		{"tick", widgets.Spinner{}, 1, "/     "},
+		{"wraps", widgets.Spinner{}, 5, "/     "},
+		{"dots", widgets.Spinner{Frames: widgets.SpinnerDots}, 2, "⣻     "},
+		{"label", widgets.Spinner{Frames: widgets.SpinnerBraille, Label: "Load"}, 0, "⠋ Load"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := goterm.NewBuffer(6, 1)
+			for range tt.ticks {
+				tt.spinner.Tick()
+			}
+			tt.spinner.Draw(buf)
+			if got := rowText(buf, 0, 0, 6); got != tt.want {
+				t.Errorf("Draw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
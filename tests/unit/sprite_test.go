@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+const shipArt = ` /\
+<==]
+ \/`
+
+func TestSpriteFromText(t *testing.T) {
+	sp := goterm.SpriteFromText(shipArt, goterm.ColorCyan, goterm.ColorDefault())
+
+	if w, h := sp.Size(); w != 4 || h != 3 {
+		t.Fatalf("Size() = (%d, %d), want (4, 3)", w, h)
+	}
+	if !sp.GetCell(0, 0).Transparent() || !sp.GetCell(3, 0).Transparent() {
+		t.Error("spaces and padding should be transparent")
+	}
+	if cell := sp.GetCell(1, 1); cell.Ch != '=' || cell.Fg != goterm.ColorCyan {
+		t.Errorf("GetCell(1, 1) = %+v, want cyan '='", cell)
+	}
+
+	// The blank half of a wide character stays opaque
+	wide := goterm.SpriteFromText("世", goterm.ColorWhite, goterm.ColorDefault())
+	if wide.GetCell(1, 0).Transparent() {
+		t.Error("wide character continuation became transparent")
+	}
+}
+
+func TestNewSprite(t *testing.T) {
+	sp := goterm.NewSprite(2, 2)
+	if !sp.GetCell(1, 1).Transparent() {
+		t.Error("NewSprite cells should start transparent")
+	}
+}
+
+func TestDrawSprite(t *testing.T) {
+	sp := goterm.SpriteFromText(shipArt, goterm.ColorCyan, goterm.ColorDefault())
+
+	tests := []struct {
+		name string
+		opts goterm.SpriteOptions
+		want []string
+	}{
+		{"plain", goterm.SpriteOptions{}, []string{`../\..`, `.<==].`, `..\/..`}},
+		{"flip_h", goterm.SpriteOptions{FlipH: true}, []string{`../\..`, `.[==>.`, `..\/..`}},
+		{"flip_v", goterm.SpriteOptions{FlipV: true}, []string{`../\..`, `.<==].`, `..\/..`}},
+		{"flip_both", goterm.SpriteOptions{FlipH: true, FlipV: true}, []string{`../\..`, `.[==>.`, `..\/..`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(6, 3)
+			screen.Fill(0, 0, 6, 3, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			screen.DrawSprite(1, 0, sp, tt.opts)
+
+			for y, want := range tt.want {
+				if got := rowText(screen, 0, y, 6); got != want {
+					t.Errorf("row %d = %q, want %q", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawSpriteRecolor(t *testing.T) {
+	sp := goterm.SpriteFromText("ab", goterm.ColorCyan, goterm.ColorBlue)
+	screen := goterm.NewScreen(3, 1)
+	screen.DrawSprite(0, 0, sp, goterm.SpriteOptions{Recolor: true, Fg: goterm.ColorRed})
+
+	for x := 0; x < 2; x++ {
+		if cell := screen.GetCell(x, 0); cell.Fg != goterm.ColorRed || cell.Bg != goterm.ColorBlue {
+			t.Errorf("cell %d = %v on %v, want red on blue", x, cell.Fg, cell.Bg)
+		}
+	}
+	if got := sp.GetCell(0, 0).Fg; got != goterm.ColorCyan {
+		t.Errorf("recolor modified the sprite: %v", got)
+	}
+}
+
+func TestDrawSpriteFlipWide(t *testing.T) {
+	sp := goterm.SpriteFromText("a世", goterm.ColorWhite, goterm.ColorDefault())
+	screen := goterm.NewScreen(3, 1)
+	screen.DrawSprite(0, 0, sp, goterm.SpriteOptions{FlipH: true})
+
+	if got := rowText(screen, 0, 0, 3); got != "世 a" {
+		t.Errorf("row = %q, want %q", got, "世 a")
+	}
+}
@@ -0,0 +1,78 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// textWidget draws its text at the top-left corner and leaves the rest of
+// its surface untouched
+type textWidget string
+
+func (t textWidget) Draw(s goterm.Surface) {
+	s.DrawText(0, 0, string(t), goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+}
+
+func TestStack(t *testing.T) {
+	screen := goterm.NewScreen(12, 3)
+	screen.Fill(0, 0, 12, 3, goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+	st := goterm.NewStack(screen, 10)
+	base := &sizeRecorder{ch: 'b'}
+	menu, tip := textWidget("menu"), textWidget("ti")
+
+	st.Push(base)
+	screen.AddLayout(func(area goterm.Rect) []goterm.Placement {
+		return []goterm.Placement{{Rect: goterm.Rect{X: 2, Y: 1, W: 8, H: 2}, Widget: st}}
+	})
+	screen.Relayout()
+	st.Push(menu)
+	st.Push(tip)
+
+	tests := []struct {
+		name string
+		op   func()
+		want []string
+	}{
+		{"pushed", func() {}, []string{"            ", "  tinubbbb  ", "  bbbbbbbb  "}},
+		{"pop", func() { st.Pop() }, []string{"            ", "  menubbbb  ", "  bbbbbbbb  "}},
+		{"hidden", func() { st.Layer(menu).SetVisible(false) }, []string{"            ", "  bbbbbbbb  ", "  bbbbbbbb  "}},
+		{"resized", func() {
+			st.Layer(menu).SetVisible(true)
+			screen.HandleResize(goterm.ResizeEvent{Width: 11, Height: 4})
+		}, []string{"           ", "  menubbbb ", "  bbbbbbbb ", "           "}},
+	}
+	for _, tt := range tests {
+		tt.op()
+		b := screen.Composite()
+		for y, row := range tt.want {
+			if got := rowText(b, 0, y, len(row)); got != row {
+				t.Errorf("%s: row %d = %q, want %q", tt.name, y, got, row)
+			}
+		}
+	}
+
+	if got := st.Rect(); got != (goterm.Rect{X: 2, Y: 1, W: 8, H: 2}) {
+		t.Errorf("Rect() = %+v", got)
+	}
+	if st.Top() != goterm.Drawable(menu) || st.Len() != 2 {
+		t.Errorf("Top() = %v, Len() = %d", st.Top(), st.Len())
+	}
+	if !st.Remove(base) || st.Remove(base) || st.Layer(menu).Z() != 10 {
+		t.Errorf("Remove did not move the children above down")
+	}
+	if st.Pop() != goterm.Drawable(menu) || st.Pop() != nil {
+		t.Error("Pop() on the last child")
+	}
+}
+
+func TestStackOnBuffer(t *testing.T) {
+	st := goterm.NewStack(goterm.NewScreen(4, 1), 0)
+	st.Push(&sizeRecorder{ch: 'x'})
+	st.Push(textWidget("ab"))
+	b := goterm.NewBuffer(4, 1)
+	st.Draw(b)
+	if got := rowText(b, 0, 0, 4); got != "abxx" {
+		t.Errorf("drawn onto a buffer = %q, want %q", got, "abxx")
+	}
+}
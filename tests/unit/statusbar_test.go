@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func plain(text string) goterm.StyledText {
+	return goterm.StyledText{}.Add(text, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+}
+
+func TestStatusBar(t *testing.T) {
+	tests := []struct {
+		name                string
+		left, center, right string
+		want                string
+	}{
+		{"all_fit", "NORMAL", "main.go", "1:1", "NORMAL   main.go      1:1"},
+		{"center_shifted", "INSERT MODE", "main.go", "1:1", "INSERT MODE main.go   1:1"},
+		{"center_truncated", "INSERT MODE", "internal/main.go", "12:4", "INSERT MODE interna… 12:4"},
+		{"left_truncated", "a very long mode name here", "x", "12:4", "a very long mode na… 12:4"},
+		{"right_only", "", "", "Ln 1, Col 1", "              Ln 1, Col 1"},
+		{"right_truncated", "", "", "a status far too long for the bar", "a status far too long fo…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar := widgets.StatusBar{Left: plain(tt.left), Center: plain(tt.center), Right: plain(tt.right)}
+			buf := goterm.NewBuffer(25, 1)
+			bar.Draw(buf)
+			if got := rowText(buf, 0, 0, 25); got != tt.want {
+				t.Errorf("Draw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusBarSegmentStyles(t *testing.T) {
+	bar := widgets.StatusBar{
+		Left: goterm.StyledText{}.
+			Add(" N ", goterm.ColorBlack, goterm.ColorGreen, goterm.StyleBold).
+			Add(" main ", goterm.ColorWhite, goterm.ColorBlue, goterm.StyleNone),
+		Bg: goterm.ColorRGB(40, 40, 40),
+	}
+	buf := goterm.NewBuffer(20, 1)
+	bar.Draw(buf)
+
+	if c := buf.GetCell(1, 0); c.Bg != goterm.ColorGreen || c.Style != goterm.StyleBold {
+		t.Errorf("first segment cell = %+v", c)
+	}
+	if c := buf.GetCell(4, 0); c.Bg != goterm.ColorBlue {
+		t.Errorf("second segment cell = %+v", c)
+	}
+	if c := buf.GetCell(15, 0); c.Bg != goterm.ColorRGB(40, 40, 40) {
+		t.Errorf("fill cell = %+v", c)
+	}
+}
@@ -1,6 +1,8 @@
 package unit
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/dshills/goterm"
@@ -60,6 +62,7 @@ func TestStyleCombinations(t *testing.T) {
 			goterm.StyleReverse,
 			goterm.StyleConceal,
 			goterm.StyleStrikethrough,
+			goterm.StyleOverline,
 		}},
 	}
 
@@ -111,6 +114,7 @@ func TestStyleNone(t *testing.T) {
 		goterm.StyleReverse,
 		goterm.StyleConceal,
 		goterm.StyleStrikethrough,
+		goterm.StyleOverline,
 	}
 
 	for _, style := range styles {
@@ -119,3 +123,65 @@ func TestStyleNone(t *testing.T) {
 		}
 	}
 }
+
+func TestStyleString(t *testing.T) {
+	tests := []struct {
+		style goterm.Style
+		want  string
+	}{
+		{goterm.StyleNone, "none"},
+		{goterm.StyleBold, "bold"},
+		{goterm.StyleUnderline | goterm.StyleBold | goterm.StyleItalic, "bold|italic|underline"},
+		{goterm.StyleStrikethrough | goterm.StyleOverline, "strikethrough|overline"},
+		{goterm.StyleDim | goterm.Style(1<<12), "dim|0x1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.style.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := fmt.Sprint(tt.style); got != tt.want {
+				t.Errorf("fmt.Sprint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    goterm.Style
+		wantErr bool
+	}{
+		{"bold,underline,italic", goterm.StyleBold | goterm.StyleUnderline | goterm.StyleItalic, false},
+		{"Bold | Reverse", goterm.StyleBold | goterm.StyleReverse, false},
+		{"dim+strike", goterm.StyleDim | goterm.StyleStrikethrough, false},
+		{"blink inverse hidden faint", goterm.StyleSlowBlink | goterm.StyleReverse | goterm.StyleConceal | goterm.StyleDim, false},
+		{"overline", goterm.StyleOverline, false},
+		{"none", goterm.StyleNone, false},
+		{"", goterm.StyleNone, false},
+		{"bold,sparkly", goterm.StyleNone, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := goterm.ParseStyle(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, goterm.ErrInvalidStyle) {
+					t.Errorf("ParseStyle(%q) error = %v, want ErrInvalidStyle", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseStyle(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+
+	// String output parses back to the same style
+	all := goterm.StyleAll
+	if got, err := goterm.ParseStyle(all.String()); err != nil || got != all {
+		t.Errorf("ParseStyle(%q) = %v, %v, want StyleAll", all.String(), got, err)
+	}
+}
@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestTags(t *testing.T) {
+	screen := goterm.NewScreen(10, 2)
+	screen.DrawText(0, 0, "[OK] [No]", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	screen.SetTag(goterm.Rect{X: 0, Y: 0, W: 4, H: 1}, 1)
+	screen.SetTag(goterm.Rect{X: 5, Y: 0, W: 4, H: 1}, 2)
+
+	// A tagged sprite keeps its tag when drawn
+	icon := goterm.SpriteFromText("@", goterm.ColorRed, goterm.ColorDefault())
+	icon.SetTag(icon.Bounds(), 3)
+	screen.DrawSprite(2, 1, icon, goterm.SpriteOptions{})
+
+	// Layer cells cover the tags beneath them
+	layer := screen.AddLayer(1)
+	layer.DrawText(6, 0, "!", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone)
+	layer.SetTag(goterm.Rect{X: 5, Y: 0, W: 2, H: 2}, 4)
+
+	// A translucent shade keeps the tag beneath it
+	shade := screen.AddLayer(2)
+	shade.Fill(0, 0, 2, 1, goterm.NewCell(' ', goterm.ColorDefault(), goterm.ColorRGBA(0, 0, 0, 128), goterm.StyleNone))
+
+	tests := []struct {
+		name string
+		x, y int
+		want int
+	}{
+		{"first_button", 1, 0, 1},
+		{"shaded", 0, 0, 1},
+		{"second_button", 8, 0, 2},
+		{"layer", 6, 0, 4},
+		{"transparent_layer_cell", 5, 0, 2},
+		{"gap", 4, 0, 0},
+		{"sprite", 2, 1, 3},
+		{"untagged", 3, 1, 0},
+		{"out_of_bounds", 10, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := screen.TagAt(tt.x, tt.y); got != tt.want {
+				t.Errorf("TagAt(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+
+	// Redrawing a cell replaces its tag and keeps the text
+	screen.SetCell(1, 0, goterm.NewCell('o', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+	if got := screen.TagAt(1, 0); got != 0 {
+		t.Errorf("TagAt after redraw = %d, want 0", got)
+	}
+	if got := screen.GetCell(2, 0).Ch; got != 'K' {
+		t.Errorf("SetTag changed content: got %q, want 'K'", got)
+	}
+}
@@ -0,0 +1,171 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		maxCols int
+		tail    string
+		want    string
+	}{
+		{"fits", "Hello", 10, "…", "Hello"},
+		{"exact_fit", "Hello", 5, "…", "Hello"},
+		{"ellipsis", "Hello, World", 8, "…", "Hello, …"},
+		{"ascii_tail", "Hello, World", 8, "...", "Hello..."},
+		{"no_tail", "Hello, World", 5, "", "Hello"},
+		{"wide_boundary", "世界世界", 5, "…", "世界…"},
+		{"wide_not_split", "世界世界", 4, "…", "世…"},
+		{"combining_kept", "e\u0301e\u0301e\u0301e\u0301", 3, "…", "e\u0301e\u0301…"},
+		{"emoji_sequence", "\U0001F468\u200d\U0001F469\u200d\U0001F467 family", 4, "…", "\U0001F468\u200d\U0001F469\u200d\U0001F467 …"},
+		{"tail_too_wide", "Hello", 2, "...", "He"},
+		{"zero_width", "Hello", 0, "…", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := goterm.Truncate(tt.s, tt.maxCols, tt.tail)
+			if got != tt.want {
+				t.Errorf("Truncate(%q, %d, %q) = %q, want %q", tt.s, tt.maxCols, tt.tail, got, tt.want)
+			}
+			if w := goterm.StringWidth(got); w > tt.maxCols {
+				t.Errorf("Truncate(%q, %d, %q) width = %d, exceeds limit", tt.s, tt.maxCols, tt.tail, w)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  []string
+	}{
+		{"fits", "hello world", 20, []string{"hello world"}},
+		{"word_boundary", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"long_word", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"newlines", "one\ntwo three", 5, []string{"one", "two", "three"}},
+		{"empty_line", "a\n\nb", 5, []string{"a", "", "b"}},
+		{"cjk", "日本語のテキスト", 6, []string{"日本語", "のテキ", "スト"}},
+		{"mixed_cjk", "go 言語です", 6, []string{"go 言", "語です"}},
+		{"extra_spaces", "a  b", 1, []string{"a", "b"}},
+		{"empty", "", 5, []string{""}},
+		{"zero_width", "text", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := goterm.Wrap(tt.s, tt.width)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Wrap(%q, %d)[%d] = %q, want %q", tt.s, tt.width, i, got[i], tt.want[i])
+				}
+				if w := goterm.StringWidth(got[i]); w > tt.width {
+					t.Errorf("Wrap(%q, %d)[%d] width = %d, exceeds limit", tt.s, tt.width, i, w)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawTextWrapped(t *testing.T) {
+	screen := goterm.NewScreen(20, 5)
+	n := screen.DrawTextWrapped(2, 1, 6, "wrap this text", goterm.ColorGreen, goterm.ColorDefault(), goterm.StyleNone)
+	if n != 3 {
+		t.Fatalf("DrawTextWrapped() = %d lines, want 3", n)
+	}
+
+	want := []string{"wrap", "this", "text"}
+	for i, line := range want {
+		for j, ch := range line {
+			if got := screen.GetCell(2+j, 1+i).Ch; got != ch {
+				t.Errorf("GetCell(%d, %d).Ch = %q, want %q", 2+j, 1+i, got, ch)
+			}
+		}
+	}
+}
+
+func TestDrawTextTabsAndNewlines(t *testing.T) {
+	screen := goterm.NewScreen(20, 4)
+	screen.DrawText(2, 0, "a\tb\nc\r\nd", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	// Tab stops are counted from the starting column
+	if got := screen.GetCell(10, 0).Ch; got != 'b' {
+		t.Errorf("GetCell(10, 0).Ch = %q, want 'b' at first tab stop", got)
+	}
+	if got := screen.GetCell(2, 1).Ch; got != 'c' {
+		t.Errorf("GetCell(2, 1).Ch = %q, want 'c' after newline", got)
+	}
+	if got := screen.GetCell(2, 2).Ch; got != 'd' {
+		t.Errorf("GetCell(2, 2).Ch = %q, want 'd' after CRLF", got)
+	}
+
+	screen.SetTabWidth(4)
+	screen.DrawText(0, 3, "ab\tc", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	if got := screen.GetCell(4, 3).Ch; got != 'c' {
+		t.Errorf("GetCell(4, 3).Ch = %q, want 'c' with tab width 4", got)
+	}
+}
+
+func TestDrawTextControlCharacters(t *testing.T) {
+	screen := goterm.NewScreen(10, 1)
+	screen.DrawText(0, 0, "a\x00\x07\x1b\x7fz", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	want := []rune{'a', '␀', '␇', '␛', '␡', 'z'}
+	for x, ch := range want {
+		if got := screen.GetCell(x, 0).Ch; got != ch {
+			t.Errorf("GetCell(%d, 0).Ch = %q, want %q", x, got, ch)
+		}
+	}
+}
+
+func TestDrawTextUnprintablePolicy(t *testing.T) {
+	text := "a\x85b\xffc"
+
+	tests := []struct {
+		name     string
+		policy   goterm.UnprintablePolicy
+		fallback rune
+		want     string
+	}{
+		{"replace", goterm.UnprintableReplace, 0, "a�b�c"},
+		{"fallback", goterm.UnprintableFallback, '?', "a?b?c"},
+		{"transliterate", goterm.UnprintableTransliterate, 0, `a\x85b\xFFc`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(20, 1)
+			screen.SetUnprintablePolicy(tt.policy, tt.fallback)
+			screen.DrawText(0, 0, text, goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+			for x, ch := range []rune(tt.want) {
+				if got := screen.GetCell(x, 0).Ch; got != ch {
+					t.Errorf("GetCell(%d, 0).Ch = %q, want %q", x, got, ch)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawTextPrintableUnaffected(t *testing.T) {
+	screen := goterm.NewScreen(20, 1)
+	screen.SetUnprintablePolicy(goterm.UnprintableFallback, '?')
+	screen.DrawText(0, 0, "é世", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	want := map[int]rune{0: 'é', 1: '世', 3: ''}
+	for x, ch := range want {
+		if got := screen.GetCell(x, 0).Ch; got != ch {
+			t.Errorf("GetCell(%d, 0).Ch = %q, want %q", x, got, ch)
+		}
+	}
+}
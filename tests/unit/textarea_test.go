@@ -0,0 +1,141 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func typeText(ta *widgets.TextArea, text string) {
+	for _, r := range text {
+		ta.HandleEvent(runeKey(r))
+	}
+}
+
+func ctrlKey(r rune) goterm.KeyEvent {
+	return goterm.KeyEvent{Key: goterm.KeyRune, Rune: r, Modifiers: goterm.ModCtrl}
+}
+
+func shiftKey(k goterm.Key) goterm.KeyEvent {
+	return goterm.KeyEvent{Key: k, Modifiers: goterm.ModShift}
+}
+
+func TestTextAreaEditing(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		events []goterm.Event
+		want   string
+		line   int
+		col    int
+	}{
+		{"type", "", []goterm.Event{runeKey('h'), runeKey('i'), key(goterm.KeyEnter), runeKey('!')}, "hi\n!", 1, 1},
+		{"backspace_joins", "ab\ncd", []goterm.Event{key(goterm.KeyDown), key(goterm.KeyBackspace)}, "abcd", 0, 2},
+		{"delete", "abc", []goterm.Event{key(goterm.KeyDelete)}, "bc", 0, 0},
+		{"end_of_line", "abc\nd", []goterm.Event{key(goterm.KeyEnd), runeKey('x')}, "abcx\nd", 0, 4},
+		{"vertical_keeps_column", "abcd\nx\nabcd", []goterm.Event{key(goterm.KeyEnd), key(goterm.KeyDown), key(goterm.KeyDown)}, "abcd\nx\nabcd", 2, 4},
+		{"shift_select_replace", "hello", []goterm.Event{shiftKey(goterm.KeyRight), shiftKey(goterm.KeyRight), runeKey('J')}, "Jllo", 0, 1},
+		{"cut_paste", "ab", []goterm.Event{ctrlKey('a'), ctrlKey('x'), ctrlKey('v'), ctrlKey('v')}, "abab", 0, 4},
+		{"paste_event", "", []goterm.Event{goterm.PasteEvent{Text: "a\tb\r\nc"}}, "a    b\nc", 1, 1},
+		{"click", "abc\ndef", []goterm.Event{click(1, 1)}, "abc\ndef", 1, 1},
+		{"click_past_end", "abc\ndef", []goterm.Event{click(9, 0)}, "abc\ndef", 0, 3},
+		{"combining_mark", "", []goterm.Event{runeKey('e'), runeKey('\u0301'), key(goterm.KeyLeft)}, "e\u0301", 0, 0},
+		{"right_over_cluster", "e\u0301x", []goterm.Event{key(goterm.KeyRight)}, "e\u0301x", 0, 2},
+		{"backspace_cluster", "ae\u0301", []goterm.Event{key(goterm.KeyEnd), key(goterm.KeyBackspace)}, "a", 0, 1},
+		{"click_after_cluster", "e\u0301x", []goterm.Event{click(1, 0)}, "e\u0301x", 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta := &widgets.TextArea{Clipboard: &widgets.MemoryClipboard{}}
+			ta.SetText(tt.text)
+			ta.Draw(goterm.NewBuffer(10, 3))
+			for _, ev := range tt.events {
+				ta.HandleEvent(ev)
+			}
+
+			if got := ta.Text(); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+			if line, col := ta.Cursor(); line != tt.line || col != tt.col {
+				t.Errorf("Cursor() = %d, %d, want %d, %d", line, col, tt.line, tt.col)
+			}
+		})
+	}
+}
+
+func TestTextAreaWrapAndScroll(t *testing.T) {
+	ta := &widgets.TextArea{}
+	ta.SetText("abcdefgh\nij\nkl\nmn")
+	buf := goterm.NewBuffer(5, 3)
+	ta.Draw(buf)
+
+	// The long line wraps onto a second row
+	if got := rowText(buf, 0, 0, 5) + "|" + rowText(buf, 0, 1, 5); got != "abcde|fgh  " {
+		t.Errorf("wrapped rows = %q", got)
+	}
+
+	// Moving down by display rows through the wrapped line scrolls the view
+	for range 3 {
+		ta.HandleEvent(key(goterm.KeyDown))
+	}
+	ta.Draw(buf)
+	if line, col := ta.Cursor(); line != 2 || col != 0 {
+		t.Errorf("Cursor() = %d, %d, want 2, 0", line, col)
+	}
+	if got := rowText(buf, 0, 0, 2); got != "fg" {
+		t.Errorf("top row = %q, want %q", got, "fg")
+	}
+	if got := buf.GetCell(0, 2).Style; !got.Has(goterm.StyleReverse) {
+		t.Error("cursor not drawn")
+	}
+}
+
+func TestTextAreaClusters(t *testing.T) {
+	ta := &widgets.TextArea{}
+	ta.SetText("e\u0301中x")
+	ta.HandleEvent(key(goterm.KeyEnd))
+	buf := goterm.NewBuffer(6, 1)
+	ta.Draw(buf)
+
+	// Each cluster takes the cells it is displayed in, as with DrawText
+	want := goterm.NewBuffer(6, 1)
+	want.DrawText(0, 0, "e\u0301中x", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	if got, want := rowText(buf, 0, 0, 4), rowText(want, 0, 0, 4); got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+	if !buf.GetCell(4, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("cursor not drawn after the last cluster")
+	}
+
+	// Three steps left cross the three clusters
+	for range 3 {
+		ta.HandleEvent(key(goterm.KeyLeft))
+	}
+	if line, col := ta.Cursor(); line != 0 || col != 0 {
+		t.Errorf("Cursor() = %d, %d, want 0, 0", line, col)
+	}
+}
+
+func TestTextAreaSelection(t *testing.T) {
+	clip := &widgets.MemoryClipboard{}
+	ta := &widgets.TextArea{Clipboard: clip, ReadOnly: true}
+	ta.SetText("one\ntwo\nthree")
+	ta.Draw(goterm.NewBuffer(10, 3))
+
+	// Drag from the middle of the first line into the third
+	ta.HandleEvent(click(1, 0))
+	ta.HandleEvent(goterm.MouseEvent{X: 2, Y: 2, Button: goterm.MouseLeft, Action: goterm.MouseMotion})
+	ta.HandleEvent(ctrlKey('c'))
+
+	if got := clip.Paste(); got != "ne\ntwo\nth" {
+		t.Errorf("copied %q, want %q", got, "ne\ntwo\nth")
+	}
+	if ta.HandleEvent(runeKey('x')) {
+		t.Error("read-only text area accepted typing")
+	}
+	if got := ta.Text(); got != "one\ntwo\nthree" {
+		t.Errorf("Text() = %q after typing into read-only text area", got)
+	}
+}
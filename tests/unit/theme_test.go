@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    goterm.Color
+		wantErr bool
+	}{
+		{"default", goterm.ColorDefault(), false},
+		{"", goterm.ColorDefault(), false},
+		{"#ff8000", goterm.ColorRGB(255, 128, 0), false},
+		{"  #f80 ", goterm.ColorRGB(255, 136, 0), false},
+		{"196", goterm.ColorIndex(196), false},
+		{"3", goterm.ColorYellow, false},
+		{"Orchid", goterm.ColorRGB(218, 112, 214), false},
+		{"ff8000", goterm.ColorRGB(255, 128, 0), false},
+		{"256", goterm.Color{}, true},
+		{"not a color", goterm.Color{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := goterm.ParseColor(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, goterm.ErrInvalidColor) {
+					t.Errorf("ParseColor(%q) error = %v, want ErrInvalidColor", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseColor(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func([]byte) (goterm.Theme, error)
+		data    string
+		wantErr bool
+	}{
+		{"json", goterm.ParseThemeJSON, `{"primary": "#5f87ff", "Error": "crimson"}`, false},
+		{"toml", goterm.ParseThemeTOML, "# my theme\nprimary = \"#5f87ff\"\n\n\"error\" = 'crimson' # red\n", false},
+		{"json_unknown_role", goterm.ParseThemeJSON, `{"sparkle": "red"}`, true},
+		{"json_bad_color", goterm.ParseThemeJSON, `{"primary": "nope"}`, true},
+		{"json_syntax", goterm.ParseThemeJSON, `{"primary": `, true},
+		{"toml_unquoted", goterm.ParseThemeTOML, "primary = red", true},
+		{"toml_no_value", goterm.ParseThemeTOML, "primary", true},
+		{"toml_trailing", goterm.ParseThemeTOML, `primary = "red" blue`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := tt.parse([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if theme.Primary != goterm.ColorRGB(0x5f, 0x87, 0xff) {
+				t.Errorf("Primary = %v, want #5f87ff", theme.Primary)
+			}
+			if theme.Error != goterm.ColorRGB(220, 20, 60) {
+				t.Errorf("Error = %v, want crimson", theme.Error)
+			}
+			if theme.Success != goterm.DefaultTheme().Success {
+				t.Errorf("Success = %v, want default theme color", theme.Success)
+			}
+		})
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"theme.json": `{"accent": "gold"}`,
+		"theme.TOML": `accent = "gold"`,
+	}
+
+	for name, data := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			theme, err := goterm.LoadTheme(path)
+			if err != nil {
+				t.Fatalf("LoadTheme() error = %v", err)
+			}
+			if theme.Accent != goterm.ColorRGB(255, 215, 0) {
+				t.Errorf("Accent = %v, want gold", theme.Accent)
+			}
+		})
+	}
+
+	if _, err := goterm.LoadTheme(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadTheme() of a missing file should fail")
+	}
+}
+
+func TestScreenTheme(t *testing.T) {
+	screen := goterm.NewScreen(4, 2)
+	if screen.Theme() != goterm.DefaultTheme() {
+		t.Error("new screen should use DefaultTheme")
+	}
+
+	theme := goterm.DefaultTheme()
+	theme.Primary = goterm.ColorRGB(1, 2, 3)
+	screen.SetTheme(theme)
+	if got := screen.Theme().Primary; got != theme.Primary {
+		t.Errorf("Theme().Primary = %v, want %v", got, theme.Primary)
+	}
+}
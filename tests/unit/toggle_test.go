@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+func TestCheckbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		box     widgets.Checkbox
+		events  []goterm.Event
+		checked bool
+		want    string
+	}{
+		{"unchecked", widgets.Checkbox{Label: "Wrap"}, nil, false, "[ ] Wrap  "},
+		{"space", widgets.Checkbox{Label: "Wrap"}, []goterm.Event{runeKey(' ')}, true, "[x] Wrap  "},
+		{"enter_twice", widgets.Checkbox{Label: "Wrap"}, []goterm.Event{key(goterm.KeyEnter), key(goterm.KeyEnter)}, false, "[ ] Wrap  "},
+		{"click", widgets.Checkbox{Label: "Wrap"}, []goterm.Event{click(5, 0)}, true, "[x] Wrap  "},
+		{"ignored", widgets.Checkbox{Label: "Wrap"}, []goterm.Event{runeKey('x'), click(0, 1)}, false, "[ ] Wrap  "},
+		{"glyphs", widgets.Checkbox{Label: "Wrap", Checked: true, Glyphs: widgets.CheckboxBoxes}, nil, true, "☑ Wrap    "},
+		{"truncated", widgets.Checkbox{Label: "Word wrap lines"}, nil, false, "[ ] Word …"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := tt.box
+			changes := 0
+			box.OnChange = func(bool) { changes++ }
+			for _, ev := range tt.events {
+				box.HandleEvent(ev)
+			}
+			buf := goterm.NewBuffer(10, 1)
+			box.Draw(buf)
+
+			if box.Checked != tt.checked {
+				t.Errorf("Checked = %v, want %v", box.Checked, tt.checked)
+			}
+			if got := rowText(buf, 0, 0, 10); got != tt.want {
+				t.Errorf("Draw() = %q, want %q", got, tt.want)
+			}
+			if changes > len(tt.events) {
+				t.Errorf("OnChange called %d times for %d events", changes, len(tt.events))
+			}
+		})
+	}
+}
+
+func TestCheckboxFocus(t *testing.T) {
+	box := widgets.Checkbox{Label: "Wrap"}
+	buf := goterm.NewBuffer(10, 1)
+	box.Draw(buf)
+	if buf.GetCell(4, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("unfocused label highlighted")
+	}
+
+	var f widgets.Focusable = &box
+	f.SetFocused(true)
+	box.Draw(buf)
+	if !buf.GetCell(4, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("focused label not highlighted")
+	}
+	if buf.GetCell(0, 0).Style.Has(goterm.StyleReverse) {
+		t.Error("glyph highlighted")
+	}
+}
+
+func TestRadioGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []goterm.Event
+		selected int
+	}{
+		{"initial", nil, 0},
+		{"down", []goterm.Event{key(goterm.KeyDown)}, 1},
+		{"stops_at_end", []goterm.Event{key(goterm.KeyEnd), key(goterm.KeyDown)}, 2},
+		{"up_from_start", []goterm.Event{key(goterm.KeyUp)}, 0},
+		{"click", []goterm.Event{click(3, 2)}, 2},
+		{"click_below", []goterm.Event{click(3, 3)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changed []int
+			group := widgets.RadioGroup{
+				Options:  []string{"Small", "Medium", "Large"},
+				OnChange: func(i int) { changed = append(changed, i) },
+			}
+			for _, ev := range tt.events {
+				group.HandleEvent(ev)
+			}
+			if group.Selected != tt.selected {
+				t.Errorf("Selected = %d, want %d", group.Selected, tt.selected)
+			}
+			if tt.selected != 0 && (len(changed) == 0 || changed[len(changed)-1] != tt.selected) {
+				t.Errorf("OnChange calls = %v, want last %d", changed, tt.selected)
+			}
+		})
+	}
+
+	group := widgets.RadioGroup{Options: []string{"Small", "Large"}, Selected: 1, Glyphs: widgets.RadioCircles}
+	buf := goterm.NewBuffer(8, 2)
+	group.Draw(buf)
+	if got := rowText(buf, 0, 0, 8) + "|" + rowText(buf, 0, 1, 8); got != "○ Small |◉ Large " {
+		t.Errorf("Draw() = %q", got)
+	}
+}
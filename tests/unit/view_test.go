@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+// Screen, Buffer and View are interchangeable drawing surfaces
+var (
+	_ goterm.Surface = (*goterm.Screen)(nil)
+	_ goterm.Surface = (*goterm.Buffer)(nil)
+	_ goterm.Surface = (*goterm.View)(nil)
+)
+
+func TestSubViewTranslation(t *testing.T) {
+	screen := goterm.NewScreen(10, 5)
+	view := screen.SubView(3, 1, 4, 2)
+
+	if w, h := view.Size(); w != 4 || h != 2 {
+		t.Errorf("Size() = (%d, %d), want (4, 2)", w, h)
+	}
+
+	view.SetCell(0, 0, goterm.NewCell('A', goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone))
+	view.DrawText(1, 1, "bc", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	if got := screen.GetCell(3, 1).Ch; got != 'A' {
+		t.Errorf("screen cell (3, 1) = %q, want 'A'", got)
+	}
+	if got := rowText(screen, 4, 2, 2); got != "bc" {
+		t.Errorf("screen row 2 = %q, want %q", got, "bc")
+	}
+	if got := view.GetCell(1, 1).Ch; got != 'b' {
+		t.Errorf("view cell (1, 1) = %q, want 'b'", got)
+	}
+}
+
+func TestSubViewClipping(t *testing.T) {
+	dot := goterm.NewCell('.', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	tests := []struct {
+		name string
+		draw func(v *goterm.View)
+		want []string
+	}{
+		{
+			name: "text_right_edge",
+			draw: func(v *goterm.View) {
+				v.DrawText(1, 0, "hello", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+			},
+			want: []string{"..he...", ".......", "......."},
+		},
+		{
+			name: "text_left_edge",
+			draw: func(v *goterm.View) {
+				v.DrawText(-2, 1, "hello", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+			},
+			want: []string{".......", ".llo...", "......."},
+		},
+		{
+			name: "set_cell_outside",
+			draw: func(v *goterm.View) {
+				v.SetCell(3, 0, dot)
+				v.SetCell(-1, 0, goterm.NewCell('x', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+				v.SetCell(0, 2, goterm.NewCell('x', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			},
+			want: []string{".......", ".......", "......."},
+		},
+		{
+			name: "wide_char_at_edge",
+			draw: func(v *goterm.View) {
+				v.DrawText(2, 0, "世", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+			},
+			want: []string{"... ...", ".......", "......."},
+		},
+		{
+			name: "fill",
+			draw: func(v *goterm.View) {
+				v.Fill(-5, -5, 20, 20, goterm.NewCell('#', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+			},
+			want: []string{".###...", ".###...", "......."},
+		},
+		{
+			name: "box",
+			draw: func(v *goterm.View) {
+				v.DrawBox(0, 0, 5, 2, goterm.BorderSingle, goterm.ColorWhite, goterm.ColorDefault())
+			},
+			want: []string{".┌──...", ".└──...", "......."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(7, 3)
+			screen.Fill(0, 0, 7, 3, dot)
+			tt.draw(screen.SubView(1, 0, 3, 2))
+
+			for y, want := range tt.want {
+				if got := rowText(screen, 0, y, 7); got != want {
+					t.Errorf("row %d = %q, want %q", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNestedSubView(t *testing.T) {
+	screen := goterm.NewScreen(10, 3)
+	outer := screen.SubView(2, 0, 5, 3)
+	inner := outer.SubView(3, 1, 5, 1)
+
+	if got := inner.Rect(); got != (goterm.Rect{X: 5, Y: 1, W: 5, H: 1}) {
+		t.Errorf("inner.Rect() = %+v", got)
+	}
+
+	inner.DrawText(0, 0, "abcde", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	if got := rowText(screen, 5, 1, 5); got != "ab   " {
+		t.Errorf("row 1 = %q, want %q (clipped to outer view)", got, "ab   ")
+	}
+	if got := inner.GetCell(3, 0).Ch; got != ' ' {
+		t.Errorf("inner.GetCell outside outer = %q, want ' '", got)
+	}
+}
+
+func TestBufferSubView(t *testing.T) {
+	buf := goterm.NewBuffer(6, 2)
+	view := buf.SubView(2, 1, 3, 1)
+	view.DrawText(0, 0, "wxyz", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+
+	if got := rowText(buf, 0, 1, 6); got != "  wxy " {
+		t.Errorf("row 1 = %q, want %q", got, "  wxy ")
+	}
+
+	// Drawing on the buffer itself is not clipped by the view
+	buf.DrawText(0, 0, "123456", goterm.ColorWhite, goterm.ColorDefault(), goterm.StyleNone)
+	if got := rowText(buf, 0, 0, 6); got != "123456" {
+		t.Errorf("row 0 = %q, want %q", got, "123456")
+	}
+}
@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+	"github.com/dshills/goterm/widgets"
+)
+
+// numberedBuffer returns a buffer whose rows read "0123456789..." shifted by
+// the row number
+func numberedBuffer(w, h int) *goterm.Buffer {
+	buf := goterm.NewBuffer(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			buf.SetCell(x, y, goterm.NewCell(rune('0'+(x+y)%10), goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone))
+		}
+	}
+	return buf
+}
+
+func TestViewportScrolling(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []goterm.Event
+		x, y   int
+	}{
+		{"initial", nil, 0, 0},
+		{"down_right", []goterm.Event{key(goterm.KeyDown), key(goterm.KeyRight)}, 1, 1},
+		{"clamped_top", []goterm.Event{key(goterm.KeyUp)}, 0, 0},
+		{"end", []goterm.Event{key(goterm.KeyEnd)}, 0, 16},
+		{"page_down", []goterm.Event{key(goterm.KeyPageDown)}, 0, 3},
+		{"wheel", []goterm.Event{goterm.MouseEvent{Button: goterm.MouseWheelDown}}, 0, 3},
+		{"shift_wheel", []goterm.Event{goterm.MouseEvent{Button: goterm.MouseWheelDown, Modifiers: goterm.ModShift}}, 3, 0},
+		{"scrollbar_click", []goterm.Event{click(9, 3)}, 0, 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := &widgets.Viewport{Content: numberedBuffer(20, 20), Scrollbars: true}
+			buf := goterm.NewBuffer(10, 5)
+			vp.Draw(buf)
+			for _, ev := range tt.events {
+				vp.HandleEvent(ev)
+			}
+			if x, y := vp.Offset(); x != tt.x || y != tt.y {
+				t.Errorf("Offset() = %d, %d, want %d, %d", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestViewportDraw(t *testing.T) {
+	vp := &widgets.Viewport{Content: numberedBuffer(20, 20), Scrollbars: true}
+	vp.ScrollTo(2, 1)
+	buf := goterm.NewBuffer(10, 5)
+	vp.Draw(buf)
+
+	want := []string{"345678901┃", "456789012│", "567890123│", "678901234│", "━━━━───── "}
+	for y, row := range want {
+		if got := rowText(buf, 0, y, 10); got != row {
+			t.Errorf("row %d = %q, want %q", y, got, row)
+		}
+	}
+
+	// Content that fits gets no scrollbars
+	small := &widgets.Viewport{Content: numberedBuffer(3, 2), Scrollbars: true}
+	small.Draw(buf)
+	if got := rowText(buf, 0, 0, 10); got != "012       " {
+		t.Errorf("small content row = %q", got)
+	}
+}
@@ -0,0 +1,228 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/dshills/goterm"
+)
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want int
+	}{
+		{"ascii_letter", 'A', 1},
+		{"space", ' ', 1},
+		{"control", '\x07', 0},
+		{"delete", '\x7f', 0},
+		{"combining_acute", '\u0301', 0},
+		{"zero_width_joiner", '\u200d', 0},
+		{"latin_accented", 'é', 1},
+		{"cjk_ideograph", '世', 2},
+		{"hiragana", 'あ', 2},
+		{"hangul_syllable", '한', 2},
+		{"fullwidth_letter", 'Ａ', 2},
+		{"emoji", '🎮', 2},
+		{"box_drawing", '─', 1},
+		{"greek", 'α', 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goterm.RuneWidth(tt.r); got != tt.want {
+				t.Errorf("RuneWidth(%U) = %d, want %d", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmbiguousWidth(t *testing.T) {
+	defer goterm.SetAmbiguousWidth(1)
+
+	ambiguous := []rune{'─', '│', 'α', 'Ж', '°', '×', '①'}
+
+	goterm.SetAmbiguousWidth(2)
+	if got := goterm.AmbiguousWidth(); got != 2 {
+		t.Fatalf("AmbiguousWidth() = %d, want 2", got)
+	}
+	for _, r := range ambiguous {
+		if got := goterm.RuneWidth(r); got != 2 {
+			t.Errorf("RuneWidth(%q) with wide ambiguous = %d, want 2", r, got)
+		}
+	}
+
+	// Non-ambiguous runes are unaffected by the setting
+	if got := goterm.RuneWidth('A'); got != 1 {
+		t.Errorf("RuneWidth('A') with wide ambiguous = %d, want 1", got)
+	}
+
+	// Invalid widths fall back to narrow
+	goterm.SetAmbiguousWidth(3)
+	if got := goterm.AmbiguousWidth(); got != 1 {
+		t.Errorf("SetAmbiguousWidth(3) then AmbiguousWidth() = %d, want 1", got)
+	}
+	for _, r := range ambiguous {
+		if got := goterm.RuneWidth(r); got != 1 {
+			t.Errorf("RuneWidth(%q) with narrow ambiguous = %d, want 1", r, got)
+		}
+	}
+}
+
+func TestDrawTextWideCharacters(t *testing.T) {
+	screen := goterm.NewScreen(10, 2)
+	screen.DrawText(0, 0, "a世b", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone)
+
+	want := []rune{'a', '世', ' ', 'b'}
+	for x, ch := range want {
+		if got := screen.GetCell(x, 0).Ch; got != ch {
+			t.Errorf("GetCell(%d, 0).Ch = %q, want %q", x, got, ch)
+		}
+	}
+
+	// A wide character that does not fit in the last column becomes a blank
+	screen.DrawText(9, 1, "世", goterm.ColorRed, goterm.ColorDefault(), goterm.StyleNone)
+	if got := screen.GetCell(9, 1).Ch; got != ' ' {
+		t.Errorf("GetCell(9, 1).Ch = %q, want blank", got)
+	}
+}
+
+func TestDrawTextAmbiguousWide(t *testing.T) {
+	defer goterm.SetAmbiguousWidth(1)
+	goterm.SetAmbiguousWidth(2)
+
+	screen := goterm.NewScreen(10, 1)
+	screen.DrawText(0, 0, "─x", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+	if got := screen.GetCell(2, 0).Ch; got != 'x' {
+		t.Errorf("GetCell(2, 0).Ch = %q, want 'x' after wide ambiguous rune", got)
+	}
+}
+
+func TestDrawTextEmojiSequences(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantCh   rune
+		wantComb string
+	}{
+		{"zwj_family", "\U0001F468\u200d\U0001F469\u200d\U0001F467", '\U0001F468', "\u200d\U0001F469\u200d\U0001F467"},
+		{"skin_tone", "\U0001F44D\U0001F3FD", '\U0001F44D', "\U0001F3FD"},
+		{"flag", "\U0001F1EF\U0001F1F5", '\U0001F1EF', "\U0001F1F5"},
+		{"emoji_presentation", "❤\ufe0f", '❤', "\ufe0f"},
+		{"keycap", "1\ufe0f\u20e3", '1', "\ufe0f\u20e3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := goterm.NewScreen(10, 1)
+			screen.DrawText(0, 0, tt.text+"x", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+
+			cell := screen.GetCell(0, 0)
+			if cell.Ch != tt.wantCh || cell.Comb != tt.wantComb {
+				t.Errorf("GetCell(0, 0) = (%q, %q), want (%q, %q)", cell.Ch, cell.Comb, tt.wantCh, tt.wantComb)
+			}
+			if got := screen.GetCell(2, 0).Ch; got != 'x' {
+				t.Errorf("GetCell(2, 0).Ch = %q, want 'x' after width-2 cluster", got)
+			}
+		})
+	}
+}
+
+func TestWidthOverrides(t *testing.T) {
+	defer goterm.ClearWidthOverrides()
+
+	heart := "❤\ufe0f"
+	goterm.SetWidthOverride(heart, 1)
+
+	screen := goterm.NewScreen(10, 1)
+	screen.DrawText(0, 0, heart+"x", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	if got := screen.GetCell(1, 0).Ch; got != 'x' {
+		t.Errorf("GetCell(1, 0).Ch = %q, want 'x' with width override 1", got)
+	}
+
+	goterm.SetWidthOverride(heart, -1)
+	screen.DrawText(0, 0, heart+"x", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	if got := screen.GetCell(2, 0).Ch; got != 'x' {
+		t.Errorf("GetCell(2, 0).Ch = %q, want 'x' after removing override", got)
+	}
+
+	if goterm.ApplyTerminalWidthOverrides("no-such-terminal") {
+		t.Error("ApplyTerminalWidthOverrides() = true for unknown terminal")
+	}
+	if !goterm.ApplyTerminalWidthOverrides("linux") {
+		t.Error("ApplyTerminalWidthOverrides(\"linux\") = false, want true")
+	}
+}
+
+func TestDrawTextCombiningCharacters(t *testing.T) {
+	screen := goterm.NewScreen(20, 3)
+
+	// Vietnamese with stacked diacritics in decomposed form
+	screen.DrawText(0, 0, "Vie\u0323\u0302t|", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	cell := screen.GetCell(2, 0)
+	if cell.Ch != 'e' || cell.Comb != "\u0323\u0302" {
+		t.Errorf("GetCell(2, 0) = (%q, %q), want ('e', %q)", cell.Ch, cell.Comb, "\u0323\u0302")
+	}
+	if got := screen.GetCell(4, 0).Ch; got != '|' {
+		t.Errorf("GetCell(4, 0).Ch = %q, want '|' (combining marks must not take columns)", got)
+	}
+
+	// Arabic with harakat
+	screen.DrawText(0, 1, "ك\u064eت\u064eب\u064e|", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	if got := screen.GetCell(3, 1).Ch; got != '|' {
+		t.Errorf("GetCell(3, 1).Ch = %q, want '|'", got)
+	}
+
+	// Marks at the start of a string attach to the cell already on screen
+	screen.DrawText(0, 2, "a", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	screen.DrawText(1, 2, "\u0301b", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	cell = screen.GetCell(0, 2)
+	if cell.Ch != 'a' || cell.Comb != "\u0301" {
+		t.Errorf("GetCell(0, 2) = (%q, %q), want ('a', %q)", cell.Ch, cell.Comb, "\u0301")
+	}
+	if got := screen.GetCell(1, 2).Ch; got != 'b' {
+		t.Errorf("GetCell(1, 2).Ch = %q, want 'b'", got)
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		wantWidth int
+		wantCells int
+	}{
+		{"empty", "", 0, 0},
+		{"ascii", "Hello", 5, 5},
+		{"cjk", "世界", 4, 2},
+		{"mixed", "Hello 世界 🎮", 13, 10},
+		{"combining", "e\u0301te\u0301", 3, 3},
+		{"zwj_sequence", "\U0001F468\u200d\U0001F469\u200d\U0001F467", 2, 1},
+		{"flag", "\U0001F1EF\U0001F1F5", 2, 1},
+		{"zero_width_space", "a\u200bb", 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goterm.StringWidth(tt.s); got != tt.wantWidth {
+				t.Errorf("StringWidth(%q) = %d, want %d", tt.s, got, tt.wantWidth)
+			}
+			if got := goterm.CellLength(tt.s); got != tt.wantCells {
+				t.Errorf("CellLength(%q) = %d, want %d", tt.s, got, tt.wantCells)
+			}
+		})
+	}
+}
+
+func TestStringWidthMatchesDrawText(t *testing.T) {
+	texts := []string{"Hello 世界 🎮", "Vie\u0323\u0302t Nam", "a\u200bb", "\U0001F44D\U0001F3FD ok"}
+
+	for _, text := range texts {
+		screen := goterm.NewScreen(40, 1)
+		screen.DrawText(0, 0, text+"|", goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+		if got := screen.GetCell(goterm.StringWidth(text), 0).Ch; got != '|' {
+			t.Errorf("DrawText(%q) did not advance StringWidth() = %d columns", text, goterm.StringWidth(text))
+		}
+	}
+}
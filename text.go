@@ -0,0 +1,226 @@
+package goterm
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UnprintablePolicy selects how DrawText displays runes a terminal is unlikely
+// to render: invalid UTF-8, C1 control characters, surrogates,
+// noncharacters and unassigned code points
+type UnprintablePolicy int
+
+const (
+	// UnprintableReplace draws U+FFFD REPLACEMENT CHARACTER (the default)
+	UnprintableReplace UnprintablePolicy = iota
+	// UnprintableFallback draws a user-provided fallback rune
+	UnprintableFallback
+	// UnprintableTransliterate spells the rune out in ASCII: invalid bytes and
+	// C1 controls as \xNN, other code points as <U+XXXX>
+	UnprintableTransliterate
+)
+
+// isUnprintable reports whether the cluster starts with a rune that a
+// terminal cannot be expected to display
+func isUnprintable(cluster string) bool {
+	r, size := utf8.DecodeRuneInString(cluster)
+	switch {
+	case r == utf8.RuneError && size <= 1:
+		return true
+	case r >= 0x80 && r < 0xa0:
+		return true
+	case r >= 0xfdd0 && r <= 0xfdef, r&0xfffe == 0xfffe:
+		return true
+	case unicode.In(r, unicode.Cf, unicode.Co):
+		// Format characters are handled as zero-width and private use
+		// characters are commonly provided by icon fonts
+		return false
+	}
+	return !unicode.IsGraphic(r)
+}
+
+// replaceUnprintable applies the policy to a cluster, returning the text to
+// draw in its place; printable clusters are returned unchanged
+func replaceUnprintable(cluster string, policy UnprintablePolicy, fallback rune) string {
+	if !isUnprintable(cluster) {
+		return cluster
+	}
+
+	switch policy {
+	case UnprintableFallback:
+		return string(fallback)
+	case UnprintableTransliterate:
+		r, size := utf8.DecodeRuneInString(cluster)
+		if r == utf8.RuneError && size <= 1 {
+			return fmt.Sprintf("\\x%02X", cluster[0])
+		}
+		if r < 0xa0 {
+			return fmt.Sprintf("\\x%02X", r)
+		}
+		return fmt.Sprintf("<U+%04X>", r)
+	}
+	return "\uFFFD"
+}
+
+// controlPicture replaces a C0 control character (or DEL) with its visible
+// symbol from the Control Pictures block; other clusters are returned as is
+func controlPicture(cluster string) string {
+	r, size := utf8.DecodeRuneInString(cluster)
+	switch {
+	case size != len(cluster):
+		return cluster
+	case r < 0x20:
+		return string(0x2400 + r)
+	case r == 0x7f:
+		return "\u2421"
+	}
+	return cluster
+}
+
+// Truncate shortens s to at most maxCols display columns, appending tail
+// (typically "…" or "...") when anything was cut
+// Cuts happen only on grapheme cluster boundaries, so wide characters and
+// combining sequences are never split. If tail itself is wider than maxCols,
+// s is cut to maxCols without a tail.
+func Truncate(s string, maxCols int, tail string) string {
+	if maxCols <= 0 {
+		return ""
+	}
+	if StringWidth(s) <= maxCols {
+		return s
+	}
+
+	budget := maxCols - StringWidth(tail)
+	if budget < 0 {
+		budget = maxCols
+		tail = ""
+	}
+
+	return s[:fitColumns(s, budget)] + tail
+}
+
+// fitColumns returns the length in bytes of the longest prefix of s made of
+// whole grapheme clusters that fits in cols display columns
+func fitColumns(s string, cols int) int {
+	end, width := 0, 0
+	for end < len(s) {
+		n := nextCluster(s[end:])
+		w := clusterWidth(s[end : end+n])
+		if width+w > cols {
+			break
+		}
+		width += w
+		end += n
+	}
+	return end
+}
+
+// wrapToken is an unbreakable piece of text together with the spaces that
+// precede it
+type wrapToken struct {
+	lead  string // spaces before the token, dropped at the start of a line
+	text  string
+	width int
+}
+
+// tokenize splits a single line into wrap tokens
+// Words break only at spaces, while each wide (CJK) character forms a token of
+// its own since any of them is a valid break opportunity.
+func tokenize(line string) []wrapToken {
+	var tokens []wrapToken
+	var lead string
+	wordStart, wordWidth := -1, 0
+
+	flush := func(end int) {
+		if wordStart >= 0 {
+			tokens = append(tokens, wrapToken{lead: lead, text: line[wordStart:end], width: wordWidth})
+			lead = ""
+			wordStart, wordWidth = -1, 0
+		}
+	}
+
+	for i := 0; i < len(line); {
+		n := nextCluster(line[i:])
+		cluster := line[i : i+n]
+		w := clusterWidth(cluster)
+
+		switch {
+		case cluster == " ":
+			flush(i)
+			lead += cluster
+		case w == 2:
+			flush(i)
+			tokens = append(tokens, wrapToken{lead: lead, text: cluster, width: w})
+			lead = ""
+		default:
+			if wordStart < 0 {
+				wordStart = i
+			}
+			wordWidth += w
+		}
+		i += n
+	}
+	flush(len(line))
+
+	return tokens
+}
+
+// Wrap breaks s into lines no wider than width display columns
+// Lines break at spaces or between wide (CJK) characters; words longer than
+// width are split at grapheme boundaries. Newlines in s always start a new
+// line. Spaces at a break are dropped.
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		lines = append(lines, wrapLine(paragraph, width)...)
+	}
+	return lines
+}
+
+// wrapLine wraps a single line of text without newlines
+func wrapLine(line string, width int) []string {
+	var lines []string
+	var current strings.Builder
+	currentWidth := 0
+
+	for _, tok := range tokenize(line) {
+		leadWidth := StringWidth(tok.lead)
+		if currentWidth > 0 && currentWidth+leadWidth+tok.width <= width {
+			current.WriteString(tok.lead)
+			current.WriteString(tok.text)
+			currentWidth += leadWidth + tok.width
+			continue
+		}
+
+		if currentWidth > 0 {
+			lines = append(lines, current.String())
+			current.Reset()
+			currentWidth = 0
+		}
+
+		// Split words that cannot fit on a line of their own
+		text := tok.text
+		for StringWidth(text) > width {
+			n := fitColumns(text, width)
+			if n == 0 {
+				// A single cluster wider than the line still has to go somewhere
+				n = nextCluster(text)
+			}
+			lines = append(lines, text[:n])
+			text = text[n:]
+		}
+		current.WriteString(text)
+		currentWidth = StringWidth(text)
+	}
+
+	if currentWidth > 0 || len(lines) == 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
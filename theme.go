@@ -0,0 +1,239 @@
+package goterm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Theme maps semantic roles to colors
+// Widgets and helpers draw with theme roles instead of literal colors, so an
+// application can be restyled by swapping the theme (see Screen.SetTheme and
+// LoadTheme) without touching drawing code.
+type Theme struct {
+	Primary    Color // Main brand or emphasis color
+	Secondary  Color // Supporting emphasis color
+	Accent     Color // Highlights that should stand out
+	Text       Color // Regular text
+	Muted      Color // De-emphasized text such as hints and disabled items
+	Background Color // Screen background
+	Surface    Color // Background of panels, dialogs and menus
+	Border     Color // Box and separator lines
+	Focus      Color // Border or marker of the focused element
+	Selection  Color // Background of selected items
+	Success    Color // Positive status
+	Warning    Color // Cautionary status
+	Error      Color // Errors and destructive actions
+}
+
+// DefaultTheme returns the built-in theme
+// It uses only the 16 ANSI colors and the terminal defaults, so it follows
+// the user's terminal color scheme and works on every terminal.
+func DefaultTheme() Theme {
+	return Theme{
+		Primary:    ColorCyan,
+		Secondary:  ColorBlue,
+		Accent:     ColorMagenta,
+		Text:       ColorDefault(),
+		Muted:      ColorIndex(8),
+		Background: ColorDefault(),
+		Surface:    ColorDefault(),
+		Border:     ColorWhite,
+		Focus:      ColorYellow,
+		Selection:  ColorBlue,
+		Success:    ColorGreen,
+		Warning:    ColorYellow,
+		Error:      ColorRed,
+	}
+}
+
+// roles returns the theme colors by their names in theme files
+func (t *Theme) roles() map[string]*Color {
+	return map[string]*Color{
+		"primary":    &t.Primary,
+		"secondary":  &t.Secondary,
+		"accent":     &t.Accent,
+		"text":       &t.Text,
+		"muted":      &t.Muted,
+		"background": &t.Background,
+		"surface":    &t.Surface,
+		"border":     &t.Border,
+		"focus":      &t.Focus,
+		"selection":  &t.Selection,
+		"success":    &t.Success,
+		"warning":    &t.Warning,
+		"error":      &t.Error,
+	}
+}
+
+// set assigns the color spec to the named role
+func (t *Theme) set(role, spec string) error {
+	c, ok := t.roles()[strings.ToLower(role)]
+	if !ok {
+		return fmt.Errorf("unknown theme role %q", role)
+	}
+	color, err := ParseColor(spec)
+	if err != nil {
+		return fmt.Errorf("theme role %q: %w", role, err)
+	}
+	*c = color
+	return nil
+}
+
+// LoadTheme reads a theme file in JSON or TOML format
+// The format is chosen by the file extension: ".toml" files are TOML and
+// everything else is JSON. See ParseThemeJSON and ParseThemeTOML.
+func LoadTheme(path string) (Theme, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		return ParseThemeTOML(data)
+	}
+	return ParseThemeJSON(data)
+}
+
+// ParseThemeJSON parses a theme from a JSON object mapping role names to
+// colors, e.g. {"primary": "#5f87ff", "error": "crimson"}
+// Role names are the lower-case Theme field names and colors are in any
+// form accepted by ParseColor. Roles that are not listed keep their
+// DefaultTheme colors.
+func ParseThemeJSON(data []byte) (Theme, error) {
+	var spec map[string]string
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme: %w", err)
+	}
+
+	t := DefaultTheme()
+	for role, color := range spec {
+		if err := t.set(role, color); err != nil {
+			return Theme{}, err
+		}
+	}
+	return t, nil
+}
+
+// ParseThemeTOML parses a theme from TOML key/value pairs, e.g.
+//
+//	primary = "#5f87ff"
+//	error = "crimson" # destructive actions
+//
+// Only the flat subset of TOML used by theme files is supported: string
+// values, comments and blank lines. Roles and colors are as for
+// ParseThemeJSON.
+func ParseThemeTOML(data []byte) (Theme, error) {
+	t := DefaultTheme()
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Theme{}, fmt.Errorf("failed to parse theme: line %d: expected key = value", n+1)
+		}
+		color, err := tomlString(strings.TrimSpace(value))
+		if err != nil {
+			return Theme{}, fmt.Errorf("failed to parse theme: line %d: %w", n+1, err)
+		}
+		if err := t.set(strings.Trim(strings.TrimSpace(key), `"`), color); err != nil {
+			return Theme{}, err
+		}
+	}
+	return t, nil
+}
+
+// tomlString decodes a quoted TOML string followed by an optional comment
+func tomlString(s string) (string, error) {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return "", fmt.Errorf("expected a quoted string")
+	}
+	end := strings.IndexByte(s[1:], s[0]) + 1
+	if end == 0 {
+		return "", fmt.Errorf("unterminated string")
+	}
+	if rest := strings.TrimSpace(s[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected %q after value", rest)
+	}
+	if s[0] == '\'' {
+		return s[1:end], nil
+	}
+	return strconv.Unquote(s[:end+1])
+}
+
+// Theme returns the screen's theme
+func (s *Screen) Theme() Theme {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.theme
+}
+
+// SetTheme changes the theme consulted by widgets drawing on the screen
+// Content that is already drawn keeps its colors until it is redrawn.
+func (s *Screen) SetTheme(t Theme) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.theme = t
+}
+
+// themePollInterval is how often WatchTheme checks the theme file
+const themePollInterval = 250 * time.Millisecond
+
+// ThemeEvent reports that a theme file watched with App.WatchTheme was
+// loaded
+type ThemeEvent struct {
+	Path  string // Theme file
+	Theme Theme  // Theme read from the file, if Err is nil
+	Err   error  // Why the file could not be loaded; the theme is unchanged
+}
+
+func (ThemeEvent) isEvent() {}
+
+// WatchTheme loads the theme file at path, applies it to the screen, and
+// applies it again whenever the file changes until the application stops
+// It lets the look of an application be worked on while it runs. Each load
+// is delivered as a ThemeEvent; before it is dispatched, a theme that loaded
+// is set on the screen and the screen is laid out again, so that widgets
+// drawn with its colors pick up the change. A file that fails to load, such
+// as one saved half-edited, leaves the theme as it was. WatchTheme returns
+// an error if the file cannot be loaded at first. The file is checked for
+// changes a few times a second.
+func (a *App) WatchTheme(path string) error {
+	t, err := LoadTheme(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read theme: %w", err)
+	}
+	a.init()
+	a.Post(ThemeEvent{Path: path, Theme: t})
+
+	go func() {
+		ticker := time.NewTicker(themePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-a.done:
+				return
+			}
+			latest, err := os.Stat(path)
+			if err != nil || latest.ModTime().Equal(info.ModTime()) && latest.Size() == info.Size() {
+				// A file being replaced may be missing for a moment
+				continue
+			}
+			info = latest
+			t, err := LoadTheme(path)
+			a.Post(ThemeEvent{Path: path, Theme: t, Err: err})
+		}
+	}()
+	return nil
+}
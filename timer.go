@@ -0,0 +1,100 @@
+package goterm
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Timer delivers TickEvents to the event loop of an App, once or
+// repeatedly
+// Because ticks arrive through the loop like key presses, animations and
+// timeouts run on the same goroutine as the rest of the application and
+// need no locking.
+type Timer struct {
+	app     *App
+	stop    chan struct{}
+	once    sync.Once
+	stopped atomic.Bool
+	queued  atomic.Bool // A tick is waiting in the queue
+}
+
+// After starts a timer that delivers one TickEvent after d
+func (a *App) After(d time.Duration) *Timer {
+	t := a.newTimer()
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case now := <-timer.C:
+			t.tick(now)
+		case <-t.stop:
+		case <-a.done:
+		}
+	}()
+	return t
+}
+
+// Every starts a timer that delivers a TickEvent every d until it is
+// stopped
+// A tick is skipped while the previous one is still waiting to be handled,
+// so a busy loop is not flooded with them; use the time of the event, not
+// a count of ticks, to keep animations in time.
+func (a *App) Every(d time.Duration) *Timer {
+	t := a.newTimer()
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				if !t.queued.Swap(true) {
+					t.tick(now)
+				}
+			case <-t.stop:
+				return
+			case <-a.done:
+				return
+			}
+		}
+	}()
+	return t
+}
+
+// newTimer creates a timer for the app
+func (a *App) newTimer() *Timer {
+	a.init()
+	return &Timer{app: a, stop: make(chan struct{})}
+}
+
+// Stop stops the timer
+// No TickEvent from the timer is delivered after Stop, even one already
+// waiting in the queue. Stop is safe to call from any goroutine, and more
+// than once.
+func (t *Timer) Stop() {
+	t.once.Do(func() {
+		t.stopped.Store(true)
+		close(t.stop)
+	})
+}
+
+// Stopped reports whether the timer has been stopped
+func (t *Timer) Stopped() bool {
+	return t.stopped.Load()
+}
+
+// tick posts a TickEvent for now
+func (t *Timer) tick(now time.Time) {
+	t.app.Post(TickEvent{Timer: t, Time: now})
+}
+
+// deliver notes that a tick of the timer was taken from the queue and
+// reports whether to dispatch it, which it is not if the timer was stopped
+// while the tick was queued
+func (t *Timer) deliver() bool {
+	if t == nil {
+		return true
+	}
+	t.queued.Store(false)
+	return !t.Stopped()
+}
@@ -0,0 +1,176 @@
+package goterm
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Surface is a grid of cells that can be drawn on
+// Screen, Buffer and View all implement it, so widget code can render to any
+// of them.
+type Surface interface {
+	Size() (width, height int)
+	SetCell(x, y int, cell Cell)
+	GetCell(x, y int) Cell
+	DrawText(x, y int, text string, fg, bg Color, style Style)
+}
+
+// View is a rectangular window onto a Screen or Buffer
+// Coordinates passed to a view are relative to its top-left corner, and
+// drawing is clipped to the view (and to any view it was created from), so
+// widgets can draw in local coordinates without knowing their absolute
+// position or scribbling over their neighbors.
+type View struct {
+	buf    *Buffer
+	mu     *sync.RWMutex // Lock of the owning Screen, nil for a Buffer
+	origin Rect          // Region in buffer coordinates
+	clip   Rect          // origin clipped to the parent views and PushClip
+	clips  []Rect        // Clip regions saved by PushClip
+}
+
+// SubView returns a view of the w×h region at (x, y)
+// The region may extend past the screen edges; drawing there is discarded.
+func (s *Screen) SubView(x, y, w, h int) *View {
+	r := Rect{X: x, Y: y, W: w, H: h}
+	return &View{buf: &s.buf, mu: &s.mu, origin: r, clip: r}
+}
+
+// SubView returns a view of the w×h region at (x, y)
+// The region may extend past the buffer edges; drawing there is discarded.
+func (b *Buffer) SubView(x, y, w, h int) *View {
+	r := Rect{X: x, Y: y, W: w, H: h}
+	return &View{buf: b, origin: r, clip: r}
+}
+
+// SubView returns a nested view of the w×h region at (x, y) in view
+// coordinates, clipped to this view
+func (v *View) SubView(x, y, w, h int) *View {
+	r := Rect{X: v.origin.X + x, Y: v.origin.Y + y, W: w, H: h}
+	return &View{buf: v.buf, mu: v.mu, origin: r, clip: r.Intersect(v.clip)}
+}
+
+// PushClip restricts subsequent drawing through the view to rect, given in
+// view coordinates, until the matching PopClip
+func (v *View) PushClip(rect Rect) {
+	if v.mu != nil {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
+	rect.X += v.origin.X
+	rect.Y += v.origin.Y
+	v.clips = append(v.clips, v.clip)
+	v.clip = rect.Intersect(v.clip)
+}
+
+// PopClip restores the clip region active before the last PushClip
+func (v *View) PopClip() {
+	if v.mu != nil {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
+	if len(v.clips) == 0 {
+		return
+	}
+	v.clip = v.clips[len(v.clips)-1]
+	v.clips = v.clips[:len(v.clips)-1]
+}
+
+// Size returns the view dimensions
+func (v *View) Size() (width, height int) {
+	r := v.Rect()
+	return r.W, r.H
+}
+
+// Rect returns the region covered by the view in screen coordinates
+func (v *View) Rect() Rect {
+	if v.mu != nil {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+	}
+	return v.origin
+}
+
+// SetCell sets the cell at the specified view position
+// Does nothing if x, y are outside the view
+func (v *View) SetCell(x, y int, cell Cell) {
+	v.draw(func(b *Buffer) { b.SetCell(v.origin.X+x, v.origin.Y+y, cell) })
+}
+
+// GetCell returns the cell at the specified view position
+// Returns a default empty cell if x, y are outside the view
+func (v *View) GetCell(x, y int) Cell {
+	if v.mu != nil {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+	}
+	if !v.clip.Contains(v.origin.X+x, v.origin.Y+y) {
+		return NewCell(' ', ColorDefault(), ColorDefault(), StyleNone)
+	}
+	return v.buf.GetCell(v.origin.X+x, v.origin.Y+y)
+}
+
+// Clear resets every cell of the view to its default state
+func (v *View) Clear() {
+	w, h := v.Size()
+	v.Fill(0, 0, w, h, NewCell(' ', ColorDefault(), ColorDefault(), StyleNone))
+}
+
+// Fill sets every cell of the w×h region at (x, y) to cell
+func (v *View) Fill(x, y, w, h int, cell Cell) {
+	v.draw(func(b *Buffer) { b.Fill(v.origin.X+x, v.origin.Y+y, w, h, cell) })
+}
+
+// DrawText draws text at the specified view position
+// Text is laid out as by Screen.DrawText and clipped to the view.
+func (v *View) DrawText(x, y int, text string, fg, bg Color, style Style) {
+	v.draw(func(b *Buffer) { b.DrawText(v.origin.X+x, v.origin.Y+y, text, fg, bg, style) })
+}
+
+// DrawTextf formats according to a format specifier and draws the result at
+// the specified view position like DrawText
+func (v *View) DrawTextf(x, y int, fg, bg Color, style Style, format string, args ...any) {
+	v.DrawText(x, y, fmt.Sprintf(format, args...), fg, bg, style)
+}
+
+// DrawTextWrapped draws text wrapped to the given width starting at (x, y)
+// and returns the number of lines drawn
+func (v *View) DrawTextWrapped(x, y, width int, text string, fg, bg Color, style Style) int {
+	var n int
+	v.draw(func(b *Buffer) { n = b.DrawTextWrapped(v.origin.X+x, v.origin.Y+y, width, text, fg, bg, style) })
+	return n
+}
+
+// DrawSpans draws the spans one after another starting at view position
+// (x, y) and returns the view column after the last one
+func (v *View) DrawSpans(x, y int, spans []Span) int {
+	var col int
+	v.draw(func(b *Buffer) { col = b.DrawSpans(v.origin.X+x, v.origin.Y+y, spans) - v.origin.X })
+	return col
+}
+
+// DrawTextAligned draws text wrapped, aligned and clipped to rect, given in
+// view coordinates
+func (v *View) DrawTextAligned(rect Rect, text string, halign HAlign, valign VAlign, fg, bg Color, style Style) {
+	rect.X += v.origin.X
+	rect.Y += v.origin.Y
+	v.draw(func(b *Buffer) { b.DrawTextAligned(rect, text, halign, valign, fg, bg, style) })
+}
+
+// DrawBox draws the outline of a w×h box with its top-left corner at (x, y)
+func (v *View) DrawBox(x, y, w, h int, border BorderStyle, fg, bg Color) {
+	v.draw(func(b *Buffer) { b.DrawBox(v.origin.X+x, v.origin.Y+y, w, h, border, fg, bg) })
+}
+
+// DrawTitledBox draws a box with a title set into its border
+func (v *View) DrawTitledBox(x, y, w, h int, border BorderStyle, title string, pos TitlePosition, fg, bg Color) {
+	v.draw(func(b *Buffer) { b.DrawTitledBox(v.origin.X+x, v.origin.Y+y, w, h, border, title, pos, fg, bg) })
+}
+
+// draw runs fn against the underlying buffer with drawing clipped to the view
+func (v *View) draw(fn func(b *Buffer)) {
+	if v.mu != nil {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
+	v.buf.withClip(v.clip, func() { fn(v.buf) })
+}
@@ -0,0 +1,261 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dshills/goterm"
+)
+
+// BigFont is a font of characters several rows tall for BigText
+// FontBlock and FontCompact are built in; FIGlet fonts can be loaded with
+// ParseFIGletFont.
+type BigFont struct {
+	Height  int               // Rows of every character
+	Spacing int               // Blank columns between characters
+	Glyphs  map[rune][]string // Height rows of equal width per character
+}
+
+// Built-in fonts drawn from the same 5×5 bitmaps
+var (
+	// FontBlock draws characters 5 rows tall with full blocks
+	FontBlock = bitmapFont(false)
+	// FontCompact draws characters 3 rows tall with half blocks
+	FontCompact = bitmapFont(true)
+)
+
+// Render returns the rows of text drawn in the font
+// Lines of text are separated by a blank row. Lowercase letters without a
+// glyph use the uppercase one, and other characters without a glyph are
+// left out.
+func (f *BigFont) Render(text string) []string {
+	var rows []string
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, f.renderLine(line)...)
+	}
+	return rows
+}
+
+// renderLine returns the rows of one line of text
+func (f *BigFont) renderLine(line string) []string {
+	rows := make([]strings.Builder, f.Height)
+	first := true
+	for _, r := range line {
+		glyph := f.glyph(r)
+		if glyph == nil {
+			continue
+		}
+		for y := range rows {
+			if !first {
+				rows[y].WriteString(strings.Repeat(" ", f.Spacing))
+			}
+			rows[y].WriteString(glyph[y])
+		}
+		first = false
+	}
+	out := make([]string, f.Height)
+	for y := range rows {
+		out[y] = rows[y].String()
+	}
+	return out
+}
+
+// glyph returns the rows of r, or nil if the font has no glyph for it
+func (f *BigFont) glyph(r rune) []string {
+	if g, ok := f.Glyphs[r]; ok {
+		return g
+	}
+	if g, ok := f.Glyphs[unicode.ToUpper(r)]; ok {
+		return g
+	}
+	return nil
+}
+
+// Size returns the columns and rows text takes up in the font
+func (f *BigFont) Size(text string) (w, h int) {
+	rows := f.Render(text)
+	for _, row := range rows {
+		w = max(w, goterm.StringWidth(row))
+	}
+	return w, len(rows)
+}
+
+// ParseFIGletFont reads a FIGlet font, the .flf files used by the figlet
+// and toilet programs
+// Characters are set at full width: the font's kerning and smushing rules
+// are not applied. Returns an error wrapping ErrInvalidFont if data is not
+// a FIGlet font.
+func ParseFIGletFont(data []byte) (*BigFont, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	header := strings.Fields(lines[0])
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) < 6 {
+		return nil, fmt.Errorf("%w: missing flf2a header", ErrInvalidFont)
+	}
+	hardblank := header[0][5:6]
+	height, err := strconv.Atoi(header[1])
+	if err != nil || height < 1 {
+		return nil, fmt.Errorf("%w: invalid height %q", ErrInvalidFont, header[1])
+	}
+	comments, err := strconv.Atoi(header[5])
+	if err != nil || comments < 0 {
+		return nil, fmt.Errorf("%w: invalid comment line count %q", ErrInvalidFont, header[5])
+	}
+
+	f := &BigFont{Height: height, Glyphs: make(map[rune][]string)}
+	pos := 1 + comments
+
+	// The printable ASCII characters are required, followed by optional
+	// German characters and code-tagged characters
+	for r := rune(' '); r <= '~'; r++ {
+		if pos+height > len(lines) {
+			return nil, fmt.Errorf("%w: missing character %q", ErrInvalidFont, r)
+		}
+		f.Glyphs[r] = figletGlyph(lines[pos:pos+height], hardblank)
+		pos += height
+	}
+	for _, r := range []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'} {
+		if pos+height > len(lines) || figletCode(lines[pos]) {
+			break
+		}
+		f.Glyphs[r] = figletGlyph(lines[pos:pos+height], hardblank)
+		pos += height
+	}
+	for pos < len(lines) {
+		fields := strings.Fields(lines[pos])
+		if len(fields) == 0 {
+			pos++
+			continue
+		}
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil || pos+1+height > len(lines) {
+			return nil, fmt.Errorf("%w: invalid character tag %q", ErrInvalidFont, lines[pos])
+		}
+		if code >= 0 {
+			f.Glyphs[rune(code)] = figletGlyph(lines[pos+1:pos+1+height], hardblank)
+		}
+		pos += 1 + height
+	}
+	return f, nil
+}
+
+// figletCode reports whether line is the tag of a code-tagged character
+func figletCode(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	_, err := strconv.ParseInt(fields[0], 0, 32)
+	return err == nil
+}
+
+// figletGlyph returns the rows of a FIGlet character with the end marks
+// removed and hard blanks turned into spaces, padded to the same width
+func figletGlyph(lines []string, hardblank string) []string {
+	rows := make([]string, len(lines))
+	width := 0
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line != "" {
+			line = strings.TrimRight(line, line[len(line)-1:])
+		}
+		rows[i] = strings.ReplaceAll(line, hardblank, " ")
+		width = max(width, goterm.StringWidth(rows[i]))
+	}
+	for i, row := range rows {
+		rows[i] = row + strings.Repeat(" ", width-goterm.StringWidth(row))
+	}
+	return rows
+}
+
+// bitmapFont builds a font from fontBitmaps, drawing each dot as a full
+// block or, if compact, two rows of dots per row of half blocks
+func bitmapFont(compact bool) *BigFont {
+	f := &BigFont{Height: 5, Spacing: 1, Glyphs: make(map[rune][]string, len(fontBitmaps))}
+	if compact {
+		f.Height = 3
+	}
+	for r, bitmap := range fontBitmaps {
+		rows := make([]string, f.Height)
+		for y := range rows {
+			if !compact {
+				rows[y] = strings.ReplaceAll(bitmap[y], "#", "█")
+				continue
+			}
+			top, bottom := bitmap[2*y], strings.Repeat(" ", len(bitmap[0]))
+			if 2*y+1 < len(bitmap) {
+				bottom = bitmap[2*y+1]
+			}
+			var sb strings.Builder
+			for x := range top {
+				sb.WriteRune([4]rune{' ', '▄', '▀', '█'}[boolInt(top[x] == '#')*2+boolInt(bottom[x] == '#')])
+			}
+			rows[y] = sb.String()
+		}
+		f.Glyphs[r] = rows
+	}
+	return f
+}
+
+// fontBitmaps are the 5 rows of dots of each character of the built-in
+// fonts
+var fontBitmaps = map[rune][5]string{
+	'A': {" ### ", "#   #", "#####", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#### ", "#   #", "#### "},
+	'C': {" ####", "#    ", "#    ", "#    ", " ####"},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#### ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#### ", "#    ", "#    "},
+	'G': {" ####", "#    ", "#  ##", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#####", "#   #", "#   #"},
+	'I': {"###", " # ", " # ", " # ", "###"},
+	'J': {"  ###", "   # ", "   # ", "#  # ", " ##  "},
+	'K': {"#   #", "#  # ", "###  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "#   #", "#   #"},
+	'N': {"#   #", "##  #", "# # #", "#  ##", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#### ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#### ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", " ### ", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "# # #", "## ##", "#   #"},
+	'X': {"#   #", " # # ", "  #  ", " # # ", "#   #"},
+	'Y': {"#   #", " # # ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "   # ", "  #  ", " #   ", "#####"},
+
+	'0': {" ### ", "#  ##", "# # #", "##  #", " ### "},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {" ### ", "#   #", "  ## ", " #   ", "#####"},
+	'3': {"#### ", "    #", " ### ", "    #", "#### "},
+	'4': {"#   #", "#   #", "#####", "    #", "    #"},
+	'5': {"#####", "#    ", "#### ", "    #", "#### "},
+	'6': {" ### ", "#    ", "#### ", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", "  #  "},
+	'8': {" ### ", "#   #", " ### ", "#   #", " ### "},
+	'9': {" ### ", "#   #", " ####", "    #", " ### "},
+
+	' ':  {"   ", "   ", "   ", "   ", "   "},
+	'.':  {" ", " ", " ", " ", "#"},
+	',':  {"  ", "  ", "  ", " #", "# "},
+	':':  {" ", "#", " ", "#", " "},
+	'!':  {"#", "#", "#", " ", "#"},
+	'?':  {" ### ", "#   #", "  ## ", "     ", "  #  "},
+	'\'': {"#", "#", " ", " ", " "},
+	'-':  {"    ", "    ", "####", "    ", "    "},
+	'+':  {"     ", "  #  ", "#####", "  #  ", "     "},
+	'=':  {"    ", "####", "    ", "####", "    "},
+	'*':  {"     ", "# # #", " ### ", "# # #", "     "},
+	'/':  {"    #", "   # ", "  #  ", " #   ", "#    "},
+	'%':  {"#   #", "   # ", "  #  ", " #   ", "#   #"},
+	'(':  {" #", "# ", "# ", "# ", " #"},
+	')':  {"# ", " #", " #", " #", "# "},
+	'_':  {"    ", "    ", "    ", "    ", "####"},
+}
@@ -0,0 +1,82 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// BigText draws text in large characters, for splash screens, titles and
+// clocks
+// Lines of Text are drawn one below the other, each aligned within the
+// surface by Align.
+type BigText struct {
+	Text     string
+	Font     *BigFont // Font of the characters; nil uses FontBlock
+	Align    goterm.HAlign
+	Fg       goterm.Color
+	Bg       goterm.Color
+	Gradient *goterm.Gradient // Colors the characters from left to right instead of Fg
+}
+
+// Size returns the columns and rows the text takes up
+func (t *BigText) Size() (w, h int) {
+	return t.font().Size(t.Text)
+}
+
+// PreferredSize returns the size of the text, as Size does, limited to
+// maxW×maxH
+func (t *BigText) PreferredSize(maxW, maxH int) (w, h int) {
+	w, h = t.Size()
+	return max(0, min(w, maxW)), max(0, min(h, maxH))
+}
+
+// Draw draws the text onto s from its top row
+func (t *BigText) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	fillRect(s, w, h, t.Bg)
+
+	// The gradient spans the widest line so that stacked lines match
+	textW, _ := t.Size()
+	left := alignOffset(t.Align, w, textW)
+	for y, row := range t.font().Render(t.Text) {
+		if y >= h {
+			break
+		}
+		x := alignOffset(t.Align, w, goterm.StringWidth(row))
+		for _, ch := range row {
+			if ch != ' ' && x >= 0 && x < w {
+				s.SetCell(x, y, goterm.NewCell(ch, t.color(x-left, textW), t.Bg, goterm.StyleNone))
+			}
+			x++
+		}
+	}
+}
+
+// color returns the color of the character in column x of text textW
+// columns wide
+func (t *BigText) color(x, textW int) goterm.Color {
+	if t.Gradient == nil {
+		return t.Fg
+	}
+	if textW <= 1 {
+		return t.Gradient.At(0)
+	}
+	return t.Gradient.At(float64(x) / float64(textW-1))
+}
+
+// alignOffset returns the column at which content n columns wide starts
+// when aligned within w columns
+func alignOffset(align goterm.HAlign, w, n int) int {
+	switch align {
+	case goterm.AlignCenter:
+		return (w - n) / 2
+	case goterm.AlignRight:
+		return w - n
+	}
+	return 0
+}
+
+// font returns the font to draw in
+func (t *BigText) font() *BigFont {
+	if t.Font == nil {
+		return FontBlock
+	}
+	return t.Font
+}
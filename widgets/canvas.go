@@ -0,0 +1,130 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// brailleBits maps a dot's column and row within a cell to its bit in the
+// braille pattern
+var brailleBits = [4][2]uint8{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Canvas is a drawing surface of braille dots, 2 across and 4 down per
+// cell, for plots and line art at eight times the resolution of cells
+// Each cell takes the color of the dot set in it last.
+type Canvas struct {
+	Bg goterm.Color // Background of every cell
+
+	width, height int // Size in cells
+	dots          []uint8
+	colors        []goterm.Color
+}
+
+// NewCanvas creates a canvas covering w×h cells
+func NewCanvas(w, h int) *Canvas {
+	w, h = max(0, w), max(0, h)
+	return &Canvas{
+		width:  w,
+		height: h,
+		dots:   make([]uint8, w*h),
+		colors: make([]goterm.Color, w*h),
+	}
+}
+
+// Size returns the canvas size in dots
+func (c *Canvas) Size() (width, height int) {
+	return c.width * 2, c.height * 4
+}
+
+// Clear removes all dots
+func (c *Canvas) Clear() {
+	clear(c.dots)
+}
+
+// Set sets the dot at (x, y), where (0, 0) is the top-left dot
+// Dots outside the canvas are ignored.
+func (c *Canvas) Set(x, y int, color goterm.Color) {
+	if x < 0 || y < 0 || x >= c.width*2 || y >= c.height*4 {
+		return
+	}
+	i := (y/4)*c.width + x/2
+	c.dots[i] |= brailleBits[y%4][x%2]
+	c.colors[i] = color
+}
+
+// IsSet reports whether the dot at (x, y) is set
+func (c *Canvas) IsSet(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.width*2 || y >= c.height*4 {
+		return false
+	}
+	return c.dots[(y/4)*c.width+x/2]&brailleBits[y%4][x%2] != 0
+}
+
+// Line sets the dots on the straight line from (x0, y0) to (x1, y1)
+func (c *Canvas) Line(x0, y0, x1, y1 int, color goterm.Color) {
+	// Bresenham's algorithm
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	for {
+		c.Set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// PreferredSize returns the size of the canvas in cells
+func (c *Canvas) PreferredSize(maxW, maxH int) (w, h int) {
+	return max(0, min(c.width, maxW)), max(0, min(c.height, maxH))
+}
+
+// Draw draws the canvas onto s from its top-left corner
+// Cells without dots are drawn as blanks.
+func (c *Canvas) Draw(s goterm.Surface) {
+	c.drawAt(s, 0, 0)
+}
+
+// drawAt draws the canvas onto s with its top-left corner at (x0, y0)
+func (c *Canvas) drawAt(s goterm.Surface, x0, y0 int) {
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			i := y*c.width + x
+			ch := ' '
+			if c.dots[i] != 0 {
+				ch = 0x2800 + rune(c.dots[i])
+			}
+			s.SetCell(x0+x, y0+y, goterm.NewCell(ch, c.colors[i], c.Bg, goterm.StyleNone))
+		}
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// sign returns -1, 0 or 1 for negative, zero and positive n
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
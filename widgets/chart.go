@@ -0,0 +1,155 @@
+package widgets
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/dshills/goterm"
+)
+
+// legendEntry is a series shown in a chart legend
+type legendEntry struct {
+	name   string
+	color  goterm.Color
+	marker rune // Symbol shown before the name; 0 for a dot
+}
+
+// chartFrame is the axes and legend drawn around the plot area of a chart
+type chartFrame struct {
+	yMin, yMax float64
+	xMin, xMax float64
+	xLabels    bool // Label the ends of the x axis
+	axes       bool
+	legend     []legendEntry
+	fg, bg     goterm.Color
+}
+
+// plotRect returns the plot area of a w×h chart
+func (f *chartFrame) plotRect(w, h int) goterm.Rect {
+	r := goterm.Rect{W: w, H: h}
+	if f.hasLegend() {
+		r.H--
+	}
+	if f.axes {
+		r.X = f.labelWidth() + 1
+		r.W -= r.X
+		r.H--
+		if f.xLabels {
+			r.H--
+		}
+	}
+	r.W, r.H = max(0, r.W), max(0, r.H)
+	return r
+}
+
+// draw draws the axes, labels and legend of a w×h chart onto s
+func (f *chartFrame) draw(s goterm.Surface, w, h int) {
+	plot := f.plotRect(w, h)
+	if f.axes && plot.H > 0 {
+		axisX, axisY := plot.X-1, plot.Y+plot.H
+		for y := 0; y < plot.H; y++ {
+			s.SetCell(axisX, y, goterm.NewCell('│', f.fg, f.bg, goterm.StyleNone))
+		}
+		s.SetCell(axisX, axisY, goterm.NewCell('└', f.fg, f.bg, goterm.StyleNone))
+		for x := plot.X; x < w; x++ {
+			s.SetCell(x, axisY, goterm.NewCell('─', f.fg, f.bg, goterm.StyleNone))
+		}
+
+		top, bottom := formatValue(f.yMax), formatValue(f.yMin)
+		s.DrawText(axisX-goterm.StringWidth(top), 0, top, f.fg, f.bg, goterm.StyleNone)
+		if plot.H > 1 {
+			s.DrawText(axisX-goterm.StringWidth(bottom), plot.H-1, bottom, f.fg, f.bg, goterm.StyleNone)
+		}
+		if f.xLabels {
+			left, right := formatValue(f.xMin), formatValue(f.xMax)
+			s.DrawText(plot.X, axisY+1, left, f.fg, f.bg, goterm.StyleNone)
+			s.DrawText(w-goterm.StringWidth(right), axisY+1, right, f.fg, f.bg, goterm.StyleNone)
+		}
+	}
+
+	if f.hasLegend() {
+		text := f.legendText()
+		drawSpans(s, plot.X, h-1, w, truncateSpans(text, w-plot.X))
+	}
+}
+
+// legendText returns the legend: a marker and the name of each series
+func (f *chartFrame) legendText() goterm.StyledText {
+	var text goterm.StyledText
+	for _, e := range f.legend {
+		if e.name == "" {
+			continue
+		}
+		if len(text) > 0 {
+			text = text.Add("  ", f.fg, f.bg, goterm.StyleNone)
+		}
+		marker := e.marker
+		if marker == 0 {
+			marker = '●'
+		}
+		text = text.Add(string(marker)+" ", e.color, f.bg, goterm.StyleNone).Add(e.name, f.fg, f.bg, goterm.StyleNone)
+	}
+	return text
+}
+
+// preferredSize returns the size of a chart with a plot area plotW cells
+// wide that shows the whole legend and takes all of the height, no larger
+// than maxW×maxH
+// Charts scale to any height, so the height is always maxH.
+func (f *chartFrame) preferredSize(plotW, maxW, maxH int) (w, h int) {
+	x := 0
+	if f.axes {
+		x = f.labelWidth() + 1
+	}
+	w = x + plotW
+	if f.hasLegend() {
+		w = max(w, x+f.legendText().Width())
+	}
+	return max(0, min(w, maxW)), max(0, maxH)
+}
+
+// hasLegend reports whether any series has a name to show
+func (f *chartFrame) hasLegend() bool {
+	for _, e := range f.legend {
+		if e.name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// labelWidth returns the width of the y axis labels
+func (f *chartFrame) labelWidth() int {
+	return max(goterm.StringWidth(formatValue(f.yMax)), goterm.StringWidth(formatValue(f.yMin)))
+}
+
+// formatValue formats an axis label with up to 4 significant digits
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// dataRange returns the range of the finite values, widened to a non-empty
+// range when they are all equal; lo and hi are used instead if lo < hi
+func dataRange(values []float64, lo, hi float64) (float64, float64) {
+	if lo < hi {
+		return lo, hi
+	}
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+	switch {
+	case lo > hi:
+		return 0, 1
+	case lo == hi:
+		return lo - 1, hi + 1
+	}
+	return lo, hi
+}
+
+// scale maps v in the range lo-hi to a dot position from 0 to n-1
+func scale(v, lo, hi float64, n int) int {
+	return int(math.Round((v - lo) / (hi - lo) * float64(n-1)))
+}
@@ -0,0 +1,55 @@
+package widgets
+
+import (
+	"sync"
+
+	"github.com/dshills/goterm"
+)
+
+// Clipboard holds text cut or copied from widgets for pasting
+type Clipboard interface {
+	Copy(text string)
+	Paste() string
+}
+
+// MemoryClipboard is a Clipboard private to the application
+// Widgets without a clipboard of their own share one MemoryClipboard.
+type MemoryClipboard struct {
+	mu   sync.Mutex
+	text string
+}
+
+// Copy replaces the clipboard content
+func (c *MemoryClipboard) Copy(text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.text = text
+}
+
+// Paste returns the clipboard content
+func (c *MemoryClipboard) Paste() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.text
+}
+
+// TerminalClipboard is a MemoryClipboard that also places copied text on the
+// system clipboard through the terminal (see goterm.Screen.SetClipboard)
+// Terminals do not let applications read the system clipboard, so Paste
+// returns the last text copied within the application; text pasted from
+// elsewhere arrives as a goterm.PasteEvent.
+type TerminalClipboard struct {
+	MemoryClipboard
+	Screen *goterm.Screen
+}
+
+// Copy replaces the clipboard content and sends it to the terminal
+// Failing to reach the terminal is not reported, since the next Show fails
+// the same way.
+func (c *TerminalClipboard) Copy(text string) {
+	c.MemoryClipboard.Copy(text)
+	_ = c.Screen.SetClipboard(text)
+}
+
+// sharedClipboard is used by widgets without a clipboard of their own
+var sharedClipboard = &MemoryClipboard{}
@@ -0,0 +1,218 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dshills/goterm"
+)
+
+// CodeView shows source code with syntax highlighting and line numbers and
+// scrolls it in both directions
+// The arrow keys scroll by one line or column, Page Up and Page Down by a
+// page, and Home and End jump to the top and bottom; the mouse wheel
+// scrolls vertically, or horizontally with Shift held. The line numbers
+// stay in place while the code scrolls sideways.
+type CodeView struct {
+	focusState
+
+	Highlighter Highlighter              // Splits lines into tokens; nil shows plain text
+	Styles      map[TokenKind]TokenStyle // Look of each kind of token; nil uses DefaultTokenStyles
+	LineNumbers bool
+	TabWidth    int // Columns per tab; 0 for 4
+	Fg          goterm.Color
+	Bg          goterm.Color
+	NumberFg    goterm.Color // Colors of the line numbers
+	NumberBg    goterm.Color
+
+	lines         []string
+	tokens        [][]Token
+	longest       int // Width of the longest line
+	x, y          int // Scroll offset
+	width, height int // Code area at the last Draw
+}
+
+// SetText replaces the code shown and highlights it
+// The scroll position is kept as far as the new text allows.
+func (c *CodeView) SetText(text string) {
+	tab := strings.Repeat(" ", c.TabWidth)
+	if c.TabWidth <= 0 {
+		tab = "    "
+	}
+	c.lines = strings.Split(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\t", tab), "\n")
+	c.longest = 0
+	for _, line := range c.lines {
+		c.longest = max(c.longest, goterm.StringWidth(line))
+	}
+	c.tokens = nil
+	if c.Highlighter != nil {
+		c.tokens = c.Highlighter.Highlight(c.lines)
+	}
+	c.clamp()
+}
+
+// Text returns the code shown, with tabs expanded
+func (c *CodeView) Text() string {
+	return strings.Join(c.lines, "\n")
+}
+
+// Lines returns the number of lines of code
+func (c *CodeView) Lines() int {
+	return len(c.lines)
+}
+
+// Offset returns the column and line shown at the top-left corner of the
+// code
+func (c *CodeView) Offset() (x, y int) {
+	return c.x, c.y
+}
+
+// ScrollTo scrolls so that column x of line y is at the top-left corner of
+// the code, as far as the text allows
+func (c *CodeView) ScrollTo(x, y int) {
+	c.x, c.y = x, y
+	c.clamp()
+}
+
+// ScrollBy scrolls by dx columns and dy lines
+func (c *CodeView) ScrollBy(dx, dy int) {
+	c.ScrollTo(c.x+dx, c.y+dy)
+}
+
+// HandleEvent scrolls in response to ev
+func (c *CodeView) HandleEvent(ev goterm.Event) bool {
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		page := max(1, c.height-1)
+		switch ev.Key {
+		case goterm.KeyUp:
+			c.ScrollBy(0, -1)
+		case goterm.KeyDown:
+			c.ScrollBy(0, 1)
+		case goterm.KeyLeft:
+			c.ScrollBy(-1, 0)
+		case goterm.KeyRight:
+			c.ScrollBy(1, 0)
+		case goterm.KeyPageUp:
+			c.ScrollBy(0, -page)
+		case goterm.KeyPageDown:
+			c.ScrollBy(0, page)
+		case goterm.KeyHome:
+			c.ScrollTo(0, 0)
+		case goterm.KeyEnd:
+			c.ScrollTo(0, len(c.lines))
+		default:
+			return false
+		}
+		return true
+	case goterm.MouseEvent:
+		shift := ev.Modifiers&goterm.ModShift != 0
+		switch {
+		case ev.Button == goterm.MouseWheelUp && shift:
+			c.ScrollBy(-3, 0)
+		case ev.Button == goterm.MouseWheelDown && shift:
+			c.ScrollBy(3, 0)
+		case ev.Button == goterm.MouseWheelUp:
+			c.ScrollBy(0, -3)
+		case ev.Button == goterm.MouseWheelDown:
+			c.ScrollBy(0, 3)
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// PreferredSize returns the size that shows all of the code without
+// scrolling, including the line numbers
+func (c *CodeView) PreferredSize(maxW, maxH int) (w, h int) {
+	return max(0, min(c.gutter()+c.longest, maxW)), max(0, min(len(c.lines), maxH))
+}
+
+// Draw draws the lines in view onto s, with the line numbers in a gutter
+// on the left
+func (c *CodeView) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	gutter := c.gutter()
+	c.width, c.height = max(0, w-gutter), h
+	c.clamp()
+
+	styles := c.Styles
+	if styles == nil {
+		styles = DefaultTokenStyles()
+	}
+	for y := 0; y < h; y++ {
+		i := c.y + y
+		if gutter > 0 {
+			number := ""
+			if i < len(c.lines) {
+				number = fmt.Sprintf("%*d", gutter-1, i+1)
+			}
+			x := drawSpans(s, 0, y, gutter, goterm.StyledText{}.Add(number, c.NumberFg, c.NumberBg, goterm.StyleNone))
+			fillRow(s, x, y, gutter, c.NumberBg, goterm.StyleNone)
+		}
+		col := 0
+		if i < len(c.lines) {
+			col = c.drawLine(s, gutter, y, w, c.lineTokens(i), styles)
+		}
+		fillRow(s, max(gutter, col), y, w, c.Bg, goterm.StyleNone)
+	}
+}
+
+// drawLine draws tokens from column c.x onwards at column x0 of row y, cut
+// off at maxX, and returns the column after the last cell drawn
+func (c *CodeView) drawLine(s goterm.Surface, x0, y, maxX int, tokens []Token, styles map[TokenKind]TokenStyle) int {
+	col := 0 // Column within the line
+	x := x0
+	for _, tok := range tokens {
+		st := styles[tok.Kind]
+		fg, bg := c.Fg, c.Bg
+		if st.Fg != goterm.ColorDefault() {
+			fg = st.Fg
+		}
+		if st.Bg != goterm.ColorDefault() {
+			bg = st.Bg
+		}
+		for _, r := range tok.Text {
+			rw := max(1, goterm.RuneWidth(r))
+			switch {
+			case col+rw <= c.x:
+			case col < c.x:
+				// A wide character cut by the left edge leaves a blank
+				s.SetCell(x, y, goterm.NewCell(' ', fg, bg, st.Style))
+				x++
+			case x+rw > maxX:
+				return x
+			default:
+				s.SetCell(x, y, goterm.NewCell(r, fg, bg, st.Style))
+				x += rw
+			}
+			col += rw
+		}
+	}
+	return x
+}
+
+// gutter returns the width of the line numbers and the space after them
+func (c *CodeView) gutter() int {
+	if !c.LineNumbers {
+		return 0
+	}
+	return len(fmt.Sprint(max(1, len(c.lines)))) + 1
+}
+
+// lineTokens returns the tokens of line i, or the whole line as plain text
+// without a highlighter
+func (c *CodeView) lineTokens(i int) []Token {
+	if i < len(c.tokens) {
+		return c.tokens[i]
+	}
+	return []Token{{Text: c.lines[i]}}
+}
+
+// clamp keeps the scroll offset within the text
+func (c *CodeView) clamp() {
+	c.x = max(0, min(c.x, c.longest-c.width))
+	c.y = max(0, min(c.y, len(c.lines)-c.height))
+}
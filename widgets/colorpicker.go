@@ -0,0 +1,457 @@
+package widgets
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dshills/goterm"
+)
+
+// ColorPickerMode is the way a ColorPicker offers colors
+type ColorPickerMode int
+
+const (
+	// ColorPicker16 offers the 16 ANSI colors
+	ColorPicker16 ColorPickerMode = iota
+	// ColorPicker256 offers the 256-color palette
+	ColorPicker256
+	// ColorPickerRGB edits the red, green and blue channels of a true color
+	ColorPickerRGB
+	// ColorPickerHSV edits the hue, saturation and value of a true color
+	ColorPickerHSV
+)
+
+// colorPickerTabs are the labels of the modes, in order
+var colorPickerTabs = []string{"16", "256", "RGB", "HSV"}
+
+// ColorPicker lets the user choose a color from the 16 or 256-color palette
+// or mix a true color with RGB or HSV sliders, for theme editors
+// The first row holds a tab per mode and the last a preview swatch of the
+// color with its description. [ and ] switch modes, as does clicking a tab.
+// In the palette modes the arrow keys, Home and End move through the
+// swatches; with sliders Up and Down pick a channel and Left and Right
+// adjust it, by ten with Shift held. Clicking a swatch or a slider sets the
+// color directly. Every change is reported to OnChange and Enter reports the
+// color to OnSelect. Switching modes converts the color to the nearest one
+// the new mode offers.
+type ColorPicker struct {
+	focusState
+
+	Mode     ColorPickerMode
+	OnChange func(color goterm.Color)
+	OnSelect func(color goterm.Color)
+	Fg       goterm.Color
+	Bg       goterm.Color
+	FocusFg  goterm.Color // Colors of the current tab and channel when focused; reverse video if both are default
+	FocusBg  goterm.Color
+
+	color   goterm.Color
+	h, s, v float64 // HSV of color, kept so hue and saturation survive on the gray axis
+	channel int     // Slider being edited
+	width   int     // Width at the last Draw
+}
+
+// Color returns the chosen color in the form the current mode offers
+// Before a color is set or chosen this is black.
+func (p *ColorPicker) Color() goterm.Color {
+	c := p.color
+	if c.Mode() == goterm.ColorModeDefault {
+		c = goterm.ColorBlack
+	}
+	switch p.Mode {
+	case ColorPicker16:
+		return c.To16()
+	case ColorPicker256:
+		return c.To256()
+	}
+	return c.ToRGB()
+}
+
+// SetColor sets the chosen color
+// OnChange is not called.
+func (p *ColorPicker) SetColor(color goterm.Color) {
+	p.color = color
+	p.h, p.s, p.v = p.Color().HSV()
+}
+
+// SetMode switches to mode, converting the color
+func (p *ColorPicker) SetMode(mode ColorPickerMode) {
+	p.Mode = max(ColorPicker16, min(ColorPickerHSV, mode))
+	p.color = p.Color()
+	p.channel = 0
+}
+
+// HandleEvent changes the mode or the color in response to ev
+func (p *ColorPicker) HandleEvent(ev goterm.Event) bool {
+	before := p.Color()
+	handled := false
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		handled = p.handleKey(ev)
+	case goterm.MouseEvent:
+		handled = p.handleMouse(ev)
+	}
+	if after := p.Color(); after != before && p.OnChange != nil {
+		p.OnChange(after)
+	}
+	return handled
+}
+
+// handleKey handles a key press
+func (p *ColorPicker) handleKey(ev goterm.KeyEvent) bool {
+	switch {
+	case ev.Key == goterm.KeyRune && ev.Rune == '[':
+		p.SetMode((p.Mode + 3) % 4)
+	case ev.Key == goterm.KeyRune && ev.Rune == ']':
+		p.SetMode((p.Mode + 1) % 4)
+	case ev.Key == goterm.KeyEnter:
+		if p.OnSelect != nil {
+			p.OnSelect(p.Color())
+		}
+	case p.Mode == ColorPicker16 || p.Mode == ColorPicker256:
+		return p.handlePaletteKey(ev)
+	default:
+		return p.handleSliderKey(ev)
+	}
+	return true
+}
+
+// handlePaletteKey moves through the palette swatches
+func (p *ColorPicker) handlePaletteKey(ev goterm.KeyEvent) bool {
+	rows := paletteRows(p.Mode)
+	index := int(p.Color().Index())
+	last := rows[len(rows)-1]
+	row, col := paletteFind(rows, index)
+	switch ev.Key {
+	case goterm.KeyLeft:
+		index = max(0, index-1)
+	case goterm.KeyRight:
+		index = min(last[len(last)-1], index+1)
+	case goterm.KeyUp, goterm.KeyDown:
+		if ev.Key == goterm.KeyUp {
+			row = max(0, row-1)
+		} else {
+			row = min(len(rows)-1, row+1)
+		}
+		index = rows[row][min(col, len(rows[row])-1)]
+	case goterm.KeyHome:
+		index = 0
+	case goterm.KeyEnd:
+		index = last[len(last)-1]
+	default:
+		return false
+	}
+	p.SetColor(goterm.ColorIndex(uint8(index))) // #nosec G115
+	return true
+}
+
+// handleSliderKey picks and adjusts a channel
+func (p *ColorPicker) handleSliderKey(ev goterm.KeyEvent) bool {
+	step := 1
+	if ev.Modifiers&goterm.ModShift != 0 {
+		step = 10
+	}
+	value, maxValue := p.channelValue(p.channel)
+	switch ev.Key {
+	case goterm.KeyUp:
+		p.channel = max(0, p.channel-1)
+	case goterm.KeyDown:
+		p.channel = min(2, p.channel+1)
+	case goterm.KeyLeft:
+		p.setChannel(p.channel, value-step)
+	case goterm.KeyRight:
+		p.setChannel(p.channel, value+step)
+	case goterm.KeyHome:
+		p.setChannel(p.channel, 0)
+	case goterm.KeyEnd:
+		p.setChannel(p.channel, maxValue)
+	default:
+		return false
+	}
+	return true
+}
+
+// handleMouse handles clicks on the tabs, swatches and sliders
+func (p *ColorPicker) handleMouse(ev goterm.MouseEvent) bool {
+	if ev.Button != goterm.MouseLeft || (ev.Action != goterm.MousePress && ev.Action != goterm.MouseMotion) {
+		return false
+	}
+	if ev.Y == 0 && ev.Action == goterm.MousePress {
+		x := 0
+		for i, tab := range colorPickerTabs {
+			w := len(tab) + 2
+			if ev.X >= x && ev.X < x+w {
+				p.SetMode(ColorPickerMode(i))
+				return true
+			}
+			x += w + 1
+		}
+		return false
+	}
+
+	y := ev.Y - 2
+	if p.Mode == ColorPicker16 || p.Mode == ColorPicker256 {
+		rows := paletteRows(p.Mode)
+		col := ev.X / paletteSwatchWidth(p.Mode)
+		if y < 0 || y >= len(rows) || ev.X < 0 || col >= len(rows[y]) {
+			return false
+		}
+		p.SetColor(goterm.ColorIndex(uint8(rows[y][col]))) // #nosec G115
+		return true
+	}
+
+	n := p.trackWidth()
+	if y < 0 || y > 2 || ev.X < 2 || ev.X >= 2+n {
+		return false
+	}
+	p.channel = y
+	_, maxValue := p.channelValue(y)
+	p.setChannel(y, trackValue(ev.X-2, n, maxValue))
+	return true
+}
+
+// channelValue returns the value of slider i and its maximum
+func (p *ColorPicker) channelValue(i int) (value, maxValue int) {
+	if p.Mode == ColorPickerRGB {
+		r, g, b := p.Color().RGB()
+		return int([3]uint8{r, g, b}[i]), 255
+	}
+	p.syncHSV()
+	switch i {
+	case 0:
+		return int(math.Round(p.h)), 359
+	case 1:
+		return int(math.Round(p.s * 100)), 100
+	}
+	return int(math.Round(p.v * 100)), 100
+}
+
+// setChannel sets slider i to value, within its range
+func (p *ColorPicker) setChannel(i, value int) {
+	if p.Mode == ColorPickerRGB {
+		r, g, b := p.Color().RGB()
+		rgb := [3]uint8{r, g, b}
+		rgb[i] = uint8(max(0, min(255, value))) // #nosec G115
+		p.SetColor(goterm.ColorRGB(rgb[0], rgb[1], rgb[2]))
+		return
+	}
+	p.syncHSV()
+	p.h, p.s, p.v = hsvWith(p.h, p.s, p.v, i, value)
+	p.color = goterm.ColorHSV(p.h, p.s, p.v)
+}
+
+// syncHSV reloads the HSV fields if the color was changed without them
+func (p *ColorPicker) syncHSV() {
+	if goterm.ColorHSV(p.h, p.s, p.v) != p.Color().ToRGB() {
+		p.h, p.s, p.v = p.Color().HSV()
+	}
+}
+
+// colorPickerTrack is the preferred width of a slider track in cells
+const colorPickerTrack = 32
+
+// PreferredSize returns the size that shows the mode tabs, the whole
+// palette or sliders with colorPickerTrack wide tracks and the preview
+func (p *ColorPicker) PreferredSize(maxW, maxH int) (w, h int) {
+	for i, tab := range colorPickerTabs {
+		w += len(tab) + 2
+		if i > 0 {
+			w++
+		}
+	}
+	rows := 3
+	if p.Mode == ColorPicker16 || p.Mode == ColorPicker256 {
+		palette := paletteRows(p.Mode)
+		for _, row := range palette {
+			w = max(w, len(row)*paletteSwatchWidth(p.Mode))
+		}
+		rows = len(palette)
+	} else {
+		w = max(w, 2+colorPickerTrack+1+3)
+	}
+	w = max(w, 7+goterm.StringWidth(colorLabel(p.Color())))
+	return max(0, min(w, maxW)), max(0, min(2+rows+2, maxH))
+}
+
+// Draw draws the mode tabs, the palette or sliders and the preview onto s
+func (p *ColorPicker) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	p.width = w
+	fillRect(s, w, h, p.Bg)
+	fg, bg, style := p.highlight()
+
+	x := 0
+	for i, tab := range colorPickerTabs {
+		if ColorPickerMode(i) == p.Mode {
+			x = drawSpans(s, x, 0, w, goterm.StyledText{}.Add(" "+tab+" ", fg, bg, style))
+		} else {
+			x = drawSpans(s, x, 0, w, goterm.StyledText{}.Add(" "+tab+" ", p.Fg, p.Bg, goterm.StyleNone))
+		}
+		x++
+	}
+
+	rows := 3
+	if p.Mode == ColorPicker16 || p.Mode == ColorPicker256 {
+		rows = p.drawPalette(s, w)
+	} else {
+		p.drawSliders(s, w)
+	}
+
+	color := p.Color()
+	preview := 2 + rows + 1
+	for x := 0; x < min(6, w); x++ {
+		s.SetCell(x, preview, goterm.NewCell(' ', goterm.ColorDefault(), color, goterm.StyleNone))
+	}
+	drawSpans(s, 7, preview, w, goterm.StyledText{}.Add(colorLabel(color), p.Fg, p.Bg, goterm.StyleNone))
+}
+
+// drawPalette draws the palette swatches from row 2 and returns the number
+// of rows drawn
+func (p *ColorPicker) drawPalette(s goterm.Surface, w int) int {
+	current := int(p.Color().Index())
+	sw := paletteSwatchWidth(p.Mode)
+	rows := paletteRows(p.Mode)
+	for y, row := range rows {
+		for col, index := range row {
+			swatch := goterm.ColorIndex(uint8(index)) // #nosec G115
+			for i := 0; i < sw && col*sw+i < w; i++ {
+				ch := ' '
+				if index == current && i == sw/2 {
+					ch = '◆'
+				}
+				s.SetCell(col*sw+i, 2+y, goterm.NewCell(ch, goterm.BestTextColor(swatch), swatch, goterm.StyleNone))
+			}
+		}
+	}
+	return len(rows)
+}
+
+// drawSliders draws a slider per channel from row 2, each a track showing
+// the colors the channel can take
+func (p *ColorPicker) drawSliders(s goterm.Surface, w int) {
+	labels := "RGB"
+	if p.Mode == ColorPickerHSV {
+		labels = "HSV"
+	}
+	n := p.trackWidth()
+	fg, bg, style := p.highlight()
+	for i := 0; i < 3; i++ {
+		y := 2 + i
+		label := goterm.StyledText{}.Add(labels[i:i+1], p.Fg, p.Bg, goterm.StyleNone)
+		if i == p.channel {
+			label = goterm.StyledText{}.Add(labels[i:i+1], fg, bg, style)
+		}
+		drawSpans(s, 0, y, w, label)
+
+		value, maxValue := p.channelValue(i)
+		marker := int(math.Round(float64(value) * float64(n-1) / float64(max(1, maxValue))))
+		for x := 0; x < n; x++ {
+			c := p.trackColor(i, trackValue(x, n, maxValue))
+			ch := ' '
+			if x == marker {
+				ch = '┃'
+			}
+			s.SetCell(2+x, y, goterm.NewCell(ch, goterm.BestTextColor(c), c, goterm.StyleNone))
+		}
+		drawSpans(s, 2+n+1, y, w, goterm.StyledText{}.Add(fmt.Sprintf("%3d", value), p.Fg, p.Bg, goterm.StyleNone))
+	}
+}
+
+// trackColor returns the color with channel i set to value
+func (p *ColorPicker) trackColor(i, value int) goterm.Color {
+	if p.Mode == ColorPickerRGB {
+		r, g, b := p.Color().RGB()
+		rgb := [3]uint8{r, g, b}
+		rgb[i] = uint8(max(0, min(255, value))) // #nosec G115
+		return goterm.ColorRGB(rgb[0], rgb[1], rgb[2])
+	}
+	return goterm.ColorHSV(hsvWith(p.h, p.s, p.v, i, value))
+}
+
+// trackWidth returns the width of the slider tracks, leaving room for the
+// channel label and value
+func (p *ColorPicker) trackWidth() int {
+	return max(2, p.width-6)
+}
+
+// highlight returns the colors and style of the current tab and channel
+func (p *ColorPicker) highlight() (fg, bg goterm.Color, style goterm.Style) {
+	if !p.focused {
+		return p.Fg, p.Bg, goterm.StyleBold
+	}
+	if p.FocusFg == goterm.ColorDefault() && p.FocusBg == goterm.ColorDefault() {
+		return p.FocusFg, p.FocusBg, goterm.StyleReverse
+	}
+	return p.FocusFg, p.FocusBg, goterm.StyleNone
+}
+
+// hsvWith returns h, s and v with channel i (0 hue in degrees, 1 saturation
+// or 2 value in percent) set to value
+func hsvWith(h, s, v float64, i, value int) (float64, float64, float64) {
+	switch i {
+	case 0:
+		h = float64(max(0, min(359, value)))
+	case 1:
+		s = float64(max(0, min(100, value))) / 100
+	default:
+		v = float64(max(0, min(100, value))) / 100
+	}
+	return h, s, v
+}
+
+// trackValue returns the channel value at column x of a track n columns
+// wide
+func trackValue(x, n, maxValue int) int {
+	return int(math.Round(float64(x) * float64(maxValue) / float64(max(1, n-1))))
+}
+
+// colorLabel describes a color for the preview: its palette index or hex
+// value
+func colorLabel(c goterm.Color) string {
+	if c.Mode() == goterm.ColorModeTrueColor {
+		r, g, b := c.RGB()
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	return c.String()
+}
+
+// paletteRows returns the palette indexes shown on each row in a palette
+// mode: the ANSI colors, then the color cube a red level per row and the
+// grayscale ramp
+func paletteRows(mode ColorPickerMode) [][]int {
+	if mode == ColorPicker16 {
+		return [][]int{indexRange(0, 8), indexRange(8, 16)}
+	}
+	rows := [][]int{indexRange(0, 16)}
+	for r := 0; r < 6; r++ {
+		rows = append(rows, indexRange(16+36*r, 16+36*(r+1)))
+	}
+	return append(rows, indexRange(232, 256))
+}
+
+// paletteFind returns the row and column of index in rows
+func paletteFind(rows [][]int, index int) (row, col int) {
+	for y, r := range rows {
+		if index >= r[0] && index <= r[len(r)-1] {
+			return y, index - r[0]
+		}
+	}
+	return 0, 0
+}
+
+// paletteSwatchWidth returns the width of a swatch in a palette mode
+func paletteSwatchWidth(mode ColorPickerMode) int {
+	if mode == ColorPicker16 {
+		return 3
+	}
+	return 1
+}
+
+// indexRange returns the integers from lo up to hi
+func indexRange(lo, hi int) []int {
+	r := make([]int, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		r = append(r, i)
+	}
+	return r
+}
@@ -0,0 +1,244 @@
+package widgets
+
+import (
+	"strings"
+
+	"github.com/dshills/goterm"
+)
+
+// DialogLayerZ is the z-index of the layer a Dialog is drawn on, above the
+// list of an open Select
+const DialogLayerZ = 2000
+
+// Dialog is a modal box with a message and a row of buttons, centered on the
+// screen
+// Open draws the dialog on a layer of its own, so it stays over the screen
+// content however often that is redrawn, and Close removes the layer to
+// reveal the content again. While open, the dialog takes all input: Left, Right and
+// Tab move between buttons, Enter or a click presses one and Escape dismisses
+// the dialog. Because the dialog places itself, the coordinates of mouse
+// events passed to HandleEvent are screen coordinates. Open gives the dialog
+// the focus and Close takes it away; the focused button is only highlighted
+// while the dialog has the focus.
+type Dialog struct {
+	focusState
+
+	Title    string
+	Message  string
+	Buttons  []string // Button labels; none shows the message only
+	Border   goterm.BorderStyle
+	Shadow   bool             // Draw a drop shadow
+	OnClose  func(button int) // Called with the pressed button, or -1 if dismissed
+	Fg       goterm.Color
+	Bg       goterm.Color
+	BorderFg goterm.Color
+	FocusFg  goterm.Color // Colors of the focused button; reverse video if both are default
+	FocusBg  goterm.Color
+
+	screen *goterm.Screen
+	layer  *goterm.Layer // Layer the dialog is drawn on, nil when closed
+	rect   goterm.Rect
+	focus  int
+}
+
+// MessageBox opens a dialog showing message with an OK button
+// onClose, which may be nil, is called when the dialog closes.
+func MessageBox(screen *goterm.Screen, title, message string, onClose func()) *Dialog {
+	d := &Dialog{Title: title, Message: message, Buttons: []string{"OK"}, Shadow: true}
+	if onClose != nil {
+		d.OnClose = func(int) { onClose() }
+	}
+	d.Open(screen)
+	return d
+}
+
+// Confirm opens a dialog asking the user to confirm message with OK or
+// Cancel
+// onResult receives true if OK was pressed and false if the dialog was
+// cancelled or dismissed.
+func Confirm(screen *goterm.Screen, title, message string, onResult func(ok bool)) *Dialog {
+	d := &Dialog{Title: title, Message: message, Buttons: []string{"OK", "Cancel"}, Shadow: true}
+	d.OnClose = func(button int) {
+		if onResult != nil {
+			onResult(button == 0)
+		}
+	}
+	d.Open(screen)
+	return d
+}
+
+// Open draws the dialog centered on screen, on a layer at DialogLayerZ
+func (d *Dialog) Open(screen *goterm.Screen) {
+	if d.IsOpen() {
+		d.Close()
+	}
+	d.screen = screen
+	d.layer = screen.AddLayer(DialogLayerZ)
+	d.focus = 0
+	d.focused = true
+
+	sw, sh := screen.Size()
+	w, h := d.PreferredSize(sw-4, sh-2)
+	d.rect = goterm.Rect{X: (sw - w) / 2, Y: (sh - h) / 2, W: w, H: h}
+	d.redraw()
+	if d.Shadow {
+		drawShadow(d.layer, d.rect)
+	}
+}
+
+// Close removes the dialog's layer, revealing the screen content beneath
+// OnClose is not called.
+func (d *Dialog) Close() {
+	if !d.IsOpen() {
+		return
+	}
+	d.screen.RemoveLayer(d.layer)
+	d.screen, d.layer = nil, nil
+	d.focused = false
+}
+
+// IsOpen reports whether the dialog is shown
+func (d *Dialog) IsOpen() bool {
+	return d.layer != nil
+}
+
+// Rect returns the region of the screen the dialog occupies
+func (d *Dialog) Rect() goterm.Rect {
+	return d.rect
+}
+
+// PreferredSize returns the size that fits the title, message and buttons,
+// wrapping the message to at most maxW columns
+func (d *Dialog) PreferredSize(maxW, maxH int) (w, h int) {
+	w = max(goterm.StringWidth(d.Title)+6, d.buttonsWidth()+4)
+	for _, line := range strings.Split(d.Message, "\n") {
+		w = max(w, goterm.StringWidth(line)+4)
+	}
+	w = max(4, min(w, maxW))
+	h = len(d.lines(w)) + 4
+	if len(d.Buttons) > 0 {
+		h++
+	}
+	return w, max(3, min(h, maxH))
+}
+
+// HandleEvent moves between and presses buttons in response to ev
+// While the dialog is open every event is used.
+func (d *Dialog) HandleEvent(ev goterm.Event) bool {
+	if !d.IsOpen() {
+		return false
+	}
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		d.handleKey(ev)
+	case goterm.MouseEvent:
+		if ev.Button == goterm.MouseLeft && ev.Action == goterm.MousePress {
+			if i := d.buttonAt(ev.X-d.rect.X, ev.Y-d.rect.Y); i >= 0 {
+				d.press(i)
+			}
+		}
+	}
+	return true
+}
+
+// handleKey handles a key press
+func (d *Dialog) handleKey(ev goterm.KeyEvent) {
+	n := len(d.Buttons)
+	switch {
+	case ev.Key == goterm.KeyEscape:
+		d.press(-1)
+	case ev.Key == goterm.KeyEnter:
+		d.press(min(d.focus, n-1))
+	case n == 0:
+	case ev.Key == goterm.KeyLeft, ev.Key == goterm.KeyTab && ev.Modifiers&goterm.ModShift != 0:
+		d.focus = (d.focus + n - 1) % n
+		d.redraw()
+	case ev.Key == goterm.KeyRight, ev.Key == goterm.KeyTab:
+		d.focus = (d.focus + 1) % n
+		d.redraw()
+	}
+}
+
+// press closes the dialog and reports button to OnClose
+func (d *Dialog) press(button int) {
+	d.Close()
+	if d.OnClose != nil {
+		d.OnClose(button)
+	}
+}
+
+// Draw draws the dialog onto s, which should have the size of Rect
+func (d *Dialog) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	b := goterm.NewBuffer(w, h)
+	b.Fill(0, 0, w, h, goterm.NewCell(' ', d.Fg, d.Bg, goterm.StyleNone))
+	b.DrawTitledBox(0, 0, w, h, d.Border, d.Title, goterm.TitleTopCenter, d.BorderFg, d.Bg)
+
+	// The message is cut short if it does not fit above the buttons
+	end := h - 2
+	if len(d.Buttons) > 0 {
+		end--
+	}
+	for i, line := range d.lines(w) {
+		if 2+i >= end {
+			break
+		}
+		b.DrawText(2, 2+i, line, d.Fg, d.Bg, goterm.StyleNone)
+	}
+
+	x := (w - d.buttonsWidth()) / 2
+	for i, label := range d.Buttons {
+		fg, bg, style := d.Fg, d.Bg, goterm.StyleNone
+		if d.focused && i == d.focus {
+			fg, bg = d.FocusFg, d.FocusBg
+			if fg == goterm.ColorDefault() && bg == goterm.ColorDefault() {
+				style = goterm.StyleReverse
+			}
+		}
+		text := "[ " + label + " ]"
+		b.DrawText(x, h-2, text, fg, bg, style)
+		x += goterm.StringWidth(text) + 2
+	}
+	drawBuffer(s, b)
+}
+
+// redraw draws the dialog onto its layer
+func (d *Dialog) redraw() {
+	d.Draw(d.layer.SubView(d.rect.X, d.rect.Y, d.rect.W, d.rect.H))
+}
+
+// lines returns the message wrapped to the inside of a dialog w columns wide
+func (d *Dialog) lines(w int) []string {
+	if d.Message == "" {
+		return nil
+	}
+	return goterm.Wrap(d.Message, max(1, w-4))
+}
+
+// buttonsWidth returns the width of the button row
+func (d *Dialog) buttonsWidth() int {
+	w := 0
+	for i, label := range d.Buttons {
+		if i > 0 {
+			w += 2
+		}
+		w += goterm.StringWidth(label) + 4
+	}
+	return w
+}
+
+// buttonAt returns the button at (x, y) relative to the dialog, or -1
+func (d *Dialog) buttonAt(x, y int) int {
+	if y != d.rect.H-2 || len(d.Buttons) == 0 {
+		return -1
+	}
+	bx := (d.rect.W - d.buttonsWidth()) / 2
+	for i, label := range d.Buttons {
+		bw := goterm.StringWidth(label) + 4
+		if x >= bx && x < bx+bw {
+			return i
+		}
+		bx += bw + 2
+	}
+	return -1
+}
@@ -0,0 +1,15 @@
+// Package widgets provides reusable interface components built on goterm
+//
+// A widget draws itself onto a goterm.Surface, filling the surface's whole
+// area. To place a widget, draw it onto a view of the target region:
+//
+//	bar := &widgets.ProgressBar{Value: 0.4, ShowPercent: true}
+//	bar.Draw(screen.SubView(2, 10, 40, 1))
+//
+// Interactive widgets have a HandleEvent method that takes the events of
+// goterm and reports whether the widget used the event. The coordinates of
+// mouse events must be relative to the widget's top-left corner.
+//
+// Widgets hold their state in exported fields that may be changed between
+// frames; they are not safe for concurrent use unless documented otherwise.
+package widgets
@@ -0,0 +1,86 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// drawSpans draws spans from column x of row y, cut off at column maxX, and
+// returns the column after the last one drawn
+func drawSpans(s goterm.Surface, x, y, maxX int, spans goterm.StyledText) int {
+	for _, span := range spans {
+		if x >= maxX {
+			break
+		}
+		text := goterm.Truncate(span.Text, maxX-x, "")
+		s.DrawText(x, y, text, span.Fg, span.Bg, span.Style)
+		x += goterm.StringWidth(text)
+	}
+	return x
+}
+
+// fillRow fills row y from column x up to maxX with blanks
+func fillRow(s goterm.Surface, x, y, maxX int, bg goterm.Color, style goterm.Style) {
+	blank := goterm.NewCell(' ', goterm.ColorDefault(), bg, style)
+	for ; x < maxX; x++ {
+		s.SetCell(x, y, blank)
+	}
+}
+
+// drawBuffer copies the cells of b onto s from its top-left corner
+// Widgets that need the drawing methods of goterm.Buffer draw into a buffer
+// and copy it onto their surface.
+func drawBuffer(s goterm.Surface, b *goterm.Buffer) {
+	w, h := b.Size()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			s.SetCell(x, y, b.GetCell(x, y))
+		}
+	}
+}
+
+// truncateSpans shortens spans to at most maxW columns, ending them with
+// "…" in the style of the span that was cut
+func truncateSpans(spans goterm.StyledText, maxW int) goterm.StyledText {
+	if spans.Width() <= maxW {
+		return spans
+	}
+	var out goterm.StyledText
+	used := 0
+	for _, span := range spans {
+		room := maxW - used
+		w := goterm.StringWidth(span.Text)
+		switch {
+		case room <= 0:
+			return out
+		case w < room:
+			out = append(out, span)
+			used += w
+			continue
+		case w == room:
+			// Later spans are cut, so make room for the ellipsis
+			span.Text = goterm.Truncate(span.Text, room-1, "") + "…"
+		default:
+			span.Text = goterm.Truncate(span.Text, room, "…")
+		}
+		return append(out, span)
+	}
+	return out
+}
+
+// shadowBg is the background of drop shadows drawn on layers: black that
+// keeps about as much of the brightness beneath as goterm's DrawShadow
+var shadowBg = goterm.ColorRGBA(0, 0, 0, 153)
+
+// drawShadow draws a drop shadow just below and to the right of r onto l
+// A layer cannot darken the cells beneath it the way Screen.DrawShadow
+// does, so the shadow is made of translucent blanks that tint them.
+func drawShadow(l *goterm.Layer, r goterm.Rect) {
+	shadow := goterm.NewCell(' ', goterm.ColorDefault(), shadowBg, goterm.StyleNone)
+	l.Fill(r.X+r.W, r.Y+1, 2, r.H, shadow)
+	l.Fill(r.X+2, r.Y+r.H, r.W-2, 1, shadow)
+}
+
+// fillRect fills the w×h area of s with blanks
+func fillRect(s goterm.Surface, w, h int, bg goterm.Color) {
+	for y := 0; y < h; y++ {
+		fillRow(s, 0, y, w, bg, goterm.StyleNone)
+	}
+}
@@ -0,0 +1,9 @@
+package widgets
+
+import "errors"
+
+// Error types for widgets
+var (
+	// ErrInvalidFont indicates that a font file could not be parsed
+	ErrInvalidFont = errors.New("invalid font")
+)
@@ -0,0 +1,196 @@
+package widgets
+
+import (
+	"slices"
+
+	"github.com/dshills/goterm"
+)
+
+// Focusable is implemented by widgets that take keyboard input while they
+// have the focus
+// Focused widgets draw themselves highlighted.
+type Focusable interface {
+	SetFocused(focused bool)
+	Focused() bool
+	HandleEvent(ev goterm.Event) bool
+}
+
+// focusState holds whether a widget has the focus
+// Widgets embed it to implement the focus methods of Focusable.
+type focusState struct {
+	focused bool
+	managed bool // Added to a FocusManager
+}
+
+// focusManaged is implemented by the widgets of this package, which are
+// told when a FocusManager takes charge of their focus
+type focusManaged interface {
+	setManaged(managed bool)
+}
+
+// setManaged records whether a FocusManager manages the focus
+func (f *focusState) setManaged(managed bool) {
+	f.managed = managed
+}
+
+// showsFocus reports whether a widget whose cursor is always shown draws
+// it in full, which it does unless a FocusManager manages the widget and
+// has given the focus to another one
+func (f *focusState) showsFocus() bool {
+	return f.focused || !f.managed
+}
+
+// SetFocused gives or takes away the focus
+func (f *focusState) SetFocused(focused bool) {
+	f.focused = focused
+}
+
+// Focused reports whether the widget has the focus
+func (f *focusState) Focused() bool {
+	return f.focused
+}
+
+// FocusManager keeps track of which of a set of widgets has the focus and
+// sends it the keyboard input
+// Tab moves the focus to the next widget and Shift-Tab to the previous one,
+// in the order the widgets were added, wrapping around at the ends. Both
+// are first offered to the focused widget, so a widget that uses Tab keeps
+// it. OnFocusChange is the place to redraw the widgets whose highlight
+// changed, or to show the focus some other way, such as by the border
+// around the focused panel.
+//
+//	editor, files := &widgets.TextArea{}, &widgets.List{Items: names}
+//	focus := widgets.NewFocusManager(editor, files, &widgets.ColorPicker{})
+//	focus.OnFocusChange = func(prev, next widgets.Focusable) { redraw() }
+//	app.Handlers = append(app.Handlers, focus)
+type FocusManager struct {
+	OnFocusChange func(prev, next Focusable) // Called after the focus moves; either may be nil
+
+	widgets []Focusable
+	current int // Index of the focused widget, or -1
+}
+
+// NewFocusManager creates a focus manager for widgets, in traversal order,
+// and gives the focus to the first one
+func NewFocusManager(widgets ...Focusable) *FocusManager {
+	m := &FocusManager{current: -1}
+	for _, w := range widgets {
+		m.Add(w)
+	}
+	return m
+}
+
+// Add appends w to the traversal order, giving it the focus if no other
+// widget has it
+func (m *FocusManager) Add(w Focusable) {
+	m.widgets = append(m.widgets, w)
+	if fm, ok := w.(focusManaged); ok {
+		fm.setManaged(true)
+	}
+	w.SetFocused(false)
+	if m.current < 0 {
+		m.focus(len(m.widgets) - 1)
+	}
+}
+
+// Remove takes w out of the traversal order, moving the focus to the next
+// widget if w had it
+func (m *FocusManager) Remove(w Focusable) {
+	i := slices.Index(m.widgets, w)
+	if i < 0 {
+		return
+	}
+	m.widgets = slices.Delete(m.widgets, i, i+1)
+	if fm, ok := w.(focusManaged); ok {
+		fm.setManaged(false)
+	}
+	if i != m.current {
+		if i < m.current {
+			m.current--
+		}
+		return
+	}
+	w.SetFocused(false)
+	m.current = -1
+	var next Focusable
+	if len(m.widgets) > 0 {
+		m.current = i % len(m.widgets)
+		next = m.widgets[m.current]
+		next.SetFocused(true)
+	}
+	if m.OnFocusChange != nil {
+		m.OnFocusChange(w, next)
+	}
+}
+
+// Focused returns the widget with the focus, or nil if there is none
+func (m *FocusManager) Focused() Focusable {
+	if m.current < 0 {
+		return nil
+	}
+	return m.widgets[m.current]
+}
+
+// Focus gives the focus to w and reports whether w is managed
+func (m *FocusManager) Focus(w Focusable) bool {
+	i := slices.Index(m.widgets, w)
+	if i < 0 {
+		return false
+	}
+	m.focus(i)
+	return true
+}
+
+// Next moves the focus to the next widget
+func (m *FocusManager) Next() {
+	if len(m.widgets) > 0 {
+		m.focus((m.current + 1) % len(m.widgets))
+	}
+}
+
+// Prev moves the focus to the previous widget
+func (m *FocusManager) Prev() {
+	if n := len(m.widgets); n > 0 {
+		m.focus((max(0, m.current) + n - 1) % n)
+	}
+}
+
+// HandleEvent sends key presses and pastes to the focused widget, and
+// moves the focus on Tab or Shift-Tab if the widget does not use them
+func (m *FocusManager) HandleEvent(ev goterm.Event) bool {
+	switch ev.(type) {
+	case goterm.KeyEvent, goterm.PasteEvent:
+	default:
+		return false
+	}
+	if w := m.Focused(); w != nil && w.HandleEvent(ev) {
+		return true
+	}
+	k, ok := ev.(goterm.KeyEvent)
+	if !ok || k.Key != goterm.KeyTab || k.Modifiers&^goterm.ModShift != 0 {
+		return false
+	}
+	if k.Modifiers&goterm.ModShift != 0 {
+		m.Prev()
+	} else {
+		m.Next()
+	}
+	return true
+}
+
+// focus moves the focus to the widget at i
+func (m *FocusManager) focus(i int) {
+	if i == m.current {
+		return
+	}
+	prev := m.Focused()
+	if prev != nil {
+		prev.SetFocused(false)
+	}
+	m.current = i
+	next := m.widgets[i]
+	next.SetFocused(true)
+	if m.OnFocusChange != nil {
+		m.OnFocusChange(prev, next)
+	}
+}
@@ -0,0 +1,272 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// HelpLayerZ is the z-index of the layer a HelpOverlay is drawn on
+const HelpLayerZ = DialogLayerZ
+
+// HelpOverlay is a modal cheat sheet of the keys in a goterm.Keymap,
+// centered on the screen
+// Bindings are listed under their group names in columns, using as few
+// columns as fit the screen height; bindings without help text are left
+// out. Like Dialog, the overlay is drawn on a layer of its own, which Close
+// removes to reveal the screen content again. While open, the overlay takes all input, and Escape,
+// Enter, q, ? or a click closes it. Open gives the overlay the focus and
+// Close takes it away.
+type HelpOverlay struct {
+	focusState
+
+	Title    string // Defaults to "Help"
+	Keymap   *goterm.Keymap
+	Border   goterm.BorderStyle
+	Shadow   bool   // Draw a drop shadow
+	OnClose  func() // Called when the overlay is closed by a key or click
+	Fg       goterm.Color
+	Bg       goterm.Color
+	BorderFg goterm.Color
+	KeyFg    goterm.Color // Color of the keys
+	GroupFg  goterm.Color // Color of the group names
+
+	screen *goterm.Screen
+	layer  *goterm.Layer // Layer the overlay is drawn on, nil when closed
+	rect   goterm.Rect
+}
+
+// helpColumnGap is the space between columns of the cheat sheet
+const helpColumnGap = 3
+
+// helpSection is the bindings of one group
+type helpSection struct {
+	group    string
+	keys     []string
+	help     []string
+	keyWidth int // Width of the widest keys
+	width    int
+}
+
+// rows returns the rows the section takes, counting its heading
+func (sec helpSection) rows() int {
+	if sec.group == "" {
+		return len(sec.keys)
+	}
+	return len(sec.keys) + 1
+}
+
+// Open draws the overlay centered on screen, on a layer at HelpLayerZ
+func (o *HelpOverlay) Open(screen *goterm.Screen) {
+	if o.IsOpen() {
+		o.Close()
+	}
+	o.screen = screen
+	o.layer = screen.AddLayer(HelpLayerZ)
+	o.focused = true
+
+	sw, sh := screen.Size()
+	w, h := o.PreferredSize(sw-4, sh-2)
+	o.rect = goterm.Rect{X: (sw - w) / 2, Y: (sh - h) / 2, W: w, H: h}
+	o.Draw(o.layer.SubView(o.rect.X, o.rect.Y, o.rect.W, o.rect.H))
+	if o.Shadow {
+		drawShadow(o.layer, o.rect)
+	}
+}
+
+// Close removes the overlay's layer, revealing the screen content beneath
+// OnClose is not called.
+func (o *HelpOverlay) Close() {
+	if !o.IsOpen() {
+		return
+	}
+	o.screen.RemoveLayer(o.layer)
+	o.screen, o.layer = nil, nil
+	o.focused = false
+}
+
+// IsOpen reports whether the overlay is shown
+func (o *HelpOverlay) IsOpen() bool {
+	return o.layer != nil
+}
+
+// Rect returns the region of the screen the overlay occupies
+func (o *HelpOverlay) Rect() goterm.Rect {
+	return o.rect
+}
+
+// PreferredSize returns the size that shows every binding in as few columns
+// as fit within maxW×maxH, or as many as fit if they cannot all be shown
+func (o *HelpOverlay) PreferredSize(maxW, maxH int) (w, h int) {
+	columns := helpColumns(o.sections(), max(1, maxH-4))
+	w, h = goterm.StringWidth(o.title())+6, 0
+	contentW := 0
+	for i, col := range columns {
+		if i > 0 {
+			contentW += helpColumnGap
+		}
+		colW := 0
+		for _, sec := range col {
+			colW = max(colW, sec.width)
+		}
+		contentW += colW
+		h = max(h, columnRows(col))
+	}
+	w = max(w, contentW+4)
+	return max(4, min(w, maxW)), max(3, min(h+4, maxH))
+}
+
+// HandleEvent closes the overlay on Escape, Enter, q, ? or a click
+// While the overlay is open every event is used.
+func (o *HelpOverlay) HandleEvent(ev goterm.Event) bool {
+	if !o.IsOpen() {
+		return false
+	}
+	closing := false
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		closing = ev.Key == goterm.KeyEscape || ev.Key == goterm.KeyEnter ||
+			ev.Key == goterm.KeyRune && (ev.Rune == 'q' || ev.Rune == '?')
+	case goterm.MouseEvent:
+		closing = ev.Action == goterm.MousePress && ev.Button <= goterm.MouseRight
+	}
+	if closing {
+		o.Close()
+		if o.OnClose != nil {
+			o.OnClose()
+		}
+	}
+	return true
+}
+
+// Draw draws the overlay onto s, which should have the size of Rect
+func (o *HelpOverlay) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	b := goterm.NewBuffer(w, h)
+	b.Fill(0, 0, w, h, goterm.NewCell(' ', o.Fg, o.Bg, goterm.StyleNone))
+	b.DrawTitledBox(0, 0, w, h, o.Border, o.title(), goterm.TitleTopCenter, o.BorderFg, o.Bg)
+
+	// Columns that do not fit are left out, and rows below the box are cut
+	maxX, maxY := w-2, h-2
+	x := 2
+	for _, col := range helpColumns(o.sections(), max(1, h-4)) {
+		colW := 0
+		for _, sec := range col {
+			colW = max(colW, sec.width)
+		}
+		if x+colW > maxX {
+			break
+		}
+		y := 2
+		for i, sec := range col {
+			if i > 0 {
+				y++
+			}
+			if sec.group != "" && y < maxY {
+				b.DrawText(x, y, sec.group, o.GroupFg, o.Bg, goterm.StyleBold)
+				y++
+			}
+			for j := range sec.keys {
+				if y >= maxY {
+					break
+				}
+				b.DrawText(x, y, sec.keys[j], o.KeyFg, o.Bg, goterm.StyleNone)
+				b.DrawText(x+sec.keyWidth+2, y, sec.help[j], o.Fg, o.Bg, goterm.StyleNone)
+				y++
+			}
+		}
+		x += colW + helpColumnGap
+	}
+	drawBuffer(s, b)
+}
+
+// title returns the title shown on the border
+func (o *HelpOverlay) title() string {
+	if o.Title == "" {
+		return "Help"
+	}
+	return o.Title
+}
+
+// sections returns the bindings with help grouped for display
+func (o *HelpOverlay) sections() []helpSection {
+	if o.Keymap == nil {
+		return nil
+	}
+	var sections []helpSection
+	for _, group := range o.Keymap.Groups() {
+		sec := helpSection{group: group, width: goterm.StringWidth(group)}
+		for _, b := range o.Keymap.Bindings {
+			if b.Group != group || b.Help == "" {
+				continue
+			}
+			keys := b.KeysString()
+			sec.keys = append(sec.keys, keys)
+			sec.help = append(sec.help, b.Help)
+			sec.keyWidth = max(sec.keyWidth, goterm.StringWidth(keys))
+		}
+		for _, help := range sec.help {
+			sec.width = max(sec.width, sec.keyWidth+2+goterm.StringWidth(help))
+		}
+		sections = append(sections, sec)
+	}
+	return sections
+}
+
+// helpColumns splits sections into the fewest columns no taller than
+// maxRows, keeping each section whole and the columns about even
+// A section taller than maxRows gets a column of its own.
+func helpColumns(sections []helpSection, maxRows int) [][]helpSection {
+	total, tallest := 0, 0
+	for i, sec := range sections {
+		if i > 0 {
+			total++
+		}
+		total += sec.rows()
+		tallest = max(tallest, sec.rows())
+	}
+	for n := 1; n <= len(sections); n++ {
+		columns := packSections(sections, max(tallest, (total+n-1)/n))
+		if len(columns) <= n && columnsFit(columns, maxRows) {
+			return columns
+		}
+	}
+	return packSections(sections, maxRows)
+}
+
+// packSections fills columns with sections in order, starting a new column
+// when the next section would make the column taller than limit
+func packSections(sections []helpSection, limit int) [][]helpSection {
+	var columns [][]helpSection
+	rows := 0
+	for _, sec := range sections {
+		n := len(columns)
+		if n == 0 || rows+1+sec.rows() > limit {
+			columns = append(columns, []helpSection{sec})
+			rows = sec.rows()
+			continue
+		}
+		columns[n-1] = append(columns[n-1], sec)
+		rows += 1 + sec.rows()
+	}
+	return columns
+}
+
+// columnsFit reports whether no column is taller than maxRows
+func columnsFit(columns [][]helpSection, maxRows int) bool {
+	for _, col := range columns {
+		if columnRows(col) > maxRows {
+			return false
+		}
+	}
+	return true
+}
+
+// columnRows returns the rows a column of sections takes, with a blank row
+// between sections
+func columnRows(col []helpSection) int {
+	rows := 0
+	for i, sec := range col {
+		if i > 0 {
+			rows++
+		}
+		rows += sec.rows()
+	}
+	return rows
+}
@@ -0,0 +1,194 @@
+package widgets
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dshills/goterm"
+)
+
+// TokenKind classifies a token of source code for coloring
+// Highlighters may define kinds of their own from TokenCustom on.
+type TokenKind int
+
+// Token kinds produced by the built-in highlighters
+const (
+	TokenPlain TokenKind = iota
+	TokenKeyword
+	TokenType
+	TokenString
+	TokenNumber
+	TokenComment
+	TokenCustom // First kind free for highlighters to define
+)
+
+// Token is a run of source text of one kind
+type Token struct {
+	Text string
+	Kind TokenKind
+}
+
+// TokenStyle is how a kind of token is drawn
+// A default Fg or Bg leaves the CodeView's color in place.
+type TokenStyle struct {
+	Fg    goterm.Color
+	Bg    goterm.Color
+	Style goterm.Style
+}
+
+// DefaultTokenStyles returns the token styles used when a CodeView has none,
+// in the 16 ANSI colors so they follow the terminal's color scheme
+func DefaultTokenStyles() map[TokenKind]TokenStyle {
+	return map[TokenKind]TokenStyle{
+		TokenKeyword: {Fg: goterm.ColorMagenta, Style: goterm.StyleBold},
+		TokenType:    {Fg: goterm.ColorCyan},
+		TokenString:  {Fg: goterm.ColorGreen},
+		TokenNumber:  {Fg: goterm.ColorYellow},
+		TokenComment: {Fg: goterm.ColorIndex(8), Style: goterm.StyleItalic},
+	}
+}
+
+// Highlighter splits source code into tokens for a CodeView
+// It receives all lines at once, so constructs spanning lines such as block
+// comments can be recognized, and returns the tokens of each line; the
+// text of a line's tokens must add up to the line.
+type Highlighter interface {
+	Highlight(lines []string) [][]Token
+}
+
+// KeywordHighlighter is a Highlighter for C-like languages driven by word
+// lists and comment and string delimiters
+type KeywordHighlighter struct {
+	Keywords     []string
+	Types        []string
+	LineComment  string    // e.g. "//"; empty for none
+	BlockComment [2]string // Start and end, e.g. "/*" and "*/"; empty for none
+	Quotes       string    // Characters that start and end strings, e.g. `"'`
+}
+
+// GoHighlighter highlights Go source code
+var GoHighlighter = &KeywordHighlighter{
+	Keywords: []string{
+		"break", "case", "chan", "const", "continue", "default", "defer", "else",
+		"fallthrough", "for", "func", "go", "goto", "if", "import", "interface",
+		"map", "package", "range", "return", "select", "struct", "switch", "type",
+		"var", "true", "false", "nil", "iota",
+	},
+	Types: []string{
+		"any", "bool", "byte", "comparable", "complex64", "complex128", "error",
+		"float32", "float64", "int", "int8", "int16", "int32", "int64", "rune",
+		"string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+	},
+	LineComment:  "//",
+	BlockComment: [2]string{"/*", "*/"},
+	Quotes:       "\"'`",
+}
+
+// Highlight splits lines into keywords, types, strings, numbers, comments
+// and plain text
+// Strings end at the end of the line unless quoted with a backquote.
+func (h *KeywordHighlighter) Highlight(lines []string) [][]Token {
+	out := make([][]Token, len(lines))
+	var open string // Delimiter closing a block comment or raw string continued from the previous line
+	openKind := TokenPlain
+	for i, line := range lines {
+		var tokens []Token
+		add := func(text string, kind TokenKind) {
+			if text == "" {
+				return
+			}
+			if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+				tokens[n-1].Text += text
+				return
+			}
+			tokens = append(tokens, Token{Text: text, Kind: kind})
+		}
+
+		rest := line
+		if open != "" {
+			end := strings.Index(rest, open)
+			if end < 0 {
+				add(rest, openKind)
+				out[i] = tokens
+				continue
+			}
+			add(rest[:end+len(open)], openKind)
+			rest = rest[end+len(open):]
+			open = ""
+		}
+
+		for rest != "" {
+			r := []rune(rest)[0]
+			switch {
+			case h.LineComment != "" && strings.HasPrefix(rest, h.LineComment):
+				add(rest, TokenComment)
+				rest = ""
+			case h.BlockComment[0] != "" && strings.HasPrefix(rest, h.BlockComment[0]):
+				n := strings.Index(rest[len(h.BlockComment[0]):], h.BlockComment[1])
+				if n < 0 {
+					add(rest, TokenComment)
+					open, openKind, rest = h.BlockComment[1], TokenComment, ""
+					break
+				}
+				n += len(h.BlockComment[0]) + len(h.BlockComment[1])
+				add(rest[:n], TokenComment)
+				rest = rest[n:]
+			case strings.ContainsRune(h.Quotes, r):
+				n := stringEnd(rest, r)
+				if n < 0 && r == '`' {
+					open, openKind = "`", TokenString
+				}
+				if n < 0 {
+					n = len(rest)
+				}
+				add(rest[:n], TokenString)
+				rest = rest[n:]
+			case unicode.IsDigit(r):
+				n := len(rest) - len(strings.TrimLeftFunc(rest, func(r rune) bool {
+					return unicode.IsDigit(r) || unicode.IsLetter(r) || r == '.' || r == '_'
+				}))
+				add(rest[:n], TokenNumber)
+				rest = rest[n:]
+			case unicode.IsLetter(r) || r == '_':
+				n := len(rest) - len(strings.TrimLeftFunc(rest, isWordRune))
+				word := rest[:n]
+				switch {
+				case slices.Contains(h.Keywords, word):
+					add(word, TokenKeyword)
+				case slices.Contains(h.Types, word):
+					add(word, TokenType)
+				default:
+					add(word, TokenPlain)
+				}
+				rest = rest[n:]
+			default:
+				n := len(string(r))
+				add(rest[:n], TokenPlain)
+				rest = rest[n:]
+			}
+		}
+		out[i] = tokens
+	}
+	return out
+}
+
+// stringEnd returns the length of the string literal opened by quote at the
+// start of s, including both quotes, or -1 if it does not end on this line
+func stringEnd(s string, quote rune) int {
+	q := len(string(quote))
+	for i := q; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case strings.HasPrefix(s[i:], string(quote)):
+			return i + q
+		}
+	}
+	return -1
+}
+
+// isWordRune reports whether r can be part of an identifier
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
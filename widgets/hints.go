@@ -0,0 +1,60 @@
+package widgets
+
+import (
+	"math"
+
+	"github.com/dshills/goterm"
+)
+
+// KeyHints is a one-row strip of the main keys of a goterm.Keymap, such as
+// "q quit  ? help  / search", for the bottom of the screen or a StatusBar
+// The hints come from Keymap.Hints, so they change with the bindings. Hints
+// that do not fit are left out whole, from the end.
+type KeyHints struct {
+	Keymap    *goterm.Keymap
+	Separator string // Between hints; defaults to two spaces
+	Fg        goterm.Color
+	Bg        goterm.Color
+	KeyFg     goterm.Color // Color of the keys
+	KeyStyle  goterm.Style // Style of the keys
+}
+
+// Text returns the hints that fit in maxW columns as styled text, e.g. to
+// show in a StatusBar group
+// Each hint shows the first key of its binding.
+func (kh *KeyHints) Text(maxW int) goterm.StyledText {
+	if kh.Keymap == nil {
+		return nil
+	}
+	sep := kh.Separator
+	if sep == "" {
+		sep = "  "
+	}
+	var text goterm.StyledText
+	bindings, help := kh.Keymap.Hints()
+	for i, b := range bindings {
+		hint := goterm.StyledText{}.
+			Add(b.Keys[0].String(), kh.KeyFg, kh.Bg, kh.KeyStyle).
+			Add(" "+help[i], kh.Fg, kh.Bg, goterm.StyleNone)
+		if i > 0 {
+			hint = append(goterm.StyledText{}.Add(sep, kh.Fg, kh.Bg, goterm.StyleNone), hint...)
+		}
+		if text.Width()+hint.Width() > maxW {
+			break
+		}
+		text = append(text, hint...)
+	}
+	return text
+}
+
+// PreferredSize returns the size that shows every hint, one row high
+func (kh *KeyHints) PreferredSize(maxW, maxH int) (w, h int) {
+	return min(kh.Text(math.MaxInt).Width(), maxW), max(0, min(1, maxH))
+}
+
+// Draw draws the hints that fit onto the first row of s
+func (kh *KeyHints) Draw(s goterm.Surface) {
+	w, _ := s.Size()
+	fillRow(s, 0, 0, w, kh.Bg, goterm.StyleNone)
+	drawSpans(s, 0, 0, w, kh.Text(w))
+}
@@ -0,0 +1,96 @@
+package widgets
+
+import (
+	"math"
+
+	"github.com/dshills/goterm"
+)
+
+// Series is a named sequence of values plotted by a LineChart
+type Series struct {
+	Name   string // Shown in the legend when not empty
+	Values []float64
+	Color  goterm.Color
+}
+
+// LineChart plots series of values as lines of braille dots, giving 2×4
+// points of resolution per cell
+// Values are spread evenly across the width, the first value at the left
+// edge; NaN values leave a gap in the line. The y axis scales to fit the
+// data unless Min is less than Max.
+type LineChart struct {
+	Series   []Series
+	Min, Max float64 // Fixed y range; autoscaled unless Min < Max
+	Axes     bool    // Draw axes with the y range labeled
+	Legend   bool    // Draw the names of the series below the chart
+	Fg       goterm.Color
+	Bg       goterm.Color
+}
+
+// PreferredSize returns the size that gives every value of the longest
+// series a column of dots, with room for the axes and the legend, and all
+// of the height
+func (lc *LineChart) PreferredSize(maxW, maxH int) (w, h int) {
+	points := 0
+	for _, series := range lc.Series {
+		points = max(points, len(series.Values))
+	}
+	// Each cell holds two columns of dots
+	frame := lc.frame()
+	return frame.preferredSize((points+1)/2, maxW, maxH)
+}
+
+// frame returns the axes and legend of the chart
+func (lc *LineChart) frame() chartFrame {
+	var all []float64
+	for _, series := range lc.Series {
+		all = append(all, series.Values...)
+	}
+	frame := chartFrame{axes: lc.Axes, fg: lc.Fg, bg: lc.Bg}
+	frame.yMin, frame.yMax = dataRange(all, lc.Min, lc.Max)
+	if lc.Legend {
+		for _, series := range lc.Series {
+			frame.legend = append(frame.legend, legendEntry{name: series.Name, color: series.Color})
+		}
+	}
+	return frame
+}
+
+// Draw draws the chart onto s
+func (lc *LineChart) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	frame := lc.frame()
+
+	fillRect(s, w, h, lc.Bg)
+	frame.draw(s, w, h)
+	plot := frame.plotRect(w, h)
+	if plot.Empty() {
+		return
+	}
+
+	canvas := NewCanvas(plot.W, plot.H)
+	canvas.Bg = lc.Bg
+	dotsW, dotsH := canvas.Size()
+	for _, series := range lc.Series {
+		n := len(series.Values)
+		prevX, prevY, prev := 0, 0, false
+		for i, v := range series.Values {
+			if math.IsNaN(v) {
+				prev = false
+				continue
+			}
+			x := 0
+			if n > 1 {
+				x = scale(float64(i), 0, float64(n-1), dotsW)
+			}
+			y := dotsH - 1 - scale(max(frame.yMin, min(frame.yMax, v)), frame.yMin, frame.yMax, dotsH)
+			if prev {
+				canvas.Line(prevX, prevY, x, y, series.Color)
+			} else {
+				canvas.Set(x, y, series.Color)
+			}
+			prevX, prevY, prev = x, y, true
+		}
+	}
+	canvas.drawAt(s, plot.X, plot.Y)
+}
@@ -0,0 +1,304 @@
+package widgets
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dshills/goterm"
+)
+
+// ListItemState describes how a list item is displayed
+type ListItemState struct {
+	Current bool // The cursor is on the item
+	Marked  bool // The item is selected in a multi-select list
+	Focused bool // The list has the focus, or is not managed by a FocusManager
+}
+
+// List is a scrollable list of items with a cursor, such as a menu or file
+// picker
+// The cursor is moved with the arrow keys, Home, End, Page Up and Page Down
+// (and j and k unless TypeToFilter is set) or by clicking an item; Enter or
+// clicking the current item again calls OnSelect. In a multi-select list
+// Space or Insert toggles the mark of the current item, as does clicking
+// an item. Only items containing Filter, ignoring case, are shown. The
+// current item is always highlighted, dimmed while a FocusManager has given
+// the focus to another widget.
+type List struct {
+	focusState
+
+	Items        []string
+	MultiSelect  bool   // Allow marking several items
+	Filter       string // Show only items containing this text
+	TypeToFilter bool   // Typing edits Filter; Backspace removes a character
+	OnSelect     func(index int)
+
+	// Render returns the text of an item; nil draws the item text with the
+	// list colors, the current item highlighted and marks as "[x] "
+	Render func(index int, item string, state ListItemState) goterm.StyledText
+
+	Fg        goterm.Color
+	Bg        goterm.Color
+	CurrentFg goterm.Color // Colors of the current item; reverse video if both are default
+	CurrentBg goterm.Color
+
+	cursor int          // Index into Items
+	offset int          // First visible row
+	height int          // Rows at the last Draw
+	marked map[int]bool // Marked item indexes
+}
+
+// Cursor returns the index in Items of the current item, or -1 if no item
+// is shown
+func (l *List) Cursor() int {
+	visible := l.Visible()
+	if len(visible) == 0 {
+		return -1
+	}
+	return visible[l.cursorRow(visible)]
+}
+
+// SetCursor moves the cursor to Items[index]
+func (l *List) SetCursor(index int) {
+	if index >= 0 && index < len(l.Items) {
+		l.cursor = index
+	}
+}
+
+// Visible returns the indexes in Items of the items passing Filter
+func (l *List) Visible() []int {
+	query := strings.ToLower(l.Filter)
+	visible := make([]int, 0, len(l.Items))
+	for i, item := range l.Items {
+		if query == "" || strings.Contains(strings.ToLower(item), query) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// Marked returns the indexes in Items of the marked items in ascending order
+func (l *List) Marked() []int {
+	var marked []int
+	for i := range l.marked {
+		if i < len(l.Items) {
+			marked = append(marked, i)
+		}
+	}
+	slices.Sort(marked)
+	return marked
+}
+
+// SetMarked marks or unmarks Items[index]
+func (l *List) SetMarked(index int, marked bool) {
+	if !marked {
+		delete(l.marked, index)
+		return
+	}
+	if l.marked == nil {
+		l.marked = make(map[int]bool)
+	}
+	l.marked[index] = true
+}
+
+// HandleEvent moves the cursor, marks or selects items and edits the filter
+// in response to ev
+func (l *List) HandleEvent(ev goterm.Event) bool {
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		return l.handleKey(ev)
+	case goterm.MouseEvent:
+		return l.handleMouse(ev)
+	}
+	return false
+}
+
+// handleKey handles a key press
+func (l *List) handleKey(ev goterm.KeyEvent) bool {
+	page := max(1, l.height-1)
+	switch ev.Key {
+	case goterm.KeyUp:
+		l.move(-1)
+	case goterm.KeyDown:
+		l.move(1)
+	case goterm.KeyPageUp:
+		l.move(-page)
+	case goterm.KeyPageDown:
+		l.move(page)
+	case goterm.KeyHome:
+		l.move(-len(l.Items))
+	case goterm.KeyEnd:
+		l.move(len(l.Items))
+	case goterm.KeyEnter:
+		l.selectCurrent()
+	case goterm.KeyInsert:
+		l.toggleCurrent()
+	case goterm.KeyBackspace:
+		if !l.TypeToFilter || l.Filter == "" {
+			return false
+		}
+		runes := []rune(l.Filter)
+		l.Filter = string(runes[:len(runes)-1])
+	case goterm.KeyRune:
+		if ev.Modifiers&(goterm.ModCtrl|goterm.ModAlt) != 0 {
+			return false
+		}
+		return l.handleRune(ev.Rune)
+	default:
+		return false
+	}
+	return true
+}
+
+// handleRune handles a character key
+func (l *List) handleRune(r rune) bool {
+	switch {
+	case l.TypeToFilter:
+		l.Filter += string(r)
+	case r == ' ' && l.MultiSelect:
+		l.toggleCurrent()
+	case r == 'k':
+		l.move(-1)
+	case r == 'j':
+		l.move(1)
+	default:
+		return false
+	}
+	return true
+}
+
+// handleMouse handles a click or wheel scroll
+func (l *List) handleMouse(ev goterm.MouseEvent) bool {
+	switch {
+	case ev.Button == goterm.MouseWheelUp:
+		l.move(-3)
+		return true
+	case ev.Button == goterm.MouseWheelDown:
+		l.move(3)
+		return true
+	case ev.Button != goterm.MouseLeft || ev.Action != goterm.MousePress:
+		return false
+	}
+
+	visible := l.Visible()
+	row := l.offset + ev.Y
+	if ev.Y < 0 || row >= len(visible) || (l.height > 0 && ev.Y >= l.height) {
+		return false
+	}
+	if visible[row] == l.Cursor() && !l.MultiSelect {
+		l.selectCurrent()
+		return true
+	}
+	l.cursor = visible[row]
+	if l.MultiSelect {
+		l.toggleCurrent()
+	}
+	return true
+}
+
+// move moves the cursor by delta visible items, stopping at either end
+func (l *List) move(delta int) {
+	visible := l.Visible()
+	if len(visible) == 0 {
+		return
+	}
+	row := max(0, min(len(visible)-1, l.cursorRow(visible)+delta))
+	l.cursor = visible[row]
+}
+
+// toggleCurrent toggles the mark of the current item in a multi-select list
+func (l *List) toggleCurrent() {
+	if i := l.Cursor(); i >= 0 && l.MultiSelect {
+		l.SetMarked(i, !l.marked[i])
+	}
+}
+
+// selectCurrent calls OnSelect for the current item
+func (l *List) selectCurrent() {
+	if i := l.Cursor(); i >= 0 && l.OnSelect != nil {
+		l.OnSelect(i)
+	}
+}
+
+// cursorRow returns the position of the cursor in visible, or 0 if the
+// current item is filtered out
+func (l *List) cursorRow(visible []int) int {
+	if row, ok := slices.BinarySearch(visible, l.cursor); ok {
+		return row
+	}
+	return 0
+}
+
+// PreferredSize returns the size that shows every visible item in full
+func (l *List) PreferredSize(maxW, maxH int) (w, h int) {
+	visible := l.Visible()
+	for _, i := range visible {
+		w = max(w, l.render(i, ListItemState{}).Width())
+	}
+	return max(0, min(w, maxW)), max(0, min(len(visible), maxH))
+}
+
+// Draw draws the visible items onto s, one per row, scrolled to keep the
+// cursor in view
+func (l *List) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	l.height = h
+	visible := l.Visible()
+
+	// Scroll just enough to show the cursor
+	cur := l.cursorRow(visible)
+	l.offset = max(0, min(l.offset, cur, len(visible)-h))
+	if cur >= l.offset+h {
+		l.offset = cur - h + 1
+	}
+
+	for y := 0; y < h; y++ {
+		row := l.offset + y
+		if row >= len(visible) {
+			fillRow(s, 0, y, w, l.Bg, goterm.StyleNone)
+			continue
+		}
+		i := visible[row]
+		state := ListItemState{Current: row == cur, Marked: l.marked[i], Focused: l.showsFocus()}
+		bg, style := l.Bg, goterm.StyleNone
+		if state.Current {
+			bg, style = l.currentColors(state.Focused)
+		}
+		x := drawSpans(s, 0, y, w, l.render(i, state))
+		fillRow(s, x, y, w, bg, style)
+	}
+}
+
+// render returns the text of Items[i]
+func (l *List) render(i int, state ListItemState) goterm.StyledText {
+	if l.Render != nil {
+		return l.Render(i, l.Items[i], state)
+	}
+
+	fg, bg, style := l.Fg, l.Bg, goterm.StyleNone
+	if state.Current {
+		fg = l.CurrentFg
+		bg, style = l.currentColors(state.Focused)
+	}
+	var text goterm.StyledText
+	if l.MultiSelect {
+		mark := "[ ] "
+		if state.Marked {
+			mark = "[x] "
+		}
+		text = text.Add(mark, fg, bg, style)
+	}
+	return text.Add(l.Items[i], fg, bg, style)
+}
+
+// currentColors returns the background and style of the current item,
+// dimmed unless focused
+func (l *List) currentColors(focused bool) (goterm.Color, goterm.Style) {
+	style := goterm.StyleNone
+	if l.CurrentFg == goterm.ColorDefault() && l.CurrentBg == goterm.ColorDefault() {
+		style = goterm.StyleReverse
+	}
+	if !focused {
+		style = style.Set(goterm.StyleDim)
+	}
+	return l.CurrentBg, style
+}
@@ -0,0 +1,386 @@
+package widgets
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/dshills/goterm"
+)
+
+// LogLevel is the severity of a line of log output
+type LogLevel int
+
+// Log levels, from least to most severe
+const (
+	LogNone LogLevel = iota // No level recognized
+	LogDebug
+	LogInfo
+	LogWarn
+	LogError
+)
+
+// DefaultLogColors returns the level colors used when a LogView has none
+// Info and unrecognized lines keep the view's own color.
+func DefaultLogColors() map[LogLevel]goterm.Color {
+	return map[LogLevel]goterm.Color{
+		LogDebug: goterm.ColorIndex(8),
+		LogWarn:  goterm.ColorYellow,
+		LogError: goterm.ColorRed,
+	}
+}
+
+// ParseLogLevel returns the level named by one of the first few words of
+// line, such as "ERROR", "[warn]" or "level=info", or LogNone
+func ParseLogLevel(line string) LogLevel {
+	words := strings.FieldsFunc(line, func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range words[:min(len(words), 5)] {
+		switch strings.ToLower(word) {
+		case "trace", "debug", "dbg":
+			return LogDebug
+		case "info", "inf", "notice":
+			return LogInfo
+		case "warn", "warning", "wrn":
+			return LogWarn
+		case "error", "err", "fatal", "panic", "crit", "critical":
+			return LogError
+		}
+	}
+	return LogNone
+}
+
+// DefaultLogLines is the number of lines a LogView keeps when MaxLines is 0
+const DefaultLogLines = 10000
+
+// LogView shows a growing log, keeping the newest lines and following the
+// end as lines are added
+// Unlike other widgets, a LogView is safe for concurrent use: lines may be
+// appended from any goroutine while the interface goroutine draws it. It
+// also implements io.Writer, so it can receive the output of a log.Logger.
+// Once MaxLines lines are held, each new line drops the oldest one.
+//
+// The view follows the end of the log until it is scrolled up, and follows
+// again once scrolled back to the end. The arrow keys, j and k scroll by a
+// line, Page Up and Page Down by a page, Home and g jump to the start, and
+// End and G to the end; the mouse wheel scrolls too. n and N repeat the
+// last Search forward and backward, and Escape clears its highlighting.
+type LogView struct {
+	focusState
+
+	MaxLines int                       // Lines kept; 0 for DefaultLogLines
+	Colors   map[LogLevel]goterm.Color // Text color of each level; nil uses DefaultLogColors
+	Fg       goterm.Color
+	Bg       goterm.Color
+	MatchFg  goterm.Color // Colors of search matches; reverse video if both are default
+	MatchBg  goterm.Color
+	OnAppend func() // Called after lines are added, e.g. to schedule a redraw; may run on any goroutine
+
+	mu       sync.Mutex
+	ring     []logLine // Lines held, oldest at start
+	start    int       // Index in ring of the oldest line
+	partial  []byte    // Text written without a final newline
+	top      int       // First line shown
+	height   int       // Rows at the last Draw
+	paused   bool      // Scrolled away from the end
+	query    []rune    // Lower-cased text of the last search
+	backward bool      // The last search went backward
+}
+
+// logLine is a line held by a LogView
+type logLine struct {
+	text  string
+	level LogLevel
+}
+
+// Append adds lines to the end of the log, taking the level of each from
+// its text with ParseLogLevel
+func (l *LogView) Append(lines ...string) {
+	l.mu.Lock()
+	for _, line := range lines {
+		l.add(line, ParseLogLevel(line))
+	}
+	l.mu.Unlock()
+	l.appended()
+}
+
+// AppendLevel adds a line to the end of the log with the given level
+func (l *LogView) AppendLevel(level LogLevel, line string) {
+	l.mu.Lock()
+	l.add(line, level)
+	l.mu.Unlock()
+	l.appended()
+}
+
+// Write adds the complete lines in p to the log as Append does
+// Text after the last newline is held until the rest of its line arrives.
+func (l *LogView) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	l.partial = append(l.partial, p...)
+	text := string(l.partial)
+	end := strings.LastIndexByte(text, '\n')
+	if end < 0 {
+		l.mu.Unlock()
+		return len(p), nil
+	}
+	l.partial = append(l.partial[:0], text[end+1:]...)
+	for _, line := range strings.Split(text[:end], "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		l.add(line, ParseLogLevel(line))
+	}
+	l.mu.Unlock()
+	l.appended()
+	return len(p), nil
+}
+
+// Clear removes all lines and follows the end again
+func (l *LogView) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ring, l.start, l.partial = nil, 0, nil
+	l.top, l.paused = 0, false
+}
+
+// Len returns the number of lines held
+func (l *LogView) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.ring)
+}
+
+// Line returns line i, counting from the oldest line held
+func (l *LogView) Line(i int) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.line(i).text
+}
+
+// Top returns the index of the first line shown
+func (l *LogView) Top() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.top
+}
+
+// Following reports whether the view follows the end of the log
+func (l *LogView) Following() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.paused
+}
+
+// SetFollow starts or stops following the end of the log
+// Following scrolls to the end at once.
+func (l *LogView) SetFollow(follow bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.paused = !follow
+	l.clamp()
+}
+
+// ScrollTo scrolls so that line is at the top, as far as the log allows
+// Scrolling away from the end stops following it, and scrolling back to
+// the end follows it again.
+func (l *LogView) ScrollTo(line int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scrollTo(line)
+}
+
+// ScrollBy scrolls by delta lines as ScrollTo does
+func (l *LogView) ScrollBy(delta int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scrollTo(l.top + delta)
+}
+
+// Search scrolls to the next line containing query, ignoring case, and
+// highlights its matches, stopping following the end
+// The search starts after the top line, or before it if backward is set,
+// and reports whether a match was found; the view does not move if not.
+func (l *LogView) Search(query string, backward bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.query = lowerRunes(query)
+	l.backward = backward
+	return l.next(backward)
+}
+
+// HandleEvent scrolls and repeats searches in response to ev
+func (l *LogView) HandleEvent(ev goterm.Event) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	page := max(1, l.height)
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		var r rune
+		if ev.Key == goterm.KeyRune && ev.Modifiers&(goterm.ModCtrl|goterm.ModAlt) == 0 {
+			r = ev.Rune
+		}
+		switch {
+		case ev.Key == goterm.KeyUp || r == 'k':
+			l.scrollTo(l.top - 1)
+		case ev.Key == goterm.KeyDown || r == 'j':
+			l.scrollTo(l.top + 1)
+		case ev.Key == goterm.KeyPageUp:
+			l.scrollTo(l.top - page)
+		case ev.Key == goterm.KeyPageDown:
+			l.scrollTo(l.top + page)
+		case ev.Key == goterm.KeyHome || r == 'g':
+			l.scrollTo(0)
+		case ev.Key == goterm.KeyEnd || r == 'G':
+			l.scrollTo(len(l.ring))
+		case r == 'n':
+			l.next(l.backward)
+		case r == 'N':
+			l.next(!l.backward)
+		case ev.Key == goterm.KeyEscape && l.query != nil:
+			l.query = nil
+		default:
+			return false
+		}
+		return true
+	case goterm.MouseEvent:
+		switch ev.Button {
+		case goterm.MouseWheelUp:
+			l.scrollTo(l.top - 3)
+		case goterm.MouseWheelDown:
+			l.scrollTo(l.top + 3)
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// PreferredSize returns the size that shows every line held in full
+func (l *LogView) PreferredSize(maxW, maxH int) (w, h int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, line := range l.ring {
+		w = max(w, goterm.StringWidth(line.text))
+	}
+	return max(0, min(w, maxW)), max(0, min(len(l.ring), maxH))
+}
+
+// Draw draws the lines in view onto s
+func (l *LogView) Draw(s goterm.Surface) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w, h := s.Size()
+	l.height = h
+	l.clamp()
+
+	colors := l.Colors
+	if colors == nil {
+		colors = DefaultLogColors()
+	}
+	for y := 0; y < h; y++ {
+		x := 0
+		if i := l.top + y; i < len(l.ring) {
+			x = drawSpans(s, 0, y, w, l.highlight(l.line(i), colors))
+		}
+		fillRow(s, x, y, w, l.Bg, goterm.StyleNone)
+	}
+}
+
+// add appends a line, dropping the oldest once the log is full
+func (l *LogView) add(text string, level LogLevel) {
+	limit := l.MaxLines
+	if limit <= 0 {
+		limit = DefaultLogLines
+	}
+	if len(l.ring) > limit {
+		// MaxLines was lowered; keep the newest lines in order
+		dropped := len(l.ring) - limit
+		l.ring = append(l.ring[l.start:], l.ring[:l.start]...)[dropped:]
+		l.start = 0
+		l.top -= dropped
+	}
+	line := logLine{text: strings.ReplaceAll(text, "\t", "    "), level: level}
+	if len(l.ring) < limit {
+		l.ring = append(l.ring, line)
+	} else {
+		l.ring[l.start] = line
+		l.start = (l.start + 1) % len(l.ring)
+		l.top--
+	}
+	l.clamp()
+}
+
+// appended calls OnAppend
+func (l *LogView) appended() {
+	if l.OnAppend != nil {
+		l.OnAppend()
+	}
+}
+
+// line returns line i, counting from the oldest line held
+func (l *LogView) line(i int) logLine {
+	return l.ring[(l.start+i)%len(l.ring)]
+}
+
+// scrollTo scrolls so that line is at the top, following the end if it is
+// reached
+func (l *LogView) scrollTo(line int) {
+	l.paused = true
+	l.top = line
+	l.clamp()
+	l.paused = l.top < len(l.ring)-l.height
+}
+
+// clamp keeps the top line within the log, at the end when following
+func (l *LogView) clamp() {
+	bottom := max(0, len(l.ring)-l.height)
+	if !l.paused {
+		l.top = bottom
+	}
+	l.top = max(0, min(l.top, bottom))
+}
+
+// next scrolls to the next line matching the last search
+func (l *LogView) next(backward bool) bool {
+	if len(l.query) == 0 {
+		return false
+	}
+	step := 1
+	if backward {
+		step = -1
+	}
+	for i := l.top + step; i >= 0 && i < len(l.ring); i += step {
+		if indexRunes(lowerRunes(l.line(i).text), l.query, 0) >= 0 {
+			l.paused = true
+			l.top = i
+			l.clamp()
+			return true
+		}
+	}
+	return false
+}
+
+// highlight returns line in its level's color with search matches marked
+func (l *LogView) highlight(line logLine, colors map[LogLevel]goterm.Color) goterm.StyledText {
+	def := goterm.ColorDefault()
+	fg, ok := colors[line.level]
+	if !ok || fg == def {
+		fg = l.Fg
+	}
+	text := goterm.StyledText{}.Add(line.text, fg, l.Bg, goterm.StyleNone)
+	if len(l.query) == 0 {
+		return text
+	}
+
+	plain := lowerRunes(line.text)
+	reverse := l.MatchFg == def && l.MatchBg == def
+	for start := indexRunes(plain, l.query, 0); start >= 0; start = indexRunes(plain, l.query, start+len(l.query)) {
+		text = restyleRunes(text, start, start+len(l.query), func(span goterm.Span) goterm.Span {
+			if reverse {
+				span.Style = span.Style.Set(goterm.StyleReverse)
+			} else {
+				span.Fg, span.Bg = l.MatchFg, l.MatchBg
+			}
+			return span
+		})
+	}
+	return text
+}
@@ -0,0 +1,551 @@
+package widgets
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dshills/goterm"
+)
+
+// RenderMarkdown renders Markdown text into a buffer width columns wide and
+// as tall as the text needs, for help screens and READMEs
+// The subset understood covers ATX headings (# to ######), paragraphs,
+// *emphasis*, **strong**, ~~strikethrough~~, `code`, [links](url) and
+// <autolinks>, bulleted and numbered lists (nested by indentation), fenced
+// code blocks, block quotes and horizontal rules. Paragraphs are wrapped to
+// width and code blocks are cut off at it. Colors come from theme; links
+// are underlined and carry their URL (see goterm.Screen.SetLink), so
+// terminals with OSC 8 support open them on click. Show the buffer in a
+// Viewport to scroll through it.
+func RenderMarkdown(src string, width int, theme goterm.Theme) *goterm.Buffer {
+	width = max(1, width)
+	m := mdRenderer{theme: theme}
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	rows := m.blocks(lines, width, false)
+
+	b := goterm.NewBuffer(width, max(1, len(rows)))
+	b.Fill(0, 0, width, max(1, len(rows)), goterm.NewCell(' ', theme.Text, theme.Background, goterm.StyleNone))
+	for y, row := range rows {
+		x := 0
+		for _, span := range row {
+			b.DrawText(x, y, span.text, span.fg, span.bg, span.style)
+			w := goterm.StringWidth(span.text)
+			if span.link != "" {
+				b.SetLink(goterm.Rect{X: x, Y: y, W: w, H: 1}, span.link)
+			}
+			x += w
+		}
+	}
+	return b
+}
+
+// mdStyle is the look of a run of rendered Markdown
+type mdStyle struct {
+	fg, bg goterm.Color
+	style  goterm.Style
+	link   string
+}
+
+// mdSpan is a run of rendered Markdown text in one style
+type mdSpan struct {
+	text string
+	mdStyle
+}
+
+// mdRow is one rendered row
+type mdRow []mdSpan
+
+// mdRenderer renders Markdown blocks to rows with the colors of a theme
+type mdRenderer struct {
+	theme goterm.Theme
+}
+
+// text returns the style of regular text
+func (m *mdRenderer) text() mdStyle {
+	return mdStyle{fg: m.theme.Text, bg: m.theme.Background}
+}
+
+// blocks renders lines as a sequence of blocks width columns wide
+// Blocks are separated by a blank row unless tight is set, as for the
+// contents of a list item.
+func (m *mdRenderer) blocks(lines []string, width int, tight bool) []mdRow {
+	var rows []mdRow
+	add := func(block []mdRow) {
+		if len(rows) > 0 && !tight {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, block...)
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+		case mdFence(trimmed) != "":
+			var block []mdRow
+			block, i = m.codeBlock(lines, i, width)
+			add(block)
+		case mdHeading(trimmed) > 0:
+			add(m.heading(trimmed, width))
+			i++
+		case mdRule(trimmed):
+			add([]mdRow{{{text: strings.Repeat("─", width), mdStyle: mdStyle{fg: m.theme.Border, bg: m.theme.Background}}}})
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var block []mdRow
+			block, i = m.quote(lines, i, width)
+			add(block)
+		case mdListMarker(line) != "":
+			var block []mdRow
+			block, i = m.list(lines, i, width)
+			add(block)
+		default:
+			start := i
+			for i < len(lines) && !mdBlockStart(lines[i]) {
+				i++
+			}
+			add(m.paragraph(strings.Join(lines[start:i], " "), width, m.text()))
+		}
+	}
+	return rows
+}
+
+// codeBlock renders the fenced code block starting at lines[i] and returns
+// the index of the line after it
+func (m *mdRenderer) codeBlock(lines []string, i, width int) ([]mdRow, int) {
+	fence := mdFence(strings.TrimSpace(lines[i]))
+	style := mdStyle{fg: m.theme.Accent, bg: m.theme.Surface}
+	var rows []mdRow
+	for i++; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+			i++
+			break
+		}
+		text := goterm.Truncate("  "+strings.ReplaceAll(lines[i], "\t", "    "), width, "")
+		pad := strings.Repeat(" ", width-goterm.StringWidth(text))
+		rows = append(rows, mdRow{{text: text + pad, mdStyle: style}})
+	}
+	return rows, i
+}
+
+// heading renders an ATX heading
+func (m *mdRenderer) heading(line string, width int) []mdRow {
+	level := mdHeading(line)
+	text := strings.TrimSpace(line[level:])
+	if closed := strings.TrimRight(text, "#"); closed == "" || strings.HasSuffix(closed, " ") {
+		text = strings.TrimSpace(closed)
+	}
+	style := mdStyle{fg: m.theme.Primary, bg: m.theme.Background, style: goterm.StyleBold}
+	switch {
+	case level == 1:
+		style.style |= goterm.StyleUnderline
+	case level > 2:
+		style.fg = m.theme.Secondary
+	}
+	return m.paragraph(text, width, style)
+}
+
+// quote renders the block quote starting at lines[i] and returns the index
+// of the line after it
+func (m *mdRenderer) quote(lines []string, i, width int) ([]mdRow, int) {
+	var inner []string
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		inner = append(inner, strings.TrimPrefix(trimmed[1:], " "))
+	}
+
+	q := mdRenderer{theme: m.theme}
+	q.theme.Text = m.theme.Muted
+	bar := mdSpan{text: "│ ", mdStyle: mdStyle{fg: m.theme.Border, bg: m.theme.Background}}
+	rows := q.blocks(inner, max(1, width-2), false)
+	for y, row := range rows {
+		for j := range row {
+			row[j].style |= goterm.StyleItalic
+		}
+		rows[y] = append(mdRow{bar}, row...)
+	}
+	return rows, i
+}
+
+// list renders the list starting at lines[i] and returns the index of the
+// line after it
+// Lines indented at least as far as an item's text belong to the item, so
+// nested lists and further paragraphs are rendered inside it.
+func (m *mdRenderer) list(lines []string, i, width int) ([]mdRow, int) {
+	indent := mdIndent(lines[i])
+	var rows []mdRow
+	number, _ := strconv.Atoi(strings.TrimRight(mdListMarker(lines[i]), ".)"))
+	number--
+	for i < len(lines) {
+		line := lines[i]
+		marker := mdListMarker(line)
+		if marker == "" || mdIndent(line) != indent {
+			break
+		}
+		number++
+
+		// The item's own text, then lines that are indented past the marker,
+		// blank lines followed by such lines, or lazy continuations
+		contentIndent := indent + len(marker) + 1
+		content := []string{strings.TrimSpace(line[indent+len(marker):])}
+		for i++; i < len(lines); i++ {
+			next := lines[i]
+			switch {
+			case strings.TrimSpace(next) == "":
+				if i+1 < len(lines) && mdIndent(lines[i+1]) >= contentIndent && strings.TrimSpace(lines[i+1]) != "" {
+					content = append(content, "")
+					continue
+				}
+			case mdIndent(next) >= contentIndent:
+				content = append(content, next[contentIndent:])
+				continue
+			case !mdBlockStart(next):
+				content = append(content, strings.TrimSpace(next))
+				continue
+			}
+			break
+		}
+
+		bullet := "• "
+		if marker[0] >= '0' && marker[0] <= '9' {
+			bullet = strconv.Itoa(number) + marker[len(marker)-1:] + " "
+		}
+		bw := goterm.StringWidth(bullet)
+		item := m.blocks(content, max(1, width-bw), true)
+		if len(item) == 0 {
+			item = []mdRow{nil}
+		}
+		for y, row := range item {
+			prefix := mdSpan{text: strings.Repeat(" ", bw), mdStyle: m.text()}
+			if y == 0 {
+				prefix = mdSpan{text: bullet, mdStyle: mdStyle{fg: m.theme.Primary, bg: m.theme.Background}}
+			}
+			rows = append(rows, append(mdRow{prefix}, row...))
+		}
+	}
+	return rows, i
+}
+
+// paragraph renders text with inline formatting, wrapped to width
+func (m *mdRenderer) paragraph(text string, width int, base mdStyle) []mdRow {
+	words := mdWords(m.inline(text, base))
+	var rows []mdRow
+	var row mdRow
+	used := 0
+	for _, word := range words {
+		ww := mdWidth(word)
+		if used > 0 && used+1+ww > width {
+			rows = append(rows, row)
+			row, used = nil, 0
+		}
+		if used > 0 {
+			// The space takes the style of the text around it inside a link
+			// or styled run, and the base style elsewhere
+			space := mdSpan{text: " ", mdStyle: base}
+			if prev := row[len(row)-1]; prev.mdStyle == word[0].mdStyle {
+				space.mdStyle = prev.mdStyle
+			}
+			row = append(row, space)
+			used++
+		}
+		for ww > width-used {
+			// Break words too long for a row of their own
+			head, tail := mdSplit(word, width-used)
+			row = append(row, head...)
+			rows = append(rows, row)
+			row, used, word = nil, 0, tail
+			ww = mdWidth(word)
+		}
+		row = append(row, word...)
+		used += ww
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// inline parses emphasis, code spans and links in text
+func (m *mdRenderer) inline(text string, base mdStyle) []mdSpan {
+	var spans []mdSpan
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			spans = append(spans, mdSpan{text: plain.String(), mdStyle: base})
+			plain.Reset()
+		}
+	}
+	nested := func(inner string, style mdStyle) {
+		flush()
+		spans = append(spans, m.inline(inner, style)...)
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune(mdPunctuation, rune(rest[1])):
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '`':
+			run := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := strings.Index(rest[run:], rest[:run]); end >= 0 {
+				flush()
+				code := strings.TrimSpace(rest[run : run+end])
+				spans = append(spans, mdSpan{text: code, mdStyle: mdStyle{fg: m.theme.Accent, bg: base.bg, style: base.style, link: base.link}})
+				i += 2*run + end
+				continue
+			}
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := mdClose(rest[2:], rest[:2]); end > 0 {
+				style := base
+				style.style |= goterm.StyleBold
+				nested(rest[2:2+end], style)
+				i += 4 + end
+				continue
+			}
+		case strings.HasPrefix(rest, "~~"):
+			if end := mdClose(rest[2:], "~~"); end > 0 {
+				style := base
+				style.style |= goterm.StyleStrikethrough
+				nested(rest[2:2+end], style)
+				i += 4 + end
+				continue
+			}
+		case rest[0] == '*' || (rest[0] == '_' && (i == 0 || !mdWordChar(text[i-1]))):
+			if end := mdClose(rest[1:], rest[:1]); end > 0 {
+				style := base
+				style.style |= goterm.StyleItalic
+				nested(rest[1:1+end], style)
+				i += 2 + end
+				continue
+			}
+		case rest[0] == '[' || strings.HasPrefix(rest, "!["):
+			image := rest[0] == '!'
+			label, url, n := mdLink(rest[boolInt(image):])
+			if n > 0 {
+				style := base
+				style.fg, style.style, style.link = m.theme.Primary, base.style|goterm.StyleUnderline, url
+				if image {
+					label = "[" + label + "]"
+				}
+				nested(label, style)
+				i += boolInt(image) + n
+				continue
+			}
+		case rest[0] == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 && mdAutolink(rest[1:end]) {
+				flush()
+				url := rest[1:end]
+				style := base
+				style.fg, style.style, style.link = m.theme.Primary, base.style|goterm.StyleUnderline, url
+				spans = append(spans, mdSpan{text: strings.TrimPrefix(url, "mailto:"), mdStyle: style})
+				i += end + 1
+				continue
+			}
+		}
+		plain.WriteByte(rest[0])
+		i++
+	}
+	flush()
+	return spans
+}
+
+// mdPunctuation are the characters a backslash escapes
+const mdPunctuation = "\\`*_{}[]()#+-.!~<>|"
+
+// mdClose returns the index in s of the delimiter closing an emphasis, or
+// -1; the emphasized text may not be empty or start with a space
+func mdClose(s, delim string) int {
+	if s == "" || s[0] == ' ' {
+		return -1
+	}
+	for i := 1; i+len(delim) <= len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], delim) && s[i-1] != ' ' {
+			// A single delimiter must not be the start of a double one
+			if len(delim) == 1 && strings.HasPrefix(s[i+1:], delim) {
+				i++
+				continue
+			}
+			if delim == "_" && i+1 < len(s) && mdWordChar(s[i+1]) {
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// mdLink parses "[label](url)" at the start of s and returns its parts and
+// length, or a length of 0
+func mdLink(s string) (label, url string, n int) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if !strings.HasPrefix(s[i+1:], "(") {
+				return "", "", 0
+			}
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0
+			}
+			target := strings.Fields(s[i+2 : i+2+end])
+			if len(target) == 0 {
+				return "", "", 0
+			}
+			return s[1:i], strings.Trim(target[0], "<>"), i + 3 + end
+		}
+	}
+	return "", "", 0
+}
+
+// mdAutolink reports whether s is a URL for an <autolink>
+func mdAutolink(s string) bool {
+	if strings.ContainsAny(s, " <") {
+		return false
+	}
+	for _, scheme := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(s, scheme) && len(s) > len(scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// mdWordChar reports whether c is part of a word, where _ does not start
+// or end emphasis
+func mdWordChar(c byte) bool {
+	return c == '_' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// mdWords splits spans at spaces into words, each of which may be made of
+// several spans
+func mdWords(spans []mdSpan) [][]mdSpan {
+	var words [][]mdSpan
+	var word []mdSpan
+	for _, span := range spans {
+		for i, part := range strings.Split(span.text, " ") {
+			if i > 0 && len(word) > 0 {
+				words = append(words, word)
+				word = nil
+			}
+			if part != "" {
+				word = append(word, mdSpan{text: part, mdStyle: span.mdStyle})
+			}
+		}
+	}
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+	return words
+}
+
+// mdWidth returns the width of a word in columns
+func mdWidth(word []mdSpan) int {
+	w := 0
+	for _, span := range word {
+		w += goterm.StringWidth(span.text)
+	}
+	return w
+}
+
+// mdSplit splits word after n columns, keeping at least one character in
+// the head
+func mdSplit(word []mdSpan, n int) (head, tail []mdSpan) {
+	for i, span := range word {
+		w := goterm.StringWidth(span.text)
+		if w <= n {
+			head = append(head, span)
+			n -= w
+			continue
+		}
+		cut := goterm.Truncate(span.text, n, "")
+		if cut == "" && len(head) == 0 {
+			cut = string([]rune(span.text)[:1])
+		}
+		if cut != "" {
+			head = append(head, mdSpan{text: cut, mdStyle: span.mdStyle})
+		}
+		tail = append([]mdSpan{{text: span.text[len(cut):], mdStyle: span.mdStyle}}, word[i+1:]...)
+		return head, tail
+	}
+	return head, nil
+}
+
+// mdFence returns the fence that opens a code block on line, or ""
+func mdFence(line string) string {
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, fence) {
+			return fence
+		}
+	}
+	return ""
+}
+
+// mdHeading returns the level of the ATX heading on line, or 0
+func mdHeading(line string) int {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level < 1 || level > 6 || (len(line) > level && line[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+// mdRule reports whether line is a horizontal rule: three or more of the
+// same *, - or _, optionally separated by spaces
+func mdRule(line string) bool {
+	compact := strings.ReplaceAll(line, " ", "")
+	if len(compact) < 3 {
+		return false
+	}
+	return strings.Count(compact, compact[:1]) == len(compact) && strings.Contains("*-_", compact[:1])
+}
+
+// mdListMarker returns the list marker starting line, such as "-" or "2.",
+// or ""
+func mdListMarker(line string) string {
+	s := strings.TrimLeft(line, " ")
+	if len(s) >= 2 && strings.ContainsRune("-*+", rune(s[0])) && s[1] == ' ' {
+		if mdRule(strings.TrimSpace(s)) {
+			return ""
+		}
+		return s[:1]
+	}
+	digits := len(s) - len(strings.TrimLeft(s, "0123456789"))
+	if digits > 0 && digits <= 9 && len(s) > digits+1 && (s[digits] == '.' || s[digits] == ')') && s[digits+1] == ' ' {
+		return s[:digits+1]
+	}
+	return ""
+}
+
+// mdIndent returns the number of leading spaces of line
+func mdIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// mdBlockStart reports whether line ends a paragraph: a blank line or the
+// start of another block
+func mdBlockStart(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || mdFence(trimmed) != "" || mdHeading(trimmed) > 0 || mdRule(trimmed) ||
+		strings.HasPrefix(trimmed, ">") || mdListMarker(line) != ""
+}
@@ -0,0 +1,397 @@
+package widgets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dshills/goterm"
+)
+
+// Pager shows long text a screen at a time above a status line, in the
+// manner of less
+// Navigation follows less and vi: j, k, Enter and the arrow keys move a
+// line; Space, f, b, Page Up, Page Down, Ctrl-F and Ctrl-B move a page; d,
+// u, Ctrl-D and Ctrl-U move half a page; g, G, Home and End jump to either
+// end. / and ? prompt for text to search forward or backward, ignoring
+// case; n and N repeat the search in the same or opposite direction, and
+// matches are highlighted until Escape is pressed. The status line shows
+// the lines in view and how far through the text they are.
+type Pager struct {
+	focusState
+
+	Fg       goterm.Color // Colors of text drawn in the default colors
+	Bg       goterm.Color
+	StatusFg goterm.Color // Colors of the status line; reverse video if both are default
+	StatusBg goterm.Color
+	MatchFg  goterm.Color // Colors of search matches; reverse video if both are default
+	MatchBg  goterm.Color
+
+	lines    []goterm.StyledText
+	plain    [][]rune // Lower-cased text of each line, for searching
+	top      int      // First line shown
+	height   int      // Text rows at the last Draw
+	query    []rune   // Lower-cased text of the last search
+	backward bool     // The last search went backward
+	prompt   *[]rune  // Search text being typed, nil when not prompting
+	message  string   // Shown on the status line until the next key
+}
+
+// SetText replaces the text shown with plain text and scrolls to the top
+func (p *Pager) SetText(text string) {
+	lines := make([]goterm.StyledText, 0, strings.Count(text, "\n")+1)
+	def := goterm.ColorDefault()
+	for _, line := range strings.Split(text, "\n") {
+		lines = append(lines, goterm.StyledText{}.Add(strings.TrimSuffix(line, "\r"), def, def, goterm.StyleNone))
+	}
+	p.SetLines(lines)
+}
+
+// SetLines replaces the text shown with styled lines and scrolls to the top
+// Text colored with escape sequences can be shown with goterm.ParseANSI:
+//
+//	p.SetLines(goterm.ParseANSI(output))
+func (p *Pager) SetLines(lines []goterm.StyledText) {
+	p.lines = lines
+	p.plain = make([][]rune, len(lines))
+	for i, line := range lines {
+		p.plain[i] = lowerRunes(line.String())
+	}
+	p.top = 0
+}
+
+// Lines returns the number of lines of text
+func (p *Pager) Lines() int {
+	return len(p.lines)
+}
+
+// Top returns the index of the first line shown
+func (p *Pager) Top() int {
+	return p.top
+}
+
+// ScrollTo scrolls so that line is at the top, as far as the text allows
+func (p *Pager) ScrollTo(line int) {
+	p.top = max(0, min(line, len(p.lines)-p.height))
+}
+
+// ScrollBy scrolls by delta lines
+func (p *Pager) ScrollBy(delta int) {
+	p.ScrollTo(p.top + delta)
+}
+
+// Percent returns how far through the text the last line shown is, from
+// 0 to 100
+func (p *Pager) Percent() int {
+	if len(p.lines) == 0 {
+		return 100
+	}
+	return min(len(p.lines), p.top+p.height) * 100 / len(p.lines)
+}
+
+// Search scrolls to the next line containing query, ignoring case, and
+// highlights its matches
+// The search starts after the top line, or before it if backward is set,
+// and reports whether a match was found; the view does not move if not.
+func (p *Pager) Search(query string, backward bool) bool {
+	p.query = lowerRunes(query)
+	p.backward = backward
+	return p.next(backward)
+}
+
+// next scrolls to the next line matching the last search
+func (p *Pager) next(backward bool) bool {
+	if len(p.query) == 0 {
+		return false
+	}
+	step := 1
+	if backward {
+		step = -1
+	}
+	for i := p.top + step; i >= 0 && i < len(p.lines); i += step {
+		if indexRunes(p.plain[i], p.query, 0) >= 0 {
+			p.top = i
+			return true
+		}
+	}
+	p.message = "Pattern not found"
+	return false
+}
+
+// HandleEvent scrolls and searches in response to ev
+func (p *Pager) HandleEvent(ev goterm.Event) bool {
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		p.message = ""
+		if p.prompt != nil {
+			p.handlePrompt(ev)
+			return true
+		}
+		return p.handleKey(ev)
+	case goterm.MouseEvent:
+		switch ev.Button {
+		case goterm.MouseWheelUp:
+			p.ScrollBy(-3)
+		case goterm.MouseWheelDown:
+			p.ScrollBy(3)
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// handleKey handles a key press outside the search prompt
+func (p *Pager) handleKey(ev goterm.KeyEvent) bool {
+	page := max(1, p.height)
+	switch ev.Key {
+	case goterm.KeyUp:
+		p.ScrollBy(-1)
+	case goterm.KeyDown, goterm.KeyEnter:
+		p.ScrollBy(1)
+	case goterm.KeyPageUp:
+		p.ScrollBy(-page)
+	case goterm.KeyPageDown:
+		p.ScrollBy(page)
+	case goterm.KeyHome:
+		p.ScrollTo(0)
+	case goterm.KeyEnd:
+		p.ScrollTo(len(p.lines))
+	case goterm.KeyEscape:
+		p.query = nil
+	case goterm.KeyRune:
+		if ev.Modifiers&goterm.ModCtrl != 0 {
+			return p.handleCtrl(ev.Rune)
+		}
+		if ev.Modifiers&goterm.ModAlt != 0 {
+			return false
+		}
+		return p.handleRune(ev.Rune)
+	default:
+		return false
+	}
+	return true
+}
+
+// handleRune handles a character key outside the search prompt
+func (p *Pager) handleRune(r rune) bool {
+	page := max(1, p.height)
+	switch r {
+	case 'j', 'e':
+		p.ScrollBy(1)
+	case 'k', 'y':
+		p.ScrollBy(-1)
+	case ' ', 'f':
+		p.ScrollBy(page)
+	case 'b':
+		p.ScrollBy(-page)
+	case 'd':
+		p.ScrollBy(max(1, page/2))
+	case 'u':
+		p.ScrollBy(-max(1, page/2))
+	case 'g', '<':
+		p.ScrollTo(0)
+	case 'G', '>':
+		p.ScrollTo(len(p.lines))
+	case '/', '?':
+		p.prompt = new([]rune)
+		p.backward = r == '?'
+	case 'n':
+		p.next(p.backward)
+	case 'N':
+		p.next(!p.backward)
+	default:
+		return false
+	}
+	return true
+}
+
+// handleCtrl handles a control key outside the search prompt
+func (p *Pager) handleCtrl(r rune) bool {
+	page := max(1, p.height)
+	switch r {
+	case 'f':
+		p.ScrollBy(page)
+	case 'b':
+		p.ScrollBy(-page)
+	case 'd':
+		p.ScrollBy(max(1, page/2))
+	case 'u':
+		p.ScrollBy(-max(1, page/2))
+	default:
+		return false
+	}
+	return true
+}
+
+// handlePrompt edits the search text and runs the search on Enter
+func (p *Pager) handlePrompt(ev goterm.KeyEvent) {
+	text := *p.prompt
+	switch ev.Key {
+	case goterm.KeyEscape:
+		p.prompt = nil
+	case goterm.KeyEnter:
+		p.prompt = nil
+		if len(text) > 0 {
+			p.Search(string(text), p.backward)
+		} else {
+			p.next(p.backward)
+		}
+	case goterm.KeyBackspace:
+		if len(text) == 0 {
+			p.prompt = nil
+			return
+		}
+		*p.prompt = text[:len(text)-1]
+	case goterm.KeyRune:
+		if ev.Modifiers&(goterm.ModCtrl|goterm.ModAlt) == 0 {
+			*p.prompt = append(text, ev.Rune)
+		}
+	}
+}
+
+// PreferredSize returns the size that shows every line in full above the
+// status line
+func (p *Pager) PreferredSize(maxW, maxH int) (w, h int) {
+	for _, line := range p.lines {
+		w = max(w, line.Width())
+	}
+	return max(0, min(w, maxW)), max(0, min(len(p.lines)+1, maxH))
+}
+
+// Draw draws the lines in view onto s with the status line on its last row
+func (p *Pager) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	p.height = max(0, h-1)
+	p.ScrollBy(0)
+
+	for y := 0; y < p.height; y++ {
+		x := 0
+		if i := p.top + y; i < len(p.lines) {
+			x = drawSpans(s, 0, y, w, p.highlight(i))
+		}
+		fillRow(s, x, y, w, p.Bg, goterm.StyleNone)
+	}
+	if h > 0 {
+		p.drawStatus(s, h-1, w)
+	}
+}
+
+// highlight returns line i in the pager colors with search matches marked
+func (p *Pager) highlight(i int) goterm.StyledText {
+	def := goterm.ColorDefault()
+	line := make(goterm.StyledText, len(p.lines[i]))
+	for j, span := range p.lines[i] {
+		if span.Fg == def {
+			span.Fg = p.Fg
+		}
+		if span.Bg == def {
+			span.Bg = p.Bg
+		}
+		line[j] = span
+	}
+	if len(p.query) == 0 {
+		return line
+	}
+
+	fg, bg := p.MatchFg, p.MatchBg
+	reverse := fg == def && bg == def
+	for start := indexRunes(p.plain[i], p.query, 0); start >= 0; start = indexRunes(p.plain[i], p.query, start+len(p.query)) {
+		line = restyleRunes(line, start, start+len(p.query), func(span goterm.Span) goterm.Span {
+			if reverse {
+				span.Style = span.Style.Set(goterm.StyleReverse)
+			} else {
+				span.Fg, span.Bg = fg, bg
+			}
+			return span
+		})
+	}
+	return line
+}
+
+// drawStatus draws the status line onto row y
+func (p *Pager) drawStatus(s goterm.Surface, y, w int) {
+	fg, bg, style := p.StatusFg, p.StatusBg, goterm.StyleNone
+	if fg == goterm.ColorDefault() && bg == goterm.ColorDefault() {
+		style = goterm.StyleReverse
+	}
+
+	var left, right string
+	switch {
+	case p.prompt != nil && p.backward:
+		left = "?" + string(*p.prompt)
+	case p.prompt != nil:
+		left = "/" + string(*p.prompt)
+	case p.message != "":
+		left = p.message
+	case p.top+p.height >= len(p.lines):
+		left = "(END)"
+	default:
+		left = ":"
+	}
+	if p.prompt == nil {
+		right = fmt.Sprintf("%d-%d/%d %d%%", min(p.top+1, len(p.lines)), min(p.top+p.height, len(p.lines)), len(p.lines), p.Percent())
+	}
+
+	x := drawSpans(s, 0, y, w, goterm.StyledText{}.Add(left, fg, bg, style))
+	rx := max(x+1, w-goterm.StringWidth(right))
+	fillRow(s, x, y, rx, bg, style)
+	x = drawSpans(s, rx, y, w, goterm.StyledText{}.Add(right, fg, bg, style))
+	fillRow(s, x, y, w, bg, style)
+}
+
+// indexRunes returns the index of the first match of sub in s at or after
+// from, or -1
+func indexRunes(s, sub []rune, from int) int {
+	for i := from; i+len(sub) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lowerRunes returns the characters of s in lower case
+// Unlike strings.ToLower, each character maps to exactly one, so indexes
+// into the result are indexes into s.
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// restyleRunes applies restyle to the characters of line from rune index
+// start up to end, splitting spans as needed
+func restyleRunes(line goterm.StyledText, start, end int, restyle func(goterm.Span) goterm.Span) goterm.StyledText {
+	out := make(goterm.StyledText, 0, len(line)+2)
+	pos := 0
+	for _, span := range line {
+		runes := []rune(span.Text)
+		lo := max(0, min(len(runes), start-pos))
+		hi := max(0, min(len(runes), end-pos))
+		pos += len(runes)
+		if lo == hi {
+			out = append(out, span)
+			continue
+		}
+		for _, part := range []struct {
+			text  []rune
+			match bool
+		}{{runes[:lo], false}, {runes[lo:hi], true}, {runes[hi:], false}} {
+			if len(part.text) == 0 {
+				continue
+			}
+			piece := span
+			piece.Text = string(part.text)
+			if part.match {
+				piece = restyle(piece)
+			}
+			out = append(out, piece)
+		}
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package widgets
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dshills/goterm"
+)
+
+// BarRunes is the set of characters a ProgressBar is drawn with
+type BarRunes struct {
+	Full    rune   // Completed cells
+	Empty   rune   // Remaining cells
+	Partial []rune // Fractional fills of the boundary cell, smallest first; may be empty
+}
+
+// Predefined rune sets for progress bars
+var (
+	BarBlocks = BarRunes{Full: '█', Empty: '░'}
+	BarSmooth = BarRunes{Full: '█', Empty: ' ', Partial: []rune("▏▎▍▌▋▊▉")}
+	BarLine   = BarRunes{Full: '━', Empty: '─'}
+	BarASCII  = BarRunes{Full: '#', Empty: '-'}
+)
+
+// ProgressBar shows how far a task has progressed
+// A determinate bar fills in proportion to Value; an indeterminate bar shows
+// a segment sweeping back and forth, advanced by Tick, for tasks of unknown
+// length. The bar fills every row of the surface, with Label on its left and
+// the percentage on its right in the middle row.
+type ProgressBar struct {
+	Value         float64          // Progress from 0 to 1
+	Indeterminate bool             // Show activity instead of Value
+	Label         string           // Drawn before the bar when not empty
+	ShowPercent   bool             // Draw the percentage after the bar
+	Runes         BarRunes         // Glyphs; the zero value uses BarBlocks
+	Fill          goterm.Color     // Color of completed cells
+	Gradient      *goterm.Gradient // Colors completed cells by position instead of Fill
+	Empty         goterm.Color     // Color of remaining cells
+	Text          goterm.Color     // Color of the label and percentage
+	Background    goterm.Color     // Background of the whole widget
+
+	phase int
+}
+
+// Tick advances the animation of an indeterminate bar by one step
+func (p *ProgressBar) Tick() {
+	p.phase++
+}
+
+// PreferredSize returns the whole width available and one row, since the
+// bar stretches to any width
+func (p *ProgressBar) PreferredSize(maxW, maxH int) (w, h int) {
+	return max(0, maxW), max(0, min(1, maxH))
+}
+
+// Draw draws the bar onto s
+func (p *ProgressBar) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	runes := p.Runes
+	if runes.Full == 0 {
+		runes = BarBlocks
+	}
+
+	// Lay out the label, bar and percentage on the middle row
+	textY := (h - 1) / 2
+	x := 0
+	if p.Label != "" {
+		label := goterm.Truncate(p.Label, w, "…")
+		s.DrawText(0, textY, label, p.Text, p.Background, goterm.StyleNone)
+		x = goterm.StringWidth(label) + 1
+	}
+	barW := w - x
+	if p.ShowPercent && !p.Indeterminate {
+		percent := fmt.Sprintf("%3.0f%%", math.Floor(clamp01(p.Value)*100))
+		if barW >= len(percent)+1 {
+			barW -= len(percent) + 1
+			s.DrawText(x+barW, textY, " "+percent, p.Text, p.Background, goterm.StyleNone)
+		}
+	}
+	if barW <= 0 {
+		return
+	}
+
+	for y := 0; y < h; y++ {
+		for i := 0; i < barW; i++ {
+			s.SetCell(x+i, y, p.cell(i, barW, runes))
+		}
+	}
+}
+
+// cell returns the cell drawn at position i of a bar barW cells wide
+func (p *ProgressBar) cell(i, barW int, runes BarRunes) goterm.Cell {
+	empty := goterm.NewCell(runes.Empty, p.Empty, p.Background, goterm.StyleNone)
+	full := goterm.NewCell(runes.Full, p.fillColor(i, barW), p.Background, goterm.StyleNone)
+
+	if p.Indeterminate {
+		segment := max(1, barW/4)
+		if pos := bounce(p.phase, barW-segment); i >= pos && i < pos+segment {
+			return full
+		}
+		return empty
+	}
+
+	// Filled cells, measured in fractions of a cell
+	steps := len(runes.Partial) + 1
+	filled := int(clamp01(p.Value) * float64(barW*steps))
+	switch {
+	case i < filled/steps:
+		return full
+	case i == filled/steps && filled%steps > 0:
+		full.Ch = runes.Partial[filled%steps-1]
+		return full
+	}
+	return empty
+}
+
+// fillColor returns the color of completed cell i of a bar barW cells wide
+func (p *ProgressBar) fillColor(i, barW int) goterm.Color {
+	if p.Gradient == nil {
+		return p.Fill
+	}
+	if barW == 1 {
+		return p.Gradient.At(0)
+	}
+	return p.Gradient.At(float64(i) / float64(barW-1))
+}
+
+// bounce maps step to a position moving back and forth between 0 and n
+func bounce(step, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	step %= 2 * n
+	if step > n {
+		return 2*n - step
+	}
+	return step
+}
+
+// clamp01 limits f to the range 0-1
+func clamp01(f float64) float64 {
+	return max(0, min(1, f))
+}
@@ -0,0 +1,108 @@
+package widgets
+
+import (
+	"math"
+
+	"github.com/dshills/goterm"
+)
+
+// Point is a data point of a scatter plot
+type Point struct {
+	X, Y float64
+}
+
+// ScatterSeries is a named set of points plotted by a ScatterPlot
+type ScatterSeries struct {
+	Name   string // Shown in the legend when not empty
+	Points []Point
+	Color  goterm.Color
+	Marker rune // Symbol filling the cell of each point; 0 plots braille dots
+}
+
+// ScatterPlot plots series of points, as braille dots with 2×4 points of
+// resolution per cell or as marker symbols filling whole cells
+// Each axis scales to fit the data unless its Min is less than its Max;
+// points outside a fixed range and NaN points are not drawn. Series are
+// drawn in order, so later series cover earlier ones.
+type ScatterPlot struct {
+	Series     []ScatterSeries
+	XMin, XMax float64 // Fixed x range; autoscaled unless XMin < XMax
+	YMin, YMax float64 // Fixed y range; autoscaled unless YMin < YMax
+	Axes       bool    // Draw axes with both ranges labeled
+	Legend     bool    // Draw the names of the series below the plot
+	Fg         goterm.Color
+	Bg         goterm.Color
+}
+
+// PreferredSize returns all of maxW×maxH
+// Points can fall anywhere in the ranges of both axes, so a plot has no
+// natural size and is clearest as large as it can be.
+func (sp *ScatterPlot) PreferredSize(maxW, maxH int) (w, h int) {
+	return max(0, maxW), max(0, maxH)
+}
+
+// frame returns the axes and legend of the plot
+func (sp *ScatterPlot) frame() chartFrame {
+	var xs, ys []float64
+	for _, series := range sp.Series {
+		for _, p := range series.Points {
+			xs, ys = append(xs, p.X), append(ys, p.Y)
+		}
+	}
+	frame := chartFrame{axes: sp.Axes, xLabels: true, fg: sp.Fg, bg: sp.Bg}
+	frame.xMin, frame.xMax = dataRange(xs, sp.XMin, sp.XMax)
+	frame.yMin, frame.yMax = dataRange(ys, sp.YMin, sp.YMax)
+	if sp.Legend {
+		for _, series := range sp.Series {
+			frame.legend = append(frame.legend, legendEntry{name: series.Name, color: series.Color, marker: series.Marker})
+		}
+	}
+	return frame
+}
+
+// Draw draws the plot onto s
+func (sp *ScatterPlot) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	frame := sp.frame()
+
+	fillRect(s, w, h, sp.Bg)
+	frame.draw(s, w, h)
+	plot := frame.plotRect(w, h)
+	if plot.Empty() {
+		return
+	}
+
+	// Dots are drawn first so markers stay legible on top of them
+	canvas := NewCanvas(plot.W, plot.H)
+	canvas.Bg = sp.Bg
+	dotsW, dotsH := canvas.Size()
+	type marker struct {
+		x, y  int
+		ch    rune
+		color goterm.Color
+	}
+	var markers []marker
+	for _, series := range sp.Series {
+		for _, p := range series.Points {
+			if !inRange(p.X, frame.xMin, frame.xMax) || !inRange(p.Y, frame.yMin, frame.yMax) {
+				continue
+			}
+			x := scale(p.X, frame.xMin, frame.xMax, dotsW)
+			y := dotsH - 1 - scale(p.Y, frame.yMin, frame.yMax, dotsH)
+			if series.Marker == 0 {
+				canvas.Set(x, y, series.Color)
+			} else {
+				markers = append(markers, marker{x / 2, y / 4, series.Marker, series.Color})
+			}
+		}
+	}
+	canvas.drawAt(s, plot.X, plot.Y)
+	for _, m := range markers {
+		s.SetCell(plot.X+m.x, plot.Y+m.y, goterm.NewCell(m.ch, m.color, sp.Bg, goterm.StyleNone))
+	}
+}
+
+// inRange reports whether v lies within lo-hi; NaN never does
+func inRange(v, lo, hi float64) bool {
+	return !math.IsNaN(v) && v >= lo && v <= hi
+}
@@ -0,0 +1,58 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// Scrollbar glyphs
+const (
+	scrollTrack  = '│'
+	scrollThumb  = '┃'
+	scrollHTrack = '─'
+	scrollHThumb = '━'
+)
+
+// thumb returns the start and length of the scrollbar thumb on a track of
+// length cells for content of total lines, visible of which are shown from
+// offset
+func thumb(length, total, visible, offset int) (start, size int) {
+	if total <= visible || length <= 0 {
+		return 0, length
+	}
+	size = max(1, length*visible/total)
+	start = (length - size) * offset / max(1, total-visible)
+	return min(start, length-size), size
+}
+
+// drawVScrollbar draws a vertical scrollbar in column x, from row 0 to
+// length
+func drawVScrollbar(s goterm.Surface, x, length, total, visible, offset int, fg, bg goterm.Color) {
+	start, size := thumb(length, total, visible, offset)
+	for y := 0; y < length; y++ {
+		ch := scrollTrack
+		if y >= start && y < start+size {
+			ch = scrollThumb
+		}
+		s.SetCell(x, y, goterm.NewCell(ch, fg, bg, goterm.StyleNone))
+	}
+}
+
+// drawHScrollbar draws a horizontal scrollbar in row y, from column 0 to
+// length
+func drawHScrollbar(s goterm.Surface, y, length, total, visible, offset int, fg, bg goterm.Color) {
+	start, size := thumb(length, total, visible, offset)
+	for x := 0; x < length; x++ {
+		ch := scrollHTrack
+		if x >= start && x < start+size {
+			ch = scrollHThumb
+		}
+		s.SetCell(x, y, goterm.NewCell(ch, fg, bg, goterm.StyleNone))
+	}
+}
+
+// scrollbarOffset returns the offset that places the thumb center at cell
+// pos of a track of length cells
+func scrollbarOffset(pos, length, total, visible int) int {
+	if length <= 1 || total <= visible {
+		return 0
+	}
+	return max(0, min(total-visible, pos*(total-visible)/(length-1)))
+}
@@ -0,0 +1,227 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// SelectLayerZ is the z-index of the layer a Select draws its open list on
+const SelectLayerZ = 1000
+
+// Select is a field showing one of several options, which opens a list of
+// all options below it
+// The list is drawn on its own layer of Screen, so it covers whatever lies
+// below the field without disturbing it. Enter, Space, Down or typing opens
+// the list while the field has the focus; typing filters the options, the
+// arrow keys move through them, Enter or a click picks one and Escape closes
+// the list unchanged. When the field is drawn on a view, the list is placed
+// under the view; if there is no room below, it opens above. Mouse
+// coordinates are relative to the field, so rows of the open list have
+// positive y.
+type Select struct {
+	focusState
+
+	Screen      *goterm.Screen // Screen the list opens on
+	Options     []string
+	Selected    int    // Index of the selected option, or -1 for none
+	Placeholder string // Shown when no option is selected
+	MaxVisible  int    // Rows of the open list; 0 for 8
+	OnChange    func(index int)
+	Fg          goterm.Color
+	Bg          goterm.Color
+	FocusFg     goterm.Color // Colors of the field when focused; reverse video if both are default
+	FocusBg     goterm.Color
+
+	field goterm.Rect   // Screen region of the field at the last Draw
+	list  List          // Options shown while open
+	layer *goterm.Layer // Layer of the open list, nil when closed
+}
+
+// IsOpen reports whether the list of options is shown
+func (sel *Select) IsOpen() bool {
+	return sel.layer != nil
+}
+
+// Open shows the list of options
+// It does nothing without a Screen.
+func (sel *Select) Open() {
+	if sel.IsOpen() || sel.Screen == nil {
+		return
+	}
+	sel.list = List{Items: sel.Options, TypeToFilter: true, Fg: sel.Fg, Bg: sel.Bg}
+	sel.list.SetCursor(sel.Selected)
+	sel.list.SetFocused(true)
+	sel.layer = sel.Screen.AddLayer(SelectLayerZ)
+	sel.drawList()
+}
+
+// Close hides the list of options without changing the selection
+func (sel *Select) Close() {
+	if !sel.IsOpen() {
+		return
+	}
+	sel.Screen.RemoveLayer(sel.layer)
+	sel.layer = nil
+}
+
+// HandleEvent opens and closes the list and selects options in response
+// to ev
+func (sel *Select) HandleEvent(ev goterm.Event) bool {
+	if !sel.IsOpen() {
+		return sel.handleClosed(ev)
+	}
+
+	handled := true
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		switch ev.Key {
+		case goterm.KeyEscape:
+			sel.Close()
+		case goterm.KeyEnter:
+			sel.pick(sel.list.Cursor())
+		case goterm.KeyTab:
+			sel.Close()
+			return false
+		default:
+			handled = sel.list.HandleEvent(ev)
+		}
+	case goterm.MouseEvent:
+		handled = sel.handleOpenMouse(ev)
+	default:
+		handled = false
+	}
+	if sel.IsOpen() {
+		sel.drawList()
+	}
+	return handled
+}
+
+// handleClosed opens the list in response to ev
+func (sel *Select) handleClosed(ev goterm.Event) bool {
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		switch {
+		case ev.Key == goterm.KeyEnter, ev.Key == goterm.KeyDown:
+			sel.Open()
+		case ev.Key == goterm.KeyRune && ev.Modifiers&(goterm.ModCtrl|goterm.ModAlt) == 0:
+			sel.Open()
+			if ev.Rune != ' ' {
+				sel.list.HandleEvent(ev)
+				sel.drawList()
+			}
+		default:
+			return false
+		}
+	case goterm.MouseEvent:
+		if ev.Button != goterm.MouseLeft || ev.Action != goterm.MousePress || ev.Y != 0 {
+			return false
+		}
+		sel.Open()
+	default:
+		return false
+	}
+	return true
+}
+
+// handleOpenMouse picks a clicked option, scrolls the list with the wheel
+// and closes it on clicks elsewhere
+func (sel *Select) handleOpenMouse(ev goterm.MouseEvent) bool {
+	r := sel.listRect()
+	local := ev
+	local.X -= r.X - sel.field.X
+	local.Y -= r.Y - sel.field.Y
+	inList := local.X >= 0 && local.X < r.W && local.Y >= 0 && local.Y < r.H
+
+	switch {
+	case ev.Button == goterm.MouseWheelUp, ev.Button == goterm.MouseWheelDown:
+		return sel.list.HandleEvent(ev)
+	case ev.Button != goterm.MouseLeft || ev.Action != goterm.MousePress:
+		return false
+	case !inList:
+		sel.Close()
+		return ev.Y == 0 && ev.X >= 0 && ev.X < sel.field.W
+	}
+
+	visible := sel.list.Visible()
+	if row := sel.list.offset + local.Y; row < len(visible) {
+		sel.pick(visible[row])
+	}
+	return true
+}
+
+// pick selects option i, if any, and closes the list
+func (sel *Select) pick(i int) {
+	sel.Close()
+	if i < 0 || i == sel.Selected {
+		return
+	}
+	sel.Selected = i
+	if sel.OnChange != nil {
+		sel.OnChange(i)
+	}
+}
+
+// PreferredSize returns the size of a field that fits the longest option or
+// placeholder and the arrow, one row high
+func (sel *Select) PreferredSize(maxW, maxH int) (w, h int) {
+	w = goterm.StringWidth(sel.Placeholder)
+	for _, option := range sel.Options {
+		w = max(w, goterm.StringWidth(option))
+	}
+	return max(0, min(w+2, maxW)), max(0, min(1, maxH))
+}
+
+// Draw draws the field onto the first row of s and, while open, the list
+// of options onto its layer
+func (sel *Select) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	sel.field = goterm.Rect{W: w, H: h}
+	if v, ok := s.(interface{ Rect() goterm.Rect }); ok {
+		sel.field = v.Rect()
+	}
+
+	fg, bg, style := sel.Fg, sel.Bg, goterm.StyleNone
+	if sel.focused {
+		fg, bg = sel.FocusFg, sel.FocusBg
+		if fg == goterm.ColorDefault() && bg == goterm.ColorDefault() {
+			style = goterm.StyleReverse
+		}
+	}
+	text := sel.Placeholder
+	if sel.Selected >= 0 && sel.Selected < len(sel.Options) {
+		text = sel.Options[sel.Selected]
+	}
+	arrow := " ▾"
+	if sel.IsOpen() {
+		arrow = " ▴"
+	}
+
+	x := drawSpans(s, 0, 0, w-len([]rune(arrow)), goterm.StyledText{}.Add(goterm.Truncate(text, w-2, "…"), fg, bg, style))
+	fillRow(s, x, 0, w-2, bg, style)
+	drawSpans(s, max(0, w-2), 0, w, goterm.StyledText{}.Add(arrow, fg, bg, style))
+
+	if sel.IsOpen() {
+		sel.drawList()
+	}
+}
+
+// listRect returns the screen region of the open list: below the field if
+// it fits there and above it otherwise
+func (sel *Select) listRect() goterm.Rect {
+	rows := sel.MaxVisible
+	if rows <= 0 {
+		rows = 8
+	}
+	rows = max(1, min(rows, len(sel.list.Visible())))
+	r := goterm.Rect{X: sel.field.X, Y: sel.field.Y + 1, W: sel.field.W, H: rows}
+	if sel.Screen != nil {
+		if _, sh := sel.Screen.Size(); r.Y+r.H > sh && sel.field.Y-rows >= 0 {
+			r.Y = sel.field.Y - rows
+		}
+	}
+	return r
+}
+
+// drawList draws the open list onto its layer
+func (sel *Select) drawList() {
+	sel.layer.Clear()
+	r := sel.listRect()
+	sel.list.Draw(sel.layer.SubView(r.X, r.Y, r.W, r.H))
+}
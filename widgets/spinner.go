@@ -0,0 +1,65 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// Predefined frame sets for spinners
+var (
+	SpinnerLine    = []string{"|", "/", "─", "\\"}
+	SpinnerDots    = []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}
+	SpinnerBraille = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerCircle  = []string{"◐", "◓", "◑", "◒"}
+	SpinnerArrows  = []string{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}
+)
+
+// Spinner shows that work is in progress by cycling through frames
+// The render loop calls Tick once per frame (or at whatever rate the spinner
+// should turn) and Draw to display the current frame followed by Label.
+type Spinner struct {
+	Frames []string     // Animation frames; nil uses SpinnerLine
+	Label  string       // Drawn after the spinner when not empty
+	Fg     goterm.Color // Color of the spinner and label
+	Bg     goterm.Color // Background of the whole widget
+	Style  goterm.Style // Style of the spinner
+
+	frame int
+}
+
+// Tick advances the spinner to its next frame
+func (sp *Spinner) Tick() {
+	sp.frame = (sp.frame + 1) % len(sp.frames())
+}
+
+// Frame returns the current frame
+func (sp *Spinner) Frame() string {
+	frames := sp.frames()
+	return frames[sp.frame%len(frames)]
+}
+
+// PreferredSize returns the size of the widest frame and the label, one row
+// high
+func (sp *Spinner) PreferredSize(maxW, maxH int) (w, h int) {
+	for _, frame := range sp.frames() {
+		w = max(w, goterm.StringWidth(frame))
+	}
+	if sp.Label != "" {
+		w += 1 + goterm.StringWidth(sp.Label)
+	}
+	return max(0, min(w, maxW)), max(0, min(1, maxH))
+}
+
+// Draw draws the current frame and label onto the first row of s
+func (sp *Spinner) Draw(s goterm.Surface) {
+	frame := sp.Frame()
+	s.DrawText(0, 0, frame, sp.Fg, sp.Bg, sp.Style)
+	if sp.Label != "" {
+		s.DrawText(goterm.StringWidth(frame), 0, " "+sp.Label, sp.Fg, sp.Bg, goterm.StyleNone)
+	}
+}
+
+// frames returns the frame set in use
+func (sp *Spinner) frames() []string {
+	if len(sp.Frames) == 0 {
+		return SpinnerLine
+	}
+	return sp.Frames
+}
@@ -0,0 +1,63 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// StatusBar is a one-line bar with left-aligned, centered and right-aligned
+// groups of segments, such as a mode indicator, file name and cursor
+// position
+// Each segment is a goterm.Span with its own colors and style. When the
+// groups do not fit, the center group is shortened first, then the left
+// group and finally the right group, with "…" marking cut text.
+type StatusBar struct {
+	Left   goterm.StyledText
+	Center goterm.StyledText
+	Right  goterm.StyledText
+	Fg     goterm.Color // Colors of the space between groups
+	Bg     goterm.Color
+	Style  goterm.Style
+}
+
+// PreferredSize returns the size that shows every group in full, one row
+// high
+func (sb *StatusBar) PreferredSize(maxW, maxH int) (w, h int) {
+	groups := 0
+	for _, group := range []goterm.StyledText{sb.Left, sb.Center, sb.Right} {
+		if gw := group.Width(); gw > 0 {
+			w += gw
+			groups++
+		}
+	}
+	w += max(0, groups-1)
+	return max(0, min(w, maxW)), max(0, min(1, maxH))
+}
+
+// Draw draws the bar onto the first row of s
+func (sb *StatusBar) Draw(s goterm.Surface) {
+	w, _ := s.Size()
+	fillRow(s, 0, 0, w, sb.Bg, sb.Style)
+
+	right := truncateSpans(sb.Right, w)
+	rightX := w - right.Width()
+	left := truncateSpans(sb.Left, max(0, rightX-gap(right)))
+	leftEnd := left.Width()
+
+	// Center in the whole bar if there is room, else in the space left over
+	space := rightX - gap(right) - leftEnd - gap(left)
+	center := truncateSpans(sb.Center, max(0, space))
+	centerX := (w - center.Width()) / 2
+	centerX = max(leftEnd+gap(left), min(centerX, rightX-gap(right)-center.Width()))
+
+	drawSpans(s, 0, 0, w, left)
+	if len(center) > 0 && space > 0 {
+		drawSpans(s, centerX, 0, w, center)
+	}
+	drawSpans(s, rightX, 0, w, right)
+}
+
+// gap returns the columns kept free next to a group: one if it is drawn
+func gap(spans goterm.StyledText) int {
+	if spans.Width() > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,575 @@
+package widgets
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dshills/goterm"
+)
+
+// TextArea is a multi-line text editor
+// Lines longer than the widget is wide wrap onto the following rows, and the
+// view scrolls to follow the cursor. Besides typing, it understands the arrow
+// keys, Home, End, Page Up, Page Down, Backspace and Delete; holding Shift
+// while moving the cursor, or dragging with the mouse, selects text. Ctrl-A
+// selects everything and Ctrl-C, Ctrl-X and Ctrl-V copy, cut and paste
+// through Clipboard. Tabs are replaced with spaces and other control
+// characters are dropped. The cursor moves over a grapheme cluster, such as
+// a letter and its accents, as a whole. The cursor is always drawn, dimmed while a
+// FocusManager has given the focus to another widget.
+type TextArea struct {
+	focusState
+
+	ReadOnly    bool      // Allow moving, selecting and copying only
+	Clipboard   Clipboard // nil uses a clipboard shared by all widgets
+	Fg          goterm.Color
+	Bg          goterm.Color
+	SelectionFg goterm.Color // Colors of selected text; reverse video if both are default
+	SelectionBg goterm.Color
+
+	lines     [][]rune
+	cur       textPos // Cursor position
+	anchor    textPos // Other end of the selection
+	selecting bool    // Whether anchor marks a selection
+	goalX     int     // Column kept when moving vertically
+	keepGoal  bool    // Whether goalX applies
+	top       int     // First visible row
+	follow    bool    // Scroll to the cursor at the next Draw
+	width     int     // Size at the last Draw
+	height    int
+}
+
+// textPos is a position in the text as a line and rune offset
+type textPos struct {
+	line, col int
+}
+
+// before reports whether p comes before q
+func (p textPos) before(q textPos) bool {
+	return p.line < q.line || (p.line == q.line && p.col < q.col)
+}
+
+// textRow is one display row: runes start to end of a line
+type textRow struct {
+	line, start, end int
+}
+
+// textCell is a grapheme cluster of a line, which the cursor moves over and
+// the text wraps around as a whole: runes start to end, width columns wide
+type textCell struct {
+	start, end, width int
+}
+
+// tabSpaces replaces a tab in the text
+const tabSpaces = "    "
+
+// Text returns the content of the text area
+func (t *TextArea) Text() string {
+	parts := make([]string, len(t.lines))
+	for i, line := range t.lines {
+		parts[i] = string(line)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// SetText replaces the content and moves the cursor to its start
+func (t *TextArea) SetText(text string) {
+	t.lines = nil
+	t.cur = textPos{}
+	t.selecting = false
+	t.top = 0
+	t.insert(text)
+	t.cur = textPos{}
+	t.follow = true
+}
+
+// Cursor returns the line and column, in runes, of the cursor
+func (t *TextArea) Cursor() (line, col int) {
+	return t.cur.line, t.cur.col
+}
+
+// SetCursor moves the cursor to the given line and column, in runes
+// Out-of-range values are clamped to the text.
+func (t *TextArea) SetCursor(line, col int) {
+	t.ensureLine()
+	t.selecting = false
+	t.moveTo(t.clamp(textPos{line, col}))
+}
+
+// SelectAll selects the whole text
+func (t *TextArea) SelectAll() {
+	t.ensureLine()
+	t.anchor = textPos{}
+	t.selecting = true
+	last := len(t.lines) - 1
+	t.moveTo(textPos{last, len(t.lines[last])})
+}
+
+// SelectedText returns the selected text, or "" if nothing is selected
+func (t *TextArea) SelectedText() string {
+	from, to, ok := t.selection()
+	if !ok {
+		return ""
+	}
+	if from.line == to.line {
+		return string(t.lines[from.line][from.col:to.col])
+	}
+	var sb strings.Builder
+	sb.WriteString(string(t.lines[from.line][from.col:]))
+	for i := from.line + 1; i < to.line; i++ {
+		sb.WriteString("\n" + string(t.lines[i]))
+	}
+	sb.WriteString("\n" + string(t.lines[to.line][:to.col]))
+	return sb.String()
+}
+
+// HandleEvent edits the text, moves the cursor or changes the selection in
+// response to ev
+func (t *TextArea) HandleEvent(ev goterm.Event) bool {
+	t.ensureLine()
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		return t.handleKey(ev)
+	case goterm.MouseEvent:
+		return t.handleMouse(ev)
+	case goterm.PasteEvent:
+		if t.ReadOnly {
+			return false
+		}
+		t.replaceSelection(ev.Text)
+		return true
+	}
+	return false
+}
+
+// handleKey handles a key press
+func (t *TextArea) handleKey(ev goterm.KeyEvent) bool {
+	if ev.Modifiers&goterm.ModCtrl != 0 && ev.Key == goterm.KeyRune {
+		return t.handleShortcut(ev.Rune)
+	}
+
+	shift := ev.Modifiers&goterm.ModShift != 0
+	switch ev.Key {
+	case goterm.KeyLeft:
+		t.move(shift, t.prevPos(t.cur))
+	case goterm.KeyRight:
+		t.move(shift, t.nextPos(t.cur))
+	case goterm.KeyUp:
+		t.moveRows(shift, -1)
+	case goterm.KeyDown:
+		t.moveRows(shift, 1)
+	case goterm.KeyPageUp:
+		t.moveRows(shift, -max(1, t.height-1))
+	case goterm.KeyPageDown:
+		t.moveRows(shift, max(1, t.height-1))
+	case goterm.KeyHome:
+		t.move(shift, textPos{t.cur.line, 0})
+	case goterm.KeyEnd:
+		t.move(shift, textPos{t.cur.line, len(t.lines[t.cur.line])})
+	case goterm.KeyEnter, goterm.KeyBackspace, goterm.KeyDelete, goterm.KeyRune:
+		if t.ReadOnly || ev.Modifiers&goterm.ModAlt != 0 {
+			return false
+		}
+		t.edit(ev)
+	default:
+		return false
+	}
+	return true
+}
+
+// handleShortcut handles a Ctrl key combination
+func (t *TextArea) handleShortcut(r rune) bool {
+	switch r {
+	case 'a':
+		t.SelectAll()
+	case 'c':
+		// Leave Ctrl+C with nothing selected to the app, which quits on it
+		if _, _, ok := t.selection(); !ok {
+			return false
+		}
+		t.clipboard().Copy(t.SelectedText())
+	case 'x':
+		if _, _, ok := t.selection(); !ok || t.ReadOnly {
+			return false
+		}
+		t.clipboard().Copy(t.SelectedText())
+		t.replaceSelection("")
+	case 'v':
+		if t.ReadOnly {
+			return false
+		}
+		t.replaceSelection(t.clipboard().Paste())
+	default:
+		return false
+	}
+	return true
+}
+
+// edit applies a key that changes the text
+func (t *TextArea) edit(ev goterm.KeyEvent) {
+	switch ev.Key {
+	case goterm.KeyEnter:
+		t.replaceSelection("\n")
+	case goterm.KeyRune:
+		t.replaceSelection(string(ev.Rune))
+	case goterm.KeyBackspace, goterm.KeyDelete:
+		if !t.selecting || t.anchor == t.cur {
+			t.anchor = t.prevPos(t.cur)
+			if ev.Key == goterm.KeyDelete {
+				t.anchor = t.nextPos(t.cur)
+			}
+			t.selecting = true
+		}
+		t.replaceSelection("")
+	}
+}
+
+// handleMouse places the cursor on a click, selects while dragging and
+// scrolls with the wheel
+func (t *TextArea) handleMouse(ev goterm.MouseEvent) bool {
+	switch ev.Button {
+	case goterm.MouseWheelUp:
+		t.top = max(0, t.top-3)
+		return true
+	case goterm.MouseWheelDown:
+		t.top = max(0, min(t.top+3, len(t.layout())-1))
+		return true
+	}
+
+	pos := t.posAt(ev.X, t.top+ev.Y)
+	switch {
+	case ev.Button == goterm.MouseLeft && ev.Action == goterm.MousePress:
+		switch {
+		case ev.Modifiers&goterm.ModShift == 0:
+			t.anchor = pos
+		case !t.selecting:
+			t.anchor = t.cur
+		}
+		t.selecting = true
+		t.moveTo(pos)
+	case ev.Action == goterm.MouseMotion:
+		t.moveTo(pos)
+	default:
+		return false
+	}
+	return true
+}
+
+// move moves the cursor to pos, extending the selection when shift is held
+// and dropping it otherwise
+func (t *TextArea) move(shift bool, pos textPos) {
+	switch {
+	case shift && !t.selecting:
+		t.anchor = t.cur
+		t.selecting = true
+	case !shift:
+		t.selecting = false
+	}
+	t.moveTo(pos)
+}
+
+// moveTo moves the cursor to pos, keeping the selection anchor
+func (t *TextArea) moveTo(pos textPos) {
+	t.cur = pos
+	t.keepGoal = false
+	t.follow = true
+}
+
+// moveRows moves the cursor up or down by n display rows, keeping its column
+func (t *TextArea) moveRows(shift bool, n int) {
+	rows := t.layout()
+	row, x := t.rowOf(rows, t.cur)
+	if t.keepGoal {
+		x = t.goalX
+	}
+	target := max(0, min(len(rows)-1, row+n))
+	t.move(shift, t.posAt(x, target))
+	t.goalX, t.keepGoal = x, true
+}
+
+// replaceSelection replaces the selected text, if any, with text and places
+// the cursor after it
+func (t *TextArea) replaceSelection(text string) {
+	if from, to, ok := t.selection(); ok {
+		line := t.lines[from.line][:from.col:from.col]
+		line = append(line, t.lines[to.line][to.col:]...)
+		t.lines = append(t.lines[:from.line+1], t.lines[to.line+1:]...)
+		t.lines[from.line] = line
+		t.cur = from
+	}
+	t.selecting = false
+	t.insert(text)
+	t.moveTo(t.cur)
+}
+
+// insert inserts text at the cursor and moves the cursor after it
+func (t *TextArea) insert(text string) {
+	t.ensureLine()
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", tabSpaces)
+
+	line := t.lines[t.cur.line]
+	tail := append([]rune(nil), line[t.cur.col:]...)
+	line = line[:t.cur.col]
+	for _, r := range text {
+		switch {
+		case r == '\n':
+			t.lines[t.cur.line] = line
+			t.lines = append(t.lines[:t.cur.line+1], append([][]rune{nil}, t.lines[t.cur.line+1:]...)...)
+			t.cur = textPos{t.cur.line + 1, 0}
+			line = nil
+		case r < 0x20 || r == 0x7f:
+			// Drop other control characters
+		default:
+			line = append(line, r)
+			t.cur.col++
+		}
+	}
+	t.lines[t.cur.line] = append(line, tail...)
+	// A mark typed before text may have joined its cluster
+	t.cur = t.snap(t.cur, true)
+}
+
+// selection returns the ordered ends of the selection, if any
+func (t *TextArea) selection() (from, to textPos, ok bool) {
+	if !t.selecting || t.anchor == t.cur {
+		return textPos{}, textPos{}, false
+	}
+	from, to = t.anchor, t.cur
+	if to.before(from) {
+		from, to = to, from
+	}
+	return t.clamp(from), t.clamp(to), true
+}
+
+// prevPos returns the position of the grapheme cluster before p, moving to
+// the end of the previous line from the start of a line
+func (t *TextArea) prevPos(p textPos) textPos {
+	switch {
+	case p.col > 0:
+		col := 0
+		for _, c := range t.cells(p.line) {
+			if c.start >= p.col {
+				break
+			}
+			col = c.start
+		}
+		return textPos{p.line, col}
+	case p.line > 0:
+		return textPos{p.line - 1, len(t.lines[p.line-1])}
+	}
+	return p
+}
+
+// nextPos returns the position after the grapheme cluster at p, moving to
+// the start of the next line from the end of a line
+func (t *TextArea) nextPos(p textPos) textPos {
+	switch {
+	case p.col < len(t.lines[p.line]):
+		return t.snap(textPos{p.line, p.col + 1}, true)
+	case p.line < len(t.lines)-1:
+		return textPos{p.line + 1, 0}
+	}
+	return p
+}
+
+// clamp limits p to the text, moving it back to the start of the grapheme
+// cluster it falls in
+func (t *TextArea) clamp(p textPos) textPos {
+	p.line = max(0, min(len(t.lines)-1, p.line))
+	p.col = max(0, min(len(t.lines[p.line]), p.col))
+	return t.snap(p, false)
+}
+
+// snap moves p, which must be in the text, to the end of the grapheme
+// cluster it falls in if forward is set, or to its start otherwise
+func (t *TextArea) snap(p textPos, forward bool) textPos {
+	for _, c := range t.cells(p.line) {
+		if p.col > c.start && p.col < c.end {
+			if forward {
+				p.col = c.end
+			} else {
+				p.col = c.start
+			}
+			break
+		}
+	}
+	return p
+}
+
+// cells splits a line into grapheme clusters as goterm.NextCell does
+// A zero-width cluster is drawn over the cell before it, as DrawText does,
+// so it is part of that cell; at the start of a line it gets a column of
+// its own.
+func (t *TextArea) cells(line int) []textCell {
+	s := string(t.lines[line])
+	var cells []textCell
+	col := 0
+	for i := 0; i < len(s); {
+		next, w := goterm.NextCell(s, i)
+		n := utf8.RuneCountInString(s[i:next])
+		if w == 0 && len(cells) > 0 {
+			cells[len(cells)-1].end += n
+		} else {
+			cells = append(cells, textCell{col, col + n, max(1, w)})
+		}
+		col, i = col+n, next
+	}
+	return cells
+}
+
+// ensureLine makes sure the text has at least one line
+func (t *TextArea) ensureLine() {
+	if len(t.lines) == 0 {
+		t.lines = [][]rune{nil}
+	}
+}
+
+// clipboard returns the clipboard in use
+func (t *TextArea) clipboard() Clipboard {
+	if t.Clipboard == nil {
+		return sharedClipboard
+	}
+	return t.Clipboard
+}
+
+// layout splits the lines into display rows of the width at the last Draw
+// A line filling its last row exactly gets an extra empty row for the cursor.
+func (t *TextArea) layout() []textRow {
+	t.ensureLine()
+	var rows []textRow
+	for i, line := range t.lines {
+		start, x := 0, 0
+		for _, c := range t.cells(i) {
+			if t.width > 0 && x+c.width > t.width && c.start > start {
+				rows = append(rows, textRow{i, start, c.start})
+				start, x = c.start, 0
+			}
+			x += c.width
+		}
+		rows = append(rows, textRow{i, start, len(line)})
+		if t.width > 0 && x >= t.width {
+			rows = append(rows, textRow{i, len(line), len(line)})
+		}
+	}
+	return rows
+}
+
+// rowOf returns the display row and column of p
+func (t *TextArea) rowOf(rows []textRow, p textPos) (row, x int) {
+	for i, r := range rows {
+		last := i+1 == len(rows) || rows[i+1].line != r.line
+		if r.line == p.line && p.col >= r.start && (p.col < r.end || last) {
+			return i, t.columns(r.line, r.start, p.col)
+		}
+	}
+	return len(rows) - 1, 0
+}
+
+// posAt returns the text position shown at column x of display row row
+func (t *TextArea) posAt(x, row int) textPos {
+	rows := t.layout()
+	row = max(0, min(len(rows)-1, row))
+	r := rows[row]
+	col, left := r.start, 0
+	for _, c := range t.cells(r.line) {
+		if c.start < r.start {
+			continue
+		}
+		if c.start >= r.end || left+c.width > x {
+			break
+		}
+		col, left = c.end, left+c.width
+	}
+	if col == r.end && row+1 < len(rows) && rows[row+1].line == r.line && r.end > r.start {
+		// The end of a wrapped row is the start of the next one
+		col = t.prevPos(textPos{r.line, col}).col
+	}
+	return textPos{r.line, col}
+}
+
+// columns returns the display width of the grapheme clusters starting from
+// rune from up to rune to of a line
+func (t *TextArea) columns(line, from, to int) int {
+	w := 0
+	for _, c := range t.cells(line) {
+		if c.start >= from && c.start < to {
+			w += c.width
+		}
+	}
+	return w
+}
+
+// PreferredSize returns the size that shows all of the text without
+// scrolling, with room for the cursor after the longest line
+func (t *TextArea) PreferredSize(maxW, maxH int) (w, h int) {
+	for _, line := range t.lines {
+		w = max(w, goterm.StringWidth(string(line))+1)
+	}
+	return max(0, min(w, maxW)), max(0, min(max(1, len(t.lines)), maxH))
+}
+
+// Draw draws the visible part of the text onto s, scrolled to keep the
+// cursor in view after it moved
+func (t *TextArea) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	t.width, t.height = w, h
+	rows := t.layout()
+	curRow, curX := t.rowOf(rows, t.cur)
+	if t.follow {
+		t.top = min(t.top, curRow)
+		if curRow >= t.top+h {
+			t.top = curRow - h + 1
+		}
+		t.follow = false
+	}
+	t.top = max(0, min(t.top, len(rows)-1))
+
+	// The cursor is dimmed while another widget has the focus
+	dim := !t.showsFocus()
+	from, to, selected := t.selection()
+	for y := 0; y < h; y++ {
+		x := 0
+		if row := t.top + y; row < len(rows) {
+			r := rows[row]
+			for _, c := range t.cells(r.line) {
+				if c.start < r.start || c.start >= r.end {
+					continue
+				}
+				pos := textPos{r.line, c.start}
+				runes := t.lines[r.line][c.start:c.end]
+				cell := goterm.NewCell(runes[0], t.Fg, t.Bg, goterm.StyleNone)
+				cell.Comb = string(runes[1:])
+				if selected && !pos.before(from) && pos.before(to) {
+					cell = t.selectedCell(cell)
+				}
+				if row == curRow && x == curX {
+					cell.Style = cell.Style.Toggle(goterm.StyleReverse)
+					if dim {
+						cell.Style = cell.Style.Set(goterm.StyleDim)
+					}
+				}
+				s.SetCell(x, y, cell)
+				x += c.width
+			}
+		}
+		fillRow(s, x, y, w, t.Bg, goterm.StyleNone)
+		if t.top+y == curRow && curX == x && x < w {
+			cell := goterm.NewCell(' ', t.Fg, t.Bg, goterm.StyleReverse)
+			if dim {
+				cell.Style = cell.Style.Set(goterm.StyleDim)
+			}
+			s.SetCell(x, y, cell)
+		}
+	}
+}
+
+// selectedCell returns the cell drawn in the selection colors
+func (t *TextArea) selectedCell(c goterm.Cell) goterm.Cell {
+	if t.SelectionFg == goterm.ColorDefault() && t.SelectionBg == goterm.ColorDefault() {
+		c.Style = c.Style.Set(goterm.StyleReverse)
+		return c
+	}
+	c.Fg, c.Bg = t.SelectionFg, t.SelectionBg
+	return c
+}
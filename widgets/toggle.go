@@ -0,0 +1,188 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// ToggleGlyphs are the symbols drawn for the states of a checkbox or radio
+// button
+type ToggleGlyphs struct {
+	On, Off string
+}
+
+// Predefined glyphs for checkboxes and radio buttons
+var (
+	CheckboxBrackets = ToggleGlyphs{On: "[x]", Off: "[ ]"}
+	CheckboxBoxes    = ToggleGlyphs{On: "☑", Off: "☐"}
+	RadioParens      = ToggleGlyphs{On: "(•)", Off: "( )"}
+	RadioCircles     = ToggleGlyphs{On: "◉", Off: "○"}
+)
+
+// Checkbox is a labeled on/off option
+// Space or Enter toggles it when it has the focus, as does clicking it.
+type Checkbox struct {
+	focusState
+
+	Label    string
+	Checked  bool
+	Glyphs   ToggleGlyphs // Symbols; the zero value uses CheckboxBrackets
+	OnChange func(checked bool)
+	Fg       goterm.Color
+	Bg       goterm.Color
+	FocusFg  goterm.Color // Colors of the label when focused; reverse video if both are default
+	FocusBg  goterm.Color
+}
+
+// HandleEvent toggles the checkbox in response to ev
+func (c *Checkbox) HandleEvent(ev goterm.Event) bool {
+	if !toggleEvent(ev) {
+		return false
+	}
+	c.Checked = !c.Checked
+	if c.OnChange != nil {
+		c.OnChange(c.Checked)
+	}
+	return true
+}
+
+// PreferredSize returns the size of the glyph and label, one row high
+func (c *Checkbox) PreferredSize(maxW, maxH int) (w, h int) {
+	glyphs := c.Glyphs
+	if glyphs == (ToggleGlyphs{}) {
+		glyphs = CheckboxBrackets
+	}
+	return max(0, min(toggleWidth(glyphs, c.Label), maxW)), max(0, min(1, maxH))
+}
+
+// Draw draws the checkbox onto the first row of s
+func (c *Checkbox) Draw(s goterm.Surface) {
+	w, _ := s.Size()
+	glyphs := c.Glyphs
+	if glyphs == (ToggleGlyphs{}) {
+		glyphs = CheckboxBrackets
+	}
+	drawToggle(s, 0, w, glyphs, c.Checked, c.Label, c.focused, toggleColors{c.Fg, c.Bg, c.FocusFg, c.FocusBg})
+}
+
+// RadioGroup is a set of mutually exclusive options, one per row
+// When it has the focus, the arrow keys select the previous or next option;
+// clicking an option selects it.
+type RadioGroup struct {
+	focusState
+
+	Options  []string
+	Selected int          // Index of the selected option, or -1 for none
+	Glyphs   ToggleGlyphs // Symbols; the zero value uses RadioParens
+	OnChange func(index int)
+	Fg       goterm.Color
+	Bg       goterm.Color
+	FocusFg  goterm.Color // Colors of the selected label when focused; reverse video if both are default
+	FocusBg  goterm.Color
+}
+
+// HandleEvent changes the selected option in response to ev
+func (r *RadioGroup) HandleEvent(ev goterm.Event) bool {
+	n := len(r.Options)
+	if n == 0 {
+		return false
+	}
+	selected := r.Selected
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		switch ev.Key {
+		case goterm.KeyUp, goterm.KeyLeft:
+			selected = max(0, selected-1)
+		case goterm.KeyDown, goterm.KeyRight:
+			selected = min(n-1, selected+1)
+		case goterm.KeyHome:
+			selected = 0
+		case goterm.KeyEnd:
+			selected = n - 1
+		default:
+			return false
+		}
+	case goterm.MouseEvent:
+		if ev.Button != goterm.MouseLeft || ev.Action != goterm.MousePress || ev.Y < 0 || ev.Y >= n {
+			return false
+		}
+		selected = ev.Y
+	default:
+		return false
+	}
+
+	if selected != r.Selected {
+		r.Selected = selected
+		if r.OnChange != nil {
+			r.OnChange(selected)
+		}
+	}
+	return true
+}
+
+// PreferredSize returns the size that shows every option in full
+func (r *RadioGroup) PreferredSize(maxW, maxH int) (w, h int) {
+	glyphs := r.Glyphs
+	if glyphs == (ToggleGlyphs{}) {
+		glyphs = RadioParens
+	}
+	for _, option := range r.Options {
+		w = max(w, toggleWidth(glyphs, option))
+	}
+	return max(0, min(w, maxW)), max(0, min(len(r.Options), maxH))
+}
+
+// Draw draws the options onto s, one per row
+func (r *RadioGroup) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	glyphs := r.Glyphs
+	if glyphs == (ToggleGlyphs{}) {
+		glyphs = RadioParens
+	}
+	colors := toggleColors{r.Fg, r.Bg, r.FocusFg, r.FocusBg}
+	for i, option := range r.Options {
+		if i >= h {
+			break
+		}
+		drawToggle(s, i, w, glyphs, i == r.Selected, option, r.focused && i == r.Selected, colors)
+	}
+}
+
+// toggleColors are the colors of a checkbox or radio button
+type toggleColors struct {
+	fg, bg, focusFg, focusBg goterm.Color
+}
+
+// drawToggle draws a glyph followed by a label on row y, highlighting the
+// label if focused
+func drawToggle(s goterm.Surface, y, w int, glyphs ToggleGlyphs, on bool, label string, focused bool, c toggleColors) {
+	glyph := glyphs.Off
+	if on {
+		glyph = glyphs.On
+	}
+	fg, bg, style := c.fg, c.bg, goterm.StyleNone
+	if focused {
+		fg, bg = c.focusFg, c.focusBg
+		if fg == goterm.ColorDefault() && bg == goterm.ColorDefault() {
+			style = goterm.StyleReverse
+		}
+	}
+
+	text := goterm.StyledText{}.Add(glyph+" ", c.fg, c.bg, goterm.StyleNone).Add(label, fg, bg, style)
+	x := drawSpans(s, 0, y, w, truncateSpans(text, w))
+	fillRow(s, x, y, w, c.bg, goterm.StyleNone)
+}
+
+// toggleWidth returns the width of a toggle with the given glyphs and label
+func toggleWidth(glyphs ToggleGlyphs, label string) int {
+	return max(goterm.StringWidth(glyphs.On), goterm.StringWidth(glyphs.Off)) + 1 + goterm.StringWidth(label)
+}
+
+// toggleEvent reports whether ev toggles a checkbox: Space, Enter or a left
+// click
+func toggleEvent(ev goterm.Event) bool {
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		return ev.Key == goterm.KeyEnter || (ev.Key == goterm.KeyRune && ev.Rune == ' ' && ev.Modifiers == 0)
+	case goterm.MouseEvent:
+		return ev.Button == goterm.MouseLeft && ev.Action == goterm.MousePress && ev.Y == 0
+	}
+	return false
+}
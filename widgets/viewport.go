@@ -0,0 +1,185 @@
+package widgets
+
+import "github.com/dshills/goterm"
+
+// Viewport shows a window onto a buffer of any size, such as a long report
+// or a large map, and scrolls it
+// The arrow keys scroll by one cell, Page Up and Page Down by a page, and
+// Home and End jump to the top and bottom; the mouse wheel scrolls
+// vertically, or horizontally with Shift held. With Scrollbars set, a
+// scrollbar is drawn along the right or bottom edge while the content does
+// not fit, and clicking or dragging on it scrolls.
+type Viewport struct {
+	focusState
+
+	Content     *goterm.Buffer
+	Scrollbars  bool
+	ScrollbarFg goterm.Color
+	ScrollbarBg goterm.Color
+
+	x, y          int  // Scroll offset
+	width, height int  // Content area at the last Draw
+	vbar, hbar    bool // Scrollbars shown at the last Draw
+}
+
+// Offset returns the content position shown at the top-left corner
+func (v *Viewport) Offset() (x, y int) {
+	return v.x, v.y
+}
+
+// ScrollTo scrolls so that content position (x, y) is at the top-left
+// corner, as far as the content allows
+func (v *Viewport) ScrollTo(x, y int) {
+	v.x, v.y = x, y
+	v.clamp()
+}
+
+// ScrollBy scrolls by dx columns and dy rows
+func (v *Viewport) ScrollBy(dx, dy int) {
+	v.ScrollTo(v.x+dx, v.y+dy)
+}
+
+// HandleEvent scrolls in response to ev
+func (v *Viewport) HandleEvent(ev goterm.Event) bool {
+	switch ev := ev.(type) {
+	case goterm.KeyEvent:
+		return v.handleKey(ev)
+	case goterm.MouseEvent:
+		return v.handleMouse(ev)
+	}
+	return false
+}
+
+// handleKey handles a key press
+func (v *Viewport) handleKey(ev goterm.KeyEvent) bool {
+	page := max(1, v.height-1)
+	switch ev.Key {
+	case goterm.KeyUp:
+		v.ScrollBy(0, -1)
+	case goterm.KeyDown:
+		v.ScrollBy(0, 1)
+	case goterm.KeyLeft:
+		v.ScrollBy(-1, 0)
+	case goterm.KeyRight:
+		v.ScrollBy(1, 0)
+	case goterm.KeyPageUp:
+		v.ScrollBy(0, -page)
+	case goterm.KeyPageDown:
+		v.ScrollBy(0, page)
+	case goterm.KeyHome:
+		v.ScrollTo(0, 0)
+	case goterm.KeyEnd:
+		v.ScrollTo(0, v.contentHeight())
+	default:
+		return false
+	}
+	return true
+}
+
+// handleMouse handles wheel scrolling and scrollbar clicks
+func (v *Viewport) handleMouse(ev goterm.MouseEvent) bool {
+	shift := ev.Modifiers&goterm.ModShift != 0
+	switch {
+	case ev.Button == goterm.MouseWheelUp && shift:
+		v.ScrollBy(-3, 0)
+	case ev.Button == goterm.MouseWheelDown && shift:
+		v.ScrollBy(3, 0)
+	case ev.Button == goterm.MouseWheelUp:
+		v.ScrollBy(0, -3)
+	case ev.Button == goterm.MouseWheelDown:
+		v.ScrollBy(0, 3)
+	case ev.Button != goterm.MouseLeft || (ev.Action != goterm.MousePress && ev.Action != goterm.MouseMotion):
+		return false
+	case v.vbar && ev.X == v.width && ev.Y < v.height:
+		v.ScrollTo(v.x, scrollbarOffset(ev.Y, v.height, v.contentHeight(), v.height))
+	case v.hbar && ev.Y == v.height && ev.X < v.width:
+		v.ScrollTo(scrollbarOffset(ev.X, v.width, v.contentWidth(), v.width), v.y)
+	default:
+		return false
+	}
+	return true
+}
+
+// PreferredSize returns the size of the content, plus the scrollbars it
+// needs if it does not fit in maxW×maxH
+func (v *Viewport) PreferredSize(maxW, maxH int) (w, h int) {
+	w, h = v.contentWidth(), v.contentHeight()
+	if v.Scrollbars {
+		vbar, hbar := h > maxH, w > maxW
+		w += boolInt(vbar)
+		h += boolInt(hbar)
+	}
+	return max(0, min(w, maxW)), max(0, min(h, maxH))
+}
+
+// Draw draws the visible part of the content and the scrollbars onto s
+func (v *Viewport) Draw(s goterm.Surface) {
+	w, h := s.Size()
+	v.layout(w, h)
+	v.clamp()
+
+	blank := goterm.NewCell(' ', goterm.ColorDefault(), goterm.ColorDefault(), goterm.StyleNone)
+	for y := 0; y < v.height; y++ {
+		for x := 0; x < v.width; x++ {
+			cell := blank
+			if v.Content != nil && v.x+x < v.contentWidth() && v.y+y < v.contentHeight() {
+				cell = v.Content.GetCell(v.x+x, v.y+y)
+			}
+			s.SetCell(x, y, cell)
+		}
+	}
+	if v.vbar {
+		drawVScrollbar(s, v.width, v.height, v.contentHeight(), v.height, v.y, v.ScrollbarFg, v.ScrollbarBg)
+	}
+	if v.hbar {
+		drawHScrollbar(s, v.height, v.width, v.contentWidth(), v.width, v.x, v.ScrollbarFg, v.ScrollbarBg)
+	}
+	if v.vbar && v.hbar {
+		s.SetCell(v.width, v.height, goterm.NewCell(' ', v.ScrollbarFg, v.ScrollbarBg, goterm.StyleNone))
+	}
+}
+
+// layout sizes the content area of a w×h viewport, leaving room for the
+// scrollbars the content needs
+func (v *Viewport) layout(w, h int) {
+	v.vbar, v.hbar = false, false
+	if v.Scrollbars {
+		v.vbar = v.contentHeight() > h
+		v.hbar = v.contentWidth() > w-boolInt(v.vbar)
+		v.vbar = v.contentHeight() > h-boolInt(v.hbar)
+	}
+	v.width = max(0, w-boolInt(v.vbar))
+	v.height = max(0, h-boolInt(v.hbar))
+}
+
+// clamp keeps the scroll offset within the content
+func (v *Viewport) clamp() {
+	v.x = max(0, min(v.x, v.contentWidth()-v.width))
+	v.y = max(0, min(v.y, v.contentHeight()-v.height))
+}
+
+// contentWidth returns the width of the content
+func (v *Viewport) contentWidth() int {
+	if v.Content == nil {
+		return 0
+	}
+	w, _ := v.Content.Size()
+	return w
+}
+
+// contentHeight returns the height of the content
+func (v *Viewport) contentHeight() int {
+	if v.Content == nil {
+		return 0
+	}
+	_, h := v.Content.Size()
+	return h
+}
+
+// boolInt returns 1 for true and 0 for false
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}